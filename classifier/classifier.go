@@ -0,0 +1,204 @@
+// Package classifier decides whether crawled profiles and listings may be
+// seeded and streamed. Content is labeled and given a verdict by pluggable
+// classifiers, either rules loaded from a file or an external service.
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/jsonpb"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Verdict is the action to take on classified content. Verdicts are
+// ordered from least to most severe.
+type Verdict int
+
+const (
+	// Allow means the content is pinned and streamed as usual.
+	Allow Verdict = iota
+
+	// Quarantine means the content needs to be reviewed by a human.
+	Quarantine
+
+	// Block means the content is neither pinned nor streamed.
+	Block
+)
+
+var verdictNames = map[Verdict]string{
+	Allow:      "allow",
+	Quarantine: "quarantine",
+	Block:      "block",
+}
+
+// String returns the name of the verdict.
+func (v Verdict) String() string {
+	if name, ok := verdictNames[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("verdict(%d)", int(v))
+}
+
+// ParseVerdict returns the verdict with the given name. The name is
+// case insensitive and an empty name is Allow.
+func ParseVerdict(name string) (Verdict, error) {
+	if name == "" {
+		return Allow, nil
+	}
+	for v, n := range verdictNames {
+		if strings.EqualFold(n, name) {
+			return v, nil
+		}
+	}
+	return Allow, fmt.Errorf("unknown verdict %q", name)
+}
+
+// MarshalText encodes the verdict as its name.
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes the verdict from its name.
+func (v *Verdict) UnmarshalText(b []byte) error {
+	verdict, err := ParseVerdict(string(b))
+	if err != nil {
+		return err
+	}
+	*v = verdict
+	return nil
+}
+
+// Content is a crawled profile or listing to classify. Exactly one of
+// Profile and Listing is set.
+type Content struct {
+	PeerID  string
+	Profile *models.Profile
+	Listing *obpb.SignedListing
+}
+
+// Result is the outcome of classifying content.
+type Result struct {
+	Verdict Verdict  `json:"verdict"`
+	Labels  []string `json:"labels"`
+}
+
+// Classifier labels content and decides what to do with it.
+type Classifier interface {
+	Classify(ctx context.Context, content *Content) (*Result, error)
+}
+
+// Chain runs each of its classifiers on the content. The result has the
+// most severe of their verdicts and the union of their labels.
+type Chain []Classifier
+
+// Classify runs the classifiers in order. It fails if any of them do.
+func (ch Chain) Classify(ctx context.Context, content *Content) (*Result, error) {
+	var results []*Result
+	for _, c := range ch {
+		res, err := c.Classify(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return Merge(results...), nil
+}
+
+// Merge combines results into one with the most severe verdict and the
+// sorted union of the labels.
+func Merge(results ...*Result) *Result {
+	var (
+		merged = &Result{Verdict: Allow}
+		seen   = make(map[string]bool)
+	)
+	for _, res := range results {
+		if res == nil {
+			continue
+		}
+		if res.Verdict > merged.Verdict {
+			merged.Verdict = res.Verdict
+		}
+		for _, label := range res.Labels {
+			if label != "" && !seen[label] {
+				seen[label] = true
+				merged.Labels = append(merged.Labels, label)
+			}
+		}
+	}
+	sort.Strings(merged.Labels)
+	return merged
+}
+
+// HTTPClassifier delegates classification to an external service. The
+// content is POSTed to the URL as JSON:
+//
+//	{"peerID": "Qm...", "profile": {...}}
+//	{"peerID": "Qm...", "listing": {...}}
+//
+// The service responds with the result:
+//
+//	{"verdict": "quarantine", "labels": ["weapons"]}
+type HTTPClassifier struct {
+	URL    string
+	Client *http.Client
+}
+
+type httpRequest struct {
+	PeerID  string          `json:"peerID"`
+	Profile *models.Profile `json:"profile,omitempty"`
+	Listing json.RawMessage `json:"listing,omitempty"`
+}
+
+// Classify posts the content to the URL and decodes the result.
+func (h *HTTPClassifier) Classify(ctx context.Context, content *Content) (*Result, error) {
+	req := httpRequest{
+		PeerID:  content.PeerID,
+		Profile: content.Profile,
+	}
+	if content.Listing != nil {
+		s, err := (&jsonpb.Marshaler{}).MarshalToString(content.Listing)
+		if err != nil {
+			return nil, err
+		}
+		req.Listing = json.RawMessage(s)
+	}
+	body, err := json.Marshal(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: time.Second * 30}
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("classifier request returned status %d", resp.StatusCode)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var res Result
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
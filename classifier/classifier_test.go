@@ -0,0 +1,192 @@
+package classifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func newListing(title, description string, categories ...string) *Content {
+	return &Content{
+		PeerID: "QmVendor",
+		Listing: &obpb.SignedListing{
+			Listing: &obpb.Listing{
+				Slug: "item",
+				Item: &obpb.Listing_Item{
+					Title:       title,
+					Description: description,
+					Categories:  categories,
+				},
+			},
+		},
+	}
+}
+
+func TestRuleClassifier(t *testing.T) {
+	dir, err := ioutil.TempDir("", "classifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rulesPath := path.Join(dir, "rules.json")
+	rules := `[
+		{"label": "weapons", "verdict": "quarantine", "keywords": ["rifle", "ammo"]},
+		{"label": "drugs", "verdict": "block", "categories": ["Drugs"]},
+		{"label": "phishing", "verdict": "block", "content": "profile", "patterns": ["(?i)free\\s+bitcoin"]},
+		{"label": "ammunition", "keywords": ["ammo"]}
+	]`
+	if err := ioutil.WriteFile(rulesPath, []byte(rules), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := LoadRules(rulesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		content *Content
+		verdict Verdict
+		labels  []string
+	}{
+		{
+			content: newListing("Hunting Rifle", "Bolt action"),
+			verdict: Quarantine,
+			labels:  []string{"weapons"},
+		},
+		{
+			content: newListing("Rifled barrel", "Not a whole word match"),
+			verdict: Allow,
+		},
+		{
+			content: newListing("Ammo box", "", "drugs"),
+			verdict: Block,
+			labels:  []string{"ammunition", "drugs", "weapons"},
+		},
+		{
+			content: newListing("Free bitcoin", "Phishing rules only apply to profiles"),
+			verdict: Allow,
+		},
+		{
+			content: &Content{PeerID: "QmVendor", Profile: &models.Profile{Name: "Scammer", About: "Get FREE  bitcoin now"}},
+			verdict: Block,
+			labels:  []string{"phishing"},
+		},
+	}
+	for i, test := range tests {
+		res, err := rc.Classify(context.Background(), test.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Verdict != test.verdict || !reflect.DeepEqual(res.Labels, test.labels) {
+			t.Errorf("Test %d: expected %s %v, got %s %v", i, test.verdict, test.labels, res.Verdict, res.Labels)
+		}
+	}
+
+	for _, invalid := range [][]Rule{
+		{{Keywords: []string{"unlabeled"}}},
+		{{Label: "empty"}},
+		{{Label: "pattern", Patterns: []string{"("}}},
+		{{Label: "content", Content: "rating", Keywords: []string{"x"}}},
+	} {
+		if _, err := NewRuleClassifier(invalid); err == nil {
+			t.Errorf("Expected rule %s to be invalid", invalid[0].Label)
+		}
+	}
+}
+
+func TestHTTPClassifier(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			PeerID  string `json:"peerID"`
+			Listing struct {
+				Listing struct {
+					Item struct {
+						Title string `json:"title"`
+					} `json:"item"`
+				} `json:"listing"`
+			} `json:"listing"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PeerID != "QmVendor" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.Listing.Listing.Item.Title == "Counterfeit watch" {
+			w.Write([]byte(`{"verdict": "block", "labels": ["counterfeit"]}`))
+			return
+		}
+		w.Write([]byte(`{"verdict": "allow"}`))
+	}))
+	defer ts.Close()
+
+	h := &HTTPClassifier{URL: ts.URL}
+	res, err := h.Classify(context.Background(), newListing("Counterfeit watch", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verdict != Block || !reflect.DeepEqual(res.Labels, []string{"counterfeit"}) {
+		t.Errorf("Expected block [counterfeit], got %s %v", res.Verdict, res.Labels)
+	}
+
+	res, err = h.Classify(context.Background(), newListing("Watch", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verdict != Allow || len(res.Labels) != 0 {
+		t.Errorf("Expected allow, got %s %v", res.Verdict, res.Labels)
+	}
+
+	h.URL = ts.URL + "/missing"
+	if _, err := h.Classify(context.Background(), &Content{PeerID: "Qm"}); err == nil {
+		t.Error("Expected an error for a bad request")
+	}
+}
+
+type staticClassifier struct {
+	res *Result
+	err error
+}
+
+func (s *staticClassifier) Classify(ctx context.Context, content *Content) (*Result, error) {
+	return s.res, s.err
+}
+
+func TestChain(t *testing.T) {
+	chain := Chain{
+		&staticClassifier{res: &Result{Verdict: Quarantine, Labels: []string{"b", "a"}}},
+		&staticClassifier{res: &Result{Verdict: Allow, Labels: []string{"a", "c"}}},
+	}
+	res, err := chain.Classify(context.Background(), &Content{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verdict != Quarantine || !reflect.DeepEqual(res.Labels, []string{"a", "b", "c"}) {
+		t.Errorf("Expected quarantine [a b c], got %s %v", res.Verdict, res.Labels)
+	}
+
+	chain = append(chain, &staticClassifier{err: errors.New("unavailable")})
+	if _, err := chain.Classify(context.Background(), &Content{}); err == nil {
+		t.Error("Expected the chain to fail")
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	for name, expected := range map[string]Verdict{"": Allow, "ALLOW": Allow, "quarantine": Quarantine, "Block": Block} {
+		v, err := ParseVerdict(name)
+		if err != nil || v != expected {
+			t.Errorf("Expected %s for %q, got %s %v", expected, name, v, err)
+		}
+	}
+	if _, err := ParseVerdict("delete"); err == nil {
+		t.Error("Expected an unknown verdict to fail")
+	}
+}
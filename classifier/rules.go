@@ -0,0 +1,151 @@
+package classifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+// Content types a rule may be restricted to.
+const (
+	ContentProfile = "profile"
+	ContentListing = "listing"
+)
+
+// Rule labels content matching any of its keywords, patterns or
+// categories. Keywords match whole words regardless of case, patterns are
+// regular expressions and categories match a listing's categories exactly
+// regardless of case. The text matched is the profile's name, handle,
+// location and descriptions or the listing's slug, title, description,
+// tags, categories and terms. If Content is set the rule only applies to
+// that type of content.
+type Rule struct {
+	Label      string   `json:"label"`
+	Verdict    Verdict  `json:"verdict"`
+	Content    string   `json:"content"`
+	Keywords   []string `json:"keywords"`
+	Patterns   []string `json:"patterns"`
+	Categories []string `json:"categories"`
+}
+
+type compiledRule struct {
+	Rule
+	exprs      []*regexp.Regexp
+	categories map[string]bool
+}
+
+// RuleClassifier classifies content with a list of rules. The result has
+// the label of every matching rule and the most severe of their verdicts.
+type RuleClassifier struct {
+	rules []compiledRule
+}
+
+// LoadRules reads the rules from a JSON file holding a list of rules.
+// For example:
+//
+//	[
+//	  {"label": "weapons", "verdict": "quarantine", "keywords": ["rifle", "ammo"]},
+//	  {"label": "drugs", "verdict": "block", "categories": ["Drugs"]},
+//	  {"label": "phishing", "verdict": "block", "content": "profile", "patterns": ["(?i)free\\s+bitcoin"]}
+//	]
+func LoadRules(path string) (*RuleClassifier, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, err
+	}
+	return NewRuleClassifier(rules)
+}
+
+// NewRuleClassifier compiles the rules into a classifier.
+func NewRuleClassifier(rules []Rule) (*RuleClassifier, error) {
+	rc := &RuleClassifier{}
+	for i, rule := range rules {
+		if rule.Label == "" {
+			return nil, fmt.Errorf("rule %d has no label", i)
+		}
+		if rule.Content != "" && rule.Content != ContentProfile && rule.Content != ContentListing {
+			return nil, fmt.Errorf("rule %s has unknown content type %q", rule.Label, rule.Content)
+		}
+		if len(rule.Keywords) == 0 && len(rule.Patterns) == 0 && len(rule.Categories) == 0 {
+			return nil, fmt.Errorf("rule %s matches nothing", rule.Label)
+		}
+		cr := compiledRule{Rule: rule, categories: make(map[string]bool)}
+		for _, kw := range rule.Keywords {
+			kw = strings.TrimSpace(kw)
+			if kw == "" {
+				return nil, fmt.Errorf("rule %s has an empty keyword", rule.Label)
+			}
+			cr.exprs = append(cr.exprs, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(kw)+`\b`))
+		}
+		for _, p := range rule.Patterns {
+			expr, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %s", rule.Label, err)
+			}
+			cr.exprs = append(cr.exprs, expr)
+		}
+		for _, cat := range rule.Categories {
+			cr.categories[strings.ToLower(cat)] = true
+		}
+		rc.rules = append(rc.rules, cr)
+	}
+	return rc, nil
+}
+
+// Classify matches the content against each rule.
+func (rc *RuleClassifier) Classify(ctx context.Context, content *Content) (*Result, error) {
+	var (
+		contentType string
+		text        []string
+		categories  []string
+	)
+	if content.Profile != nil {
+		p := content.Profile
+		contentType = ContentProfile
+		text = append(text, p.Name, p.Handle, p.Location, p.ShortDescription, p.About)
+		if p.ModeratorInfo != nil {
+			text = append(text, p.ModeratorInfo.Description, p.ModeratorInfo.TermsAndConditions)
+		}
+	} else if content.Listing != nil {
+		l := content.Listing.GetListing()
+		contentType = ContentListing
+		categories = l.GetItem().GetCategories()
+		text = append(text, l.GetSlug(), l.GetItem().GetTitle(), l.GetItem().GetDescription(), l.GetTermsAndConditions(), l.GetRefundPolicy())
+		text = append(text, l.GetItem().GetTags()...)
+		text = append(text, categories...)
+	}
+
+	var results []*Result
+	for _, rule := range rc.rules {
+		if rule.Content != "" && rule.Content != contentType {
+			continue
+		}
+		if rule.matches(text, categories) {
+			results = append(results, &Result{Verdict: rule.Verdict, Labels: []string{rule.Label}})
+		}
+	}
+	return Merge(results...), nil
+}
+
+func (r *compiledRule) matches(text, categories []string) bool {
+	for _, cat := range categories {
+		if r.categories[strings.ToLower(cat)] {
+			return true
+		}
+	}
+	for _, expr := range r.exprs {
+		for _, t := range text {
+			if expr.MatchString(t) {
+				return true
+			}
+		}
+	}
+	return false
+}
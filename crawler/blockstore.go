@@ -0,0 +1,169 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	obrepo "github.com/cpacia/openbazaar3.0/repo"
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	"github.com/ipfs/go-ipfs/core/corerepo"
+	"github.com/ipfs/go-ipfs/gc"
+	"github.com/ipfs/go-ipfs/repo/fsrepo"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-merkledag"
+	"io/ioutil"
+	"os"
+	"path"
+)
+
+// defaultBlocksPath is the path of the flatfs blockstore relative to
+// a node's IPFS repo.
+const defaultBlocksPath = "blocks"
+
+// configureBlockstore points the flatfs blockstore of the node's IPFS repo
+// at blocksPath. This must be done before the node is started. Passing an
+// absolute path shared by all the nodes gives them a single deduplicated
+// blockstore while the rest of their datastores, which hold their keys,
+// DHT records and pins, remain separate.
+//
+// The repo is initialized first if needed. Blocks already in the old
+// location are not moved.
+func configureBlockstore(dataDir, blocksPath string) error {
+	r, err := obrepo.NewRepo(dataDir)
+	if err != nil {
+		return err
+	}
+	r.Close()
+
+	ipfsDir := path.Join(dataDir, "ipfs")
+	configPath := path.Join(ipfsDir, "config")
+	configBytes, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(configBytes, &cfg); err != nil {
+		return err
+	}
+	ds, ok := cfg["Datastore"].(map[string]interface{})
+	if !ok {
+		return errors.New("ipfs config is missing datastore")
+	}
+	spec, ok := ds["Spec"].(map[string]interface{})
+	if !ok {
+		return errors.New("ipfs config is missing datastore spec")
+	}
+	if !setFlatfsPath(spec, blocksPath) {
+		return nil
+	}
+
+	dsc, err := fsrepo.AnyDatastoreConfig(spec)
+	if err != nil {
+		return err
+	}
+	configBytes, err = json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(configPath, configBytes, os.ModePerm); err != nil {
+		return err
+	}
+	// The spec on disk has to match the config or the repo will refuse to open.
+	if err := ioutil.WriteFile(path.Join(ipfsDir, "datastore_spec"), dsc.DiskSpec().Bytes(), os.ModePerm); err != nil {
+		return err
+	}
+	log.Infof("Blockstore for %s moved to %s", dataDir, blocksPath)
+	return nil
+}
+
+// setFlatfsPath sets the path of any flatfs datastore in the spec and
+// returns whether the spec was changed.
+func setFlatfsPath(spec map[string]interface{}, p string) bool {
+	changed := false
+	if spec["type"] == "flatfs" && spec["path"] != p {
+		spec["path"] = p
+		changed = true
+	}
+	if child, ok := spec["child"].(map[string]interface{}); ok {
+		changed = setFlatfsPath(child, p) || changed
+	}
+	if mounts, ok := spec["mounts"].([]interface{}); ok {
+		for _, m := range mounts {
+			if mount, ok := m.(map[string]interface{}); ok {
+				changed = setFlatfsPath(mount, p) || changed
+			}
+		}
+	}
+	return changed
+}
+
+// collectSharedGarbage garbage collects the blockstore shared by the
+// crawler's IPFS nodes. Running GC on a single node would delete the blocks
+// pinned by the others, so the GC lock of every node is held while the
+// union of all their pins is marked. Unmarked blocks are then deleted
+// through every node so that their blockstore caches stay correct.
+func (c *Crawler) collectSharedGarbage(ctx context.Context) (uint64, error) {
+	var stores []blockstore.GCBlockstore
+	for _, n := range c.nodes {
+		bs := n.IPFSNode().Blockstore
+		unlocker := bs.GCLock()
+		defer unlocker.Unlock()
+		stores = append(stores, bs)
+	}
+
+	output := make(chan gc.Result, 128)
+	done := make(chan struct{})
+	go func() {
+		for res := range output {
+			if res.Error != nil {
+				log.Warningf("Shared blockstore GC: %s", res.Error)
+			}
+		}
+		close(done)
+	}()
+	defer func() {
+		close(output)
+		<-done
+	}()
+
+	marked := cid.NewSet()
+	for _, n := range c.nodes {
+		ipfsNode := n.IPFSNode()
+		roots, err := corerepo.BestEffortRoots(ipfsNode.FilesRoot)
+		if err != nil {
+			return 0, err
+		}
+		ng := merkledag.NewDAGService(blockservice.New(ipfsNode.Blockstore, offline.Exchange(ipfsNode.Blockstore)))
+		set, err := gc.ColoredSet(ctx, ipfsNode.Pinning, ng, roots, output)
+		if err != nil {
+			return 0, err
+		}
+		if err := set.ForEach(func(id cid.Cid) error {
+			marked.Add(id)
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	keys, err := stores[0].AllKeysChan(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var removed uint64
+	for id := range keys {
+		if marked.Has(id) {
+			continue
+		}
+		for _, bs := range stores {
+			if err := bs.DeleteBlock(id); err != nil && !errors.Is(err, ipld.ErrNotFound) && !errors.Is(err, blockstore.ErrNotFound) {
+				log.Warningf("Error deleting block %s from shared blockstore: %s", id, err)
+			}
+		}
+		removed++
+	}
+	return removed, ctx.Err()
+}
@@ -0,0 +1,120 @@
+package crawler
+
+import (
+	"context"
+	"github.com/cpacia/openbazaar3.0/core"
+	files "github.com/ipfs/go-ipfs-files"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/ipfs/go-ipfs/repo/fsrepo"
+	"github.com/ipfs/interface-go-ipfs-core/options"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+)
+
+func TestConfigureBlockstore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "obcrawler")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	shared := path.Join(dir, "blocks")
+	for i := 0; i < 2; i++ {
+		nodeDir := path.Join(dir, "nodes", strconv.Itoa(i))
+		if err := configureBlockstore(nodeDir, shared); err != nil {
+			t.Fatal(err)
+		}
+		r, err := fsrepo.Open(path.Join(nodeDir, "ipfs"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := r.Config()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if setFlatfsPath(cfg.Datastore.Spec, shared) {
+			t.Errorf("Expected node %d to use the shared blockstore", i)
+		}
+		r.Close()
+	}
+	if _, err := os.Stat(shared); err != nil {
+		t.Errorf("Expected shared blockstore to be created: %s", err)
+	}
+
+	// Switching back to a per-node blockstore.
+	nodeDir := path.Join(dir, "nodes", "0")
+	if err := configureBlockstore(nodeDir, defaultBlocksPath); err != nil {
+		t.Fatal(err)
+	}
+	r, err := fsrepo.Open(path.Join(nodeDir, "ipfs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := r.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setFlatfsPath(cfg.Datastore.Spec, defaultBlocksPath) {
+		t.Error("Expected node to use its own blockstore")
+	}
+	r.Close()
+}
+
+func TestCrawler_CollectSharedGarbage(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	c := &Crawler{nodes: mn.Nodes()}
+	ctx := context.Background()
+
+	api0, err := coreapi.NewCoreAPI(mn.Nodes()[0].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	api1, err := coreapi.NewCoreAPI(mn.Nodes()[1].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pinned, err := api0.Unixfs().Add(ctx, files.NewBytesFile([]byte("pinned by node 0")), options.Unixfs.Pin(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Stored by node 0 but only pinned by node 1.
+	other, err := api0.Unixfs().Add(ctx, files.NewBytesFile([]byte("pinned by node 1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api1.Unixfs().Add(ctx, files.NewBytesFile([]byte("pinned by node 1")), options.Unixfs.Pin(true)); err != nil {
+		t.Fatal(err)
+	}
+	garbage, err := api0.Unixfs().Add(ctx, files.NewBytesFile([]byte("not pinned")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := c.collectSharedGarbage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed == 0 {
+		t.Error("Expected blocks to be removed")
+	}
+
+	bs := mn.Nodes()[0].IPFSNode().Blockstore
+	if has, err := bs.Has(pinned.Cid()); err != nil || !has {
+		t.Error("Expected block pinned by node 0 to be kept")
+	}
+	if has, err := bs.Has(other.Cid()); err != nil || !has {
+		t.Error("Expected block pinned by node 1 to be kept")
+	}
+	if has, err := bs.Has(garbage.Cid()); err != nil || has {
+		t.Error("Expected unpinned block to be removed")
+	}
+}
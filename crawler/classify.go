@@ -0,0 +1,150 @@
+package crawler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/cpacia/obcrawler/classifier"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/ipfs/go-cid"
+	"gorm.io/gorm"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+const (
+	// classifyTimeout is how long to wait for the classifiers to
+	// classify a single profile or listing.
+	classifyTimeout = time.Second * 30
+
+	// labelUnclassified labels content the classifiers failed on.
+	labelUnclassified = "unclassified"
+)
+
+// classify runs the crawler's classifiers on the content with the given
+// CID. Content is allowed if there are none. Verdicts are cached so content
+// is only classified once by the same classifiers. If the classifiers fail
+// the content is quarantined rather than seeded without being checked, and
+// is classified again on the next crawl.
+func (c *Crawler) classify(content *classifier.Content, id string) *classifier.Result {
+	if c.classifier == nil {
+		return &classifier.Result{Verdict: classifier.Allow}
+	}
+
+	var cached repo.ClassifierVerdict
+	err := c.db.View(func(db *gorm.DB) error {
+		return db.Where("c_id=? AND classifier=?", id, c.classifierID).First(&cached).Error
+	})
+	if err == nil {
+		verdict, err := classifier.ParseVerdict(cached.Verdict)
+		if err == nil {
+			res := &classifier.Result{Verdict: verdict}
+			if cached.Labels != "" {
+				res.Labels = strings.Split(cached.Labels, ",")
+			}
+			return res
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorf("Error loading classifier verdict for peer %s: %s", content.PeerID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, classifyTimeout)
+	defer cancel()
+
+	res, err := c.classifier.Classify(ctx, content)
+	if err != nil {
+		log.Errorf("Error classifying content for peer %s: %s", content.PeerID, err)
+		return &classifier.Result{Verdict: classifier.Quarantine, Labels: []string{labelUnclassified}}
+	}
+	err = c.db.Update(func(db *gorm.DB) error {
+		return db.Clauses(upsert).Create(&repo.ClassifierVerdict{
+			CID:        id,
+			Classifier: c.classifierID,
+			Verdict:    res.Verdict.String(),
+			Labels:     strings.Join(res.Labels, ","),
+		}).Error
+	})
+	if err != nil {
+		log.Errorf("Error saving classifier verdict for peer %s: %s", content.PeerID, err)
+	}
+	return res
+}
+
+// classifierID identifies the classifiers loaded from the rules file and
+// endpoint so cached verdicts are dropped when either changes.
+func classifierID(rulesFile, url string) (string, error) {
+	h := sha256.New()
+	if rulesFile != "" {
+		b, err := ioutil.ReadFile(rulesFile)
+		if err != nil {
+			return "", err
+		}
+		h.Write(b)
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(url))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// profileFiles adds the CIDs of the profile's images in every size.
+func profileFiles(files map[string]bool, p *models.Profile) {
+	for _, h := range []models.ImageHashes{p.AvatarHashes, p.HeaderHashes} {
+		for _, id := range []string{h.Tiny, h.Small, h.Medium, h.Large, h.Original} {
+			if id != "" {
+				files[id] = true
+			}
+		}
+	}
+}
+
+// listingFiles adds the CIDs of the listing's images in every size.
+func listingFiles(files map[string]bool, l *obpb.Listing) {
+	var images []*obpb.Listing_Item_Image
+	images = append(images, l.GetItem().GetImages()...)
+	for _, opt := range l.GetItem().GetOptions() {
+		for _, v := range opt.Variants {
+			if v.GetImage() != nil {
+				images = append(images, v.GetImage())
+			}
+		}
+	}
+	for _, img := range images {
+		for _, id := range []string{img.Tiny, img.Small, img.Medium, img.Large, img.Original} {
+			if id != "" {
+				files[id] = true
+			}
+		}
+	}
+}
+
+// withheldGraph returns the CIDs of the files of blocked or quarantined
+// content which aren't also used by allowed content. If the peer's graph
+// was cached the nodes under each file are included too.
+func (c *Crawler) withheldGraph(node uint, withheld, allowed map[string]bool, cached bool) map[cid.Cid]bool {
+	excluded := make(map[cid.Cid]bool)
+	for s := range withheld {
+		if allowed[s] {
+			continue
+		}
+		id, err := cid.Decode(s)
+		if err != nil {
+			continue
+		}
+		excluded[id] = true
+		if !cached {
+			continue
+		}
+		sub, _, err := c.fetchGraph(c.nodes[node].IPFSNode(), &id, 0)
+		if err != nil {
+			log.Warningf("Error fetching graph of withheld file %s: %s", s, err)
+		}
+		for _, k := range sub {
+			excluded[k] = true
+		}
+	}
+	return excluded
+}
@@ -0,0 +1,123 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/obcrawler/classifier"
+	"github.com/cpacia/obcrawler/repo"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"gorm.io/gorm"
+	"reflect"
+	"testing"
+)
+
+type failingClassifier struct{}
+
+func (failingClassifier) Classify(ctx context.Context, content *classifier.Content) (*classifier.Result, error) {
+	return nil, errors.New("unavailable")
+}
+
+// countingClassifier counts the content it classifies.
+type countingClassifier struct {
+	classifier.Classifier
+	calls int
+}
+
+func (cc *countingClassifier) Classify(ctx context.Context, content *classifier.Content) (*classifier.Result, error) {
+	cc.calls++
+	return cc.Classifier.Classify(ctx, content)
+}
+
+func TestCrawler_Classify(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{ctx: context.Background(), db: db, classifierID: "rules-v1"}
+	content := &classifier.Content{
+		PeerID: "QmVendor",
+		Listing: &obpb.SignedListing{
+			Listing: &obpb.Listing{Item: &obpb.Listing_Item{Title: "Counterfeit watch"}},
+		},
+	}
+
+	// Without classifiers everything is allowed.
+	res := c.classify(content, "QmListing")
+	if res.Verdict != classifier.Allow || len(res.Labels) != 0 {
+		t.Errorf("Expected allow, got %s %v", res.Verdict, res.Labels)
+	}
+
+	rules, err := classifier.NewRuleClassifier([]classifier.Rule{
+		{Label: "counterfeit", Verdict: classifier.Block, Keywords: []string{"counterfeit"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter := &countingClassifier{Classifier: rules}
+	c.classifier = counter
+	for i := 0; i < 2; i++ {
+		res = c.classify(content, "QmListing")
+		if res.Verdict != classifier.Block || !reflect.DeepEqual(res.Labels, []string{"counterfeit"}) {
+			t.Errorf("Expected block [counterfeit], got %s %v", res.Verdict, res.Labels)
+		}
+	}
+	if counter.calls != 1 {
+		t.Errorf("Expected the cached verdict to be used, got %d calls", counter.calls)
+	}
+
+	// New content and changed classifiers are classified again.
+	c.classify(content, "QmOther")
+	c.classifierID = "rules-v2"
+	c.classify(content, "QmListing")
+	if counter.calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", counter.calls)
+	}
+
+	// Content is quarantined if a classifier fails and the failure
+	// isn't cached.
+	c.classifier = classifier.Chain{rules, failingClassifier{}}
+	for i := 0; i < 2; i++ {
+		res = c.classify(content, "QmFailed")
+		if res.Verdict != classifier.Quarantine || !reflect.DeepEqual(res.Labels, []string{labelUnclassified}) {
+			t.Errorf("Expected quarantine [%s], got %s %v", labelUnclassified, res.Verdict, res.Labels)
+		}
+	}
+	err = db.View(func(db *gorm.DB) error {
+		var count int64
+		if err := db.Model(&repo.ClassifierVerdict{}).Where("c_id=?", "QmFailed").Count(&count).Error; err != nil {
+			return err
+		}
+		if count != 0 {
+			t.Error("Expected the failed classification not to be cached")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListingFiles(t *testing.T) {
+	l := &obpb.Listing{
+		Item: &obpb.Listing_Item{
+			Images: []*obpb.Listing_Item_Image{
+				{Original: "QmOriginal", Large: "QmLarge", Tiny: "QmTiny"},
+			},
+			Options: []*obpb.Listing_Item_Option{
+				{Variants: []*obpb.Listing_Item_Option_Variant{
+					{Name: "Red", Image: &obpb.Listing_Item_Image{Original: "QmRed", Small: "QmRedSmall"}},
+					{Name: "Blue"},
+				}},
+			},
+		},
+	}
+	files := make(map[string]bool)
+	listingFiles(files, l)
+	expected := map[string]bool{"QmOriginal": true, "QmLarge": true, "QmTiny": true, "QmRed": true, "QmRedSmall": true}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+	if len(l.Item.Images) != 1 {
+		t.Errorf("Expected the listing's images to be unchanged, got %d", len(l.Item.Images))
+	}
+}
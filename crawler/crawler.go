@@ -5,17 +5,16 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"github.com/cpacia/obcrawler/classifier"
+	"github.com/cpacia/obcrawler/pricing"
 	"github.com/cpacia/obcrawler/repo"
 	"github.com/cpacia/obcrawler/rpc"
 	"github.com/cpacia/openbazaar3.0/core"
 	obrepo "github.com/cpacia/openbazaar3.0/repo"
 	"github.com/gogo/protobuf/proto"
-	"github.com/ipfs/go-cid"
 	core2 "github.com/ipfs/go-ipfs/core"
-	"github.com/ipfs/go-ipfs/core/coreapi"
-	"github.com/ipfs/go-ipfs/core/corerepo"
 	ipnspb "github.com/ipfs/go-ipns/pb"
-	ipath "github.com/ipfs/interface-go-ipfs-core/path"
+	iface "github.com/ipfs/interface-go-ipfs-core"
 	crypto "github.com/libp2p/go-libp2p-core/crypto"
 	inet "github.com/libp2p/go-libp2p-core/network"
 	peer "github.com/libp2p/go-libp2p-core/peer"
@@ -24,53 +23,153 @@ import (
 	"gorm.io/gorm"
 	mrand "math/rand"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var log = logging.MustGetLogger("CRWLR")
 
+// bootstrapTimeout is how long StartNodes waits for each node
+// to connect to the network.
+const bootstrapTimeout = time.Second * 30
+
 // Crawler is an OpenBazaar network crawler which seeks to
 // scrape all new listings and profiles.
 type Crawler struct {
-	nodes         []*core.OpenBazaarNode
-	numPubsub     uint
-	numWorkers    uint
-	ipnsQuorum    uint
-	workChan      chan *job
-	cacheData     bool
-	pinFiles      bool
-	pinRecords    bool
-	subs          map[uint64]*rpc.Subscription
-	subMtx        sync.RWMutex
-	db            *repo.Database
-	ctx           context.Context
-	cancel        context.CancelFunc
-	crawlInterval time.Duration
-	grpcServer    *rpc.GrpcServer
-	resolver      *resolver
-	shutdown      chan struct{}
+	nodes             []*core.OpenBazaarNode
+	numPubsub         uint
+	numWorkers        uint
+	ipnsQuorum        uint
+	workChan          chan *job
+	cacheData         bool
+	pinFiles          bool
+	pinRecords        bool
+	sharedBlockstore  bool
+	sharedBlocksPath  string
+	subs              map[uint64]*rpc.Subscription
+	subMtx            sync.RWMutex
+	db                *repo.Database
+	pins              *pinManager
+	ring              *hashRing
+	ctx               context.Context
+	cancel            context.CancelFunc
+	crawlInterval     time.Duration
+	grpcServer        *rpc.GrpcServer
+	resolver          *resolver
+	pubsubTopics      []string
+	peerTopics        bool
+	maxPeerTopics     uint
+	messageChan       chan iface.PubSubMessage
+	pubsubSubs        []iface.PubSubSubscription
+	peerSubs          map[string]*peerTopic
+	pubsubMtx         sync.Mutex
+	republish         bool
+	republishInterval time.Duration
+	republishRate     uint
+	ipnsPinInterval   time.Duration
+	ipnsPinBatchSize  uint
+	graphFanout       uint
+	graphRetries      uint
+	graphMaxDepth     uint
+	graphMaxBytes     uint64
+	peerQuota         uint64
+	reconcileInterval time.Duration
+	recrawlInterval   time.Duration
+	recrawlAge        time.Duration
+	recrawlMinAge     time.Duration
+	recrawlMaxAge     time.Duration
+	recrawlBatchSize  uint
+	peerExpiry        time.Duration
+	observedExpiry    time.Duration
+	unpinInterval     time.Duration
+	unpinBatchSize    uint
+	activeWorkers     int32
+	pendingRecrawls   int32
+	prices            *pricing.Normalizer
+	rateInterval      time.Duration
+	classifier        classifier.Classifier
+	classifierID      string
+	dhtCrawl          bool
+	dhtCrawlInterval  time.Duration
+	dhtCrawlParallel  uint
+	gcInterval        time.Duration
+	gcHighWatermark   uint64
+	gcLowWatermark    uint64
+	gcEvictAfter      time.Duration
+	gcStagger         bool
+	shutdown          chan struct{}
 }
 
 // NewCrawler returns a new crawler with the given config options.
 func NewCrawler(cfg *repo.Config) (*Crawler, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	crawler := &Crawler{
-		ctx:           ctx,
-		cancel:        cancel,
-		workChan:      make(chan *job),
-		subs:          make(map[uint64]*rpc.Subscription),
-		subMtx:        sync.RWMutex{},
-		cacheData:     !cfg.DisableDataCaching,
-		pinFiles:      !cfg.DisableFilePinning,
-		pinRecords:    !cfg.DisableIPNSPinning,
-		numPubsub:     cfg.PubsubNodes,
-		numWorkers:    cfg.NumWorkers,
-		ipnsQuorum:    cfg.IPNSQuorum,
-		crawlInterval: cfg.CrawlInterval,
-		shutdown:      make(chan struct{}),
+		ctx:               ctx,
+		cancel:            cancel,
+		workChan:          make(chan *job),
+		subs:              make(map[uint64]*rpc.Subscription),
+		subMtx:            sync.RWMutex{},
+		cacheData:         !cfg.DisableDataCaching,
+		pinFiles:          !cfg.DisableFilePinning,
+		pinRecords:        !cfg.DisableIPNSPinning,
+		sharedBlockstore:  cfg.SharedBlockstore,
+		numPubsub:         cfg.PubsubNodes,
+		numWorkers:        cfg.NumWorkers,
+		ipnsQuorum:        cfg.IPNSQuorum,
+		crawlInterval:     cfg.CrawlInterval,
+		pubsubTopics:      cfg.PubsubTopics,
+		peerTopics:        !cfg.DisablePeerTopics,
+		maxPeerTopics:     cfg.MaxPeerTopics,
+		messageChan:       make(chan iface.PubSubMessage),
+		peerSubs:          make(map[string]*peerTopic),
+		pubsubMtx:         sync.Mutex{},
+		republish:         cfg.IPNSRepublish,
+		republishInterval: cfg.RepublishInterval,
+		republishRate:     cfg.RepublishRate,
+		ipnsPinInterval:   cfg.IPNSPinInterval,
+		ipnsPinBatchSize:  cfg.IPNSPinBatchSize,
+		graphFanout:       cfg.GraphFanout,
+		graphRetries:      cfg.GraphRetries,
+		graphMaxDepth:     cfg.GraphMaxDepth,
+		graphMaxBytes:     cfg.GraphMaxBytes,
+		peerQuota:         cfg.PeerQuota,
+		reconcileInterval: cfg.ReconcileInterval,
+		recrawlInterval:   cfg.RecrawlInterval,
+		recrawlAge:        cfg.RecrawlAge,
+		recrawlMinAge:     cfg.RecrawlMinAge,
+		recrawlMaxAge:     cfg.RecrawlMaxAge,
+		recrawlBatchSize:  cfg.RecrawlBatchSize,
+		peerExpiry:        cfg.PeerExpiry,
+		observedExpiry:    cfg.ObservedPeerExpiry,
+		unpinInterval:     cfg.UnpinInterval,
+		unpinBatchSize:    cfg.UnpinBatchSize,
+		rateInterval:      cfg.ExchangeRateInterval,
+		dhtCrawl:          cfg.DHTCrawl,
+		dhtCrawlInterval:  cfg.DHTCrawlInterval,
+		dhtCrawlParallel:  cfg.DHTCrawlParallelism,
+		gcInterval:        cfg.GCInterval,
+		gcHighWatermark:   cfg.GCHighWatermark,
+		gcLowWatermark:    cfg.GCLowWatermark,
+		gcEvictAfter:      cfg.GCEvictAfter,
+		gcStagger:         cfg.GCStagger,
+		shutdown:          make(chan struct{}),
+	}
+	crawler.ring = newHashRing(cfg.NumNodes, cfg.Replicas)
+	if len(crawler.pubsubTopics) == 0 {
+		crawler.pubsubTopics = []string{ipnsPubsubTopic}
+	}
+	blocksPath := defaultBlocksPath
+	if cfg.SharedBlockstore {
+		dataDir, err := filepath.Abs(cfg.DataDir)
+		if err != nil {
+			return nil, err
+		}
+		blocksPath = path.Join(dataDir, "blocks")
+		crawler.sharedBlocksPath = blocksPath
 	}
 	for i := 0; i < int(cfg.NumNodes); i++ {
 		nodeConfig := &obrepo.Config{
@@ -92,6 +191,10 @@ func NewCrawler(cfg *repo.Config) (*Crawler, error) {
 			nodeConfig.BoostrapAddrs = cfg.BoostrapAddrs
 		}
 
+		if err := configureBlockstore(nodeConfig.DataDir, blocksPath); err != nil {
+			return nil, err
+		}
+
 		n, err := core.NewNode(ctx, nodeConfig)
 		if err != nil {
 			return nil, err
@@ -124,6 +227,7 @@ func NewCrawler(cfg *repo.Config) (*Crawler, error) {
 	}
 
 	crawler.db = db
+	crawler.pins = newPinManager(ctx, crawler.nodes, crawler.ring, db)
 
 	if err := repo.CheckAndSetUlimit(); err != nil {
 		return nil, err
@@ -148,6 +252,39 @@ func NewCrawler(cfg *repo.Config) (*Crawler, error) {
 		crawler.resolver = newResolver(netAddrs, db, cfg)
 	}
 
+	var rateProvider pricing.RateProvider
+	if cfg.ExchangeRateFile != "" {
+		rateProvider = &pricing.FileProvider{Path: cfg.ExchangeRateFile}
+	} else if cfg.ExchangeRateURL != "" {
+		rateProvider = &pricing.HTTPProvider{URL: cfg.ExchangeRateURL}
+	}
+	crawler.prices = pricing.NewNormalizer(cfg.PriceCurrency, rateProvider)
+
+	var classifiers classifier.Chain
+	if cfg.ClassifierRules != "" {
+		rules, err := classifier.LoadRules(cfg.ClassifierRules)
+		if err != nil {
+			return nil, err
+		}
+		classifiers = append(classifiers, rules)
+	}
+	if cfg.ClassifierURL != "" {
+		classifiers = append(classifiers, &classifier.HTTPClassifier{URL: cfg.ClassifierURL})
+	}
+	if len(classifiers) > 0 {
+		crawler.classifier = classifiers
+		crawler.classifierID, err = classifierID(cfg.ClassifierRules, cfg.ClassifierURL)
+		if err != nil {
+			return nil, err
+		}
+		err = db.Update(func(db *gorm.DB) error {
+			return db.Where("classifier<>?", crawler.classifierID).Delete(&repo.ClassifierVerdict{}).Error
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return crawler, nil
 }
 
@@ -184,7 +321,6 @@ func (c *Crawler) CrawlNode(pid peer.ID) error {
 //
 // Once a node is banned it will no longer be crawled going forward.
 func (c *Crawler) BanNode(pid peer.ID) error {
-	var cidsRecs []repo.CIDRecord
 	err := c.db.Update(func(db *gorm.DB) error {
 		var peer repo.Peer
 		err := db.Where("peer_id=?", pid.Pretty()).First(&peer).Error
@@ -193,25 +329,18 @@ func (c *Crawler) BanNode(pid peer.ID) error {
 		}
 		peer.PeerID = pid.Pretty()
 		peer.Banned = true
-		if err := db.Save(&peer).Error; err != nil {
-			return err
-		}
-		return db.Where("peer_id=?", pid.Pretty()).Find(&cidsRecs).Error
+		return db.Save(&peer).Error
 	})
 	if err != nil {
 		return err
 	}
-	for _, rec := range cidsRecs {
-		id, err := cid.Decode(rec.CID)
-		if err != nil {
-			continue
-		}
-
-		if err := c.unpinCID(id); err != nil {
-			log.Error("Error unpinning data for banned node %s: %s", pid.String(), err)
-		}
+	c.leavePeerTopic(pid)
+	if err := c.removeFromIndex(pid.Pretty()); err != nil {
+		log.Errorf("Error removing banned node %s from search index: %s", pid.String(), err)
+	}
+	if err := c.pins.releasePeer(pid); err != nil {
+		log.Errorf("Error unpinning data for banned node %s: %s", pid.String(), err)
 	}
-
 	return nil
 }
 
@@ -230,12 +359,40 @@ func (c *Crawler) UnbanNode(pid peer.ID) error {
 	})
 }
 
+// GetQuota returns the size of the node's data as of the last crawl
+// and whether it exceeds the per-peer quota.
+func (c *Crawler) GetQuota(pid peer.ID) (*rpc.QuotaStatus, error) {
+	var peer repo.Peer
+	err := c.db.View(func(db *gorm.DB) error {
+		return db.Where("peer_id=?", pid.Pretty()).First(&peer).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("peer not found")
+	} else if err != nil {
+		return nil, err
+	}
+	return &rpc.QuotaStatus{
+		GraphSize: peer.GraphSize,
+		Quota:     c.peerQuota,
+		OverQuota: peer.OverQuota,
+	}, nil
+}
+
+// Reconcile compares the pin table against the pins held by each of the
+// crawler's IPFS nodes, repairs any differences and returns a report of
+// what was found.
+func (c *Crawler) Reconcile() (*PinReport, error) {
+	return c.pins.reconcile()
+}
+
 // Subscribe returns a subscription with a channel over which new profiles
-// and listings will be pushed when they are crawled.
-func (c *Crawler) Subscribe() (*rpc.Subscription, error) {
+// and listings will be pushed when they are crawled. Quarantined profiles
+// and listings are only pushed to moderator subscriptions.
+func (c *Crawler) Subscribe(moderator bool) (*rpc.Subscription, error) {
 	i := mrand.Uint64()
 	sub := &rpc.Subscription{
-		Out: make(chan *rpc.Object),
+		Moderator: moderator,
+		Out:       make(chan *rpc.Object),
 		Close: func() error {
 			c.subMtx.Lock()
 			defer c.subMtx.Unlock()
@@ -257,17 +414,39 @@ func (c *Crawler) Subscribe() (*rpc.Subscription, error) {
 	return sub, nil
 }
 
+// StartNodes brings the IPFS nodes online without starting the crawler
+// so that maintenance commands, such as reconcile, can fetch data from
+// the network. It waits up to bootstrapTimeout for each node to connect
+// to a peer. It must not be used together with Start.
+func (c *Crawler) StartNodes() {
+	for _, n := range c.nodes {
+		n.Start()
+	}
+	deadline := time.Now().Add(bootstrapTimeout)
+	for i, n := range c.nodes {
+		for len(n.IPFSNode().PeerHost.Network().Peers()) == 0 {
+			if time.Now().After(deadline) {
+				log.Warningf("Node %d failed to connect to the network", i)
+				break
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
+
 // Start will start the crawler and related processes.
 func (c *Crawler) Start() error {
 	for _, n := range c.nodes {
 		n.Start()
 		c.listenPeers(n.IPFSNode())
+		if err := c.listenIdentify(n.IPFSNode().PeerHost); err != nil {
+			return err
+		}
 	}
 	go func() {
 		crawlTicker := time.NewTicker(c.crawlInterval)
-		gcTicker := time.NewTicker(time.Hour * 24)
-		oldNodeTicker := time.NewTicker(time.Minute)
-		unPinTicker := time.NewTicker(time.Hour)
+		oldNodeTicker := time.NewTicker(c.recrawlInterval)
+		unPinTicker := time.NewTicker(c.unpinInterval)
 		for {
 			select {
 			case <-crawlTicker.C:
@@ -287,35 +466,38 @@ func (c *Crawler) Start() error {
 				if err != routing.ErrNotFound {
 					log.Errorf("Error crawling for more peers %s", err)
 				}
-			case <-gcTicker.C:
-				for _, n := range c.nodes {
-					corerepo.GarbageCollectAsync(n.IPFSNode(), c.ctx)
-				}
 			case <-oldNodeTicker.C:
+				batchSize := c.recrawlBatch()
+				if batchSize == 0 {
+					continue
+				}
 				var peers []repo.Peer
 				err := c.db.View(func(db *gorm.DB) error {
 					return db.Where("banned=?", false).
 						Where("ip_ns_expiration>?", time.Now()).
-						Where("last_crawled<?", time.Now().Add(-time.Hour*24*7)).
-						Where("last_seen>?", time.Now().Add(-time.Hour*24*90)).
-						Order("last_crawled asc").
-						Limit(10).
+						Where("next_crawl_at<?", time.Now()).
+						Where("last_seen>?", time.Now().Add(-c.peerExpiry)).
+						Order("next_crawl_at asc").
+						Limit(batchSize).
 						Find(&peers).Error
 				})
 				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 					log.Errorf("Error crawling loading old peers %s", err)
 					continue
 				}
+				atomic.AddInt32(&c.pendingRecrawls, int32(len(peers)))
 				go func() {
 					for _, p := range peers {
 						pid, err := peer.Decode(p.PeerID)
 						if err != nil {
 							log.Errorf("Error decoding peerID in old node loop: %s", err)
+							atomic.AddInt32(&c.pendingRecrawls, -1)
 							continue
 						}
 						rec := new(ipnspb.IpnsEntry)
 						if err := proto.Unmarshal(p.IPNSRecord, rec); err != nil {
 							log.Errorf("Error unmarshalling IPNS record for peer %s: %s", p.PeerID, err)
+							atomic.AddInt32(&c.pendingRecrawls, -1)
 							continue
 						}
 						c.workChan <- &job{
@@ -325,15 +507,20 @@ func (c *Crawler) Start() error {
 							PinRecord:      c.pinRecords,
 							Expiration:     p.IPNSExpiration,
 						}
+						// The peer is no longer pending once a worker has
+						// taken it.
+						atomic.AddInt32(&c.pendingRecrawls, -1)
 					}
 				}()
 			case <-unPinTicker.C:
+				// Release the pins of dead peers. Their data is only unpinned
+				// if no live peer references it.
 				var peers []repo.Peer
 				err := c.db.View(func(db *gorm.DB) error {
-					return db.Where("ip_ns_expiration>?", time.Now()).
-						Where("last_seen>?", time.Now().Add(-time.Hour*24*90)).
+					return db.Where("ip_ns_expiration<? OR last_seen<?", time.Now(), time.Now().Add(-c.peerExpiry)).
+						Where("peer_id IN (?)", db.Model(&repo.PinRef{}).Select("peer_id")).
 						Order("last_crawled asc").
-						Limit(10).
+						Limit(int(c.unpinBatchSize)).
 						Find(&peers).Error
 				})
 				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -342,40 +529,65 @@ func (c *Crawler) Start() error {
 				}
 				go func() {
 					for _, p := range peers {
-						var cidsRecs []repo.CIDRecord
-						err := c.db.View(func(db *gorm.DB) error {
-							return db.Where("peer_id=?", p.PeerID).Find(&cidsRecs).Error
-						})
-						if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-							log.Errorf("Error loading cid for dead peer %s", err)
+						pid, err := peer.Decode(p.PeerID)
+						if err != nil {
+							log.Errorf("Error decoding peerID in unpin loop: %s", err)
 							continue
 						}
-						for _, rec := range cidsRecs {
-							id, err := cid.Decode(rec.CID)
-							if err != nil {
-								continue
-							}
-
-							if err := c.unpinCID(id); err != nil {
-								log.Error("Error unpinning data for dead node %s: %s", p.PeerID, err)
-							}
+						if err := c.pins.releasePeer(pid); err != nil {
+							log.Errorf("Error unpinning data for dead node %s: %s", p.PeerID, err)
 						}
 					}
 				}()
 			case <-c.shutdown:
 				crawlTicker.Stop()
-				gcTicker.Stop()
 				oldNodeTicker.Stop()
+				unPinTicker.Stop()
 				return
 			}
 		}
 	}()
+	if _, err := c.pins.reconcile(); err != nil {
+		log.Errorf("Error reconciling pins: %s", err)
+	}
 	for i := 0; i < int(c.numWorkers); i++ {
 		go c.worker()
 	}
+	go c.runGC()
+	go c.runPruner()
+	if err := c.refreshPrices(); err != nil {
+		log.Errorf("Error loading exchange rates: %s", err)
+	}
+	go c.runRateRefresher()
+	if c.dhtCrawl {
+		go c.runDHTCrawler()
+	}
+	if c.reconcileInterval > 0 {
+		go c.runPinReconciler()
+	}
+	if c.republish {
+		go c.runRepublisher()
+	}
+	if c.pinRecords {
+		go c.runRecordPinner()
+	}
 	return c.listenPubsub()
 }
 
+// recrawlBatch returns the number of stale peers to queue for re-crawl.
+// This is the number of idle workers less the re-crawls still waiting
+// to be picked up, capped at the configured batch size.
+func (c *Crawler) recrawlBatch() int {
+	idle := int(c.numWorkers) - int(atomic.LoadInt32(&c.activeWorkers)) - int(atomic.LoadInt32(&c.pendingRecrawls))
+	if idle < 0 {
+		return 0
+	}
+	if idle > int(c.recrawlBatchSize) {
+		return int(c.recrawlBatchSize)
+	}
+	return idle
+}
+
 // Stop shuts down the crawler.
 func (c *Crawler) Stop() error {
 	close(c.shutdown)
@@ -391,38 +603,35 @@ func (c *Crawler) Stop() error {
 func (c *Crawler) notifySubscribers(obj *rpc.Object) {
 	c.subMtx.RLock()
 	for _, sub := range c.subs {
+		if obj.Quarantined && !sub.Moderator {
+			continue
+		}
 		sub.Out <- obj
 	}
 	c.subMtx.RUnlock()
 }
 
-func (c *Crawler) unpinCID(id cid.Cid) error {
+// hasSubscribers returns whether any subscription is open.
+func (c *Crawler) hasSubscribers() bool {
+	c.subMtx.RLock()
+	defer c.subMtx.RUnlock()
+	return len(c.subs) > 0
+}
+
+// isOwnNode returns whether the peer ID belongs to one of
+// the crawler's IPFS nodes.
+func (c *Crawler) isOwnNode(pid peer.ID) bool {
 	for _, n := range c.nodes {
-		capi, err := coreapi.NewCoreAPI(n.IPFSNode())
-		if err != nil {
-			return err
+		if n.Identity() == pid {
+			return true
 		}
-		capi.Pin().Rm(c.ctx, ipath.IpfsPath(id))
 	}
-	return nil
+	return false
 }
 
 func (c *Crawler) listenPeers(n *core2.IpfsNode) {
 	updatePeer := func(_ inet.Network, conn inet.Conn) {
-		err := c.db.Update(func(db *gorm.DB) error {
-			var peer repo.Peer
-			err := db.Where("peer_id=?", conn.RemotePeer().Pretty()).First(&peer).Error
-			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-				return err
-			} else if errors.Is(err, gorm.ErrRecordNotFound) {
-				peer.FirstSeen = time.Now()
-				peer.PeerID = conn.RemotePeer().Pretty()
-				log.Infof("Detected new peer: %s", conn.RemotePeer().Pretty())
-			}
-			peer.LastSeen = time.Now()
-			return db.Save(&peer).Error
-		})
-		if err != nil {
+		if _, err := c.markSeen(conn.RemotePeer()); err != nil {
 			log.Errorf("Error updating database on peer connection: %s", err)
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/cpacia/openbazaar3.0/models"
 	"github.com/cpacia/openbazaar3.0/models/factory"
 	"github.com/cpacia/openbazaar3.0/orders/pb"
+	iface "github.com/ipfs/interface-go-ipfs-core"
 	"gorm.io/gorm"
 	"sync"
 	"testing"
@@ -29,6 +30,13 @@ func mockCrawler() (*Crawler, *core.Mocknet, error) {
 		numWorkers:    2,
 		ipnsQuorum:    4,
 		crawlInterval: time.Minute,
+		pubsubTopics:  []string{ipnsPubsubTopic},
+		peerTopics:    true,
+		maxPeerTopics: 100,
+		graphFanout:   4,
+		messageChan:   make(chan iface.PubSubMessage),
+		peerSubs:      make(map[string]*peerTopic),
+		pubsubMtx:     sync.Mutex{},
 		shutdown:      make(chan struct{}),
 	}
 	mocknet, err := core.NewMocknet(3)
@@ -36,6 +44,7 @@ func mockCrawler() (*Crawler, *core.Mocknet, error) {
 		return nil, nil, err
 	}
 	crawler.nodes = mocknet.Nodes()[:2]
+	crawler.ring = newHashRing(2, 0)
 
 	db, err := repo.NewDatabase("", repo.Dialect("test"))
 	if err != nil {
@@ -43,6 +52,7 @@ func mockCrawler() (*Crawler, *core.Mocknet, error) {
 	}
 
 	crawler.db = db
+	crawler.pins = newPinManager(ctx, crawler.nodes, crawler.ring, db)
 
 	go crawler.worker()
 	go crawler.worker()
@@ -118,7 +128,7 @@ func TestCrawler_Subscribe(t *testing.T) {
 		t.Fatal("Timed out waiting on publish")
 	}
 
-	sub, err := crawler.Subscribe()
+	sub, err := crawler.Subscribe(false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -223,7 +233,7 @@ func TestCrawler_CrawlNode(t *testing.T) {
 
 	defer mn.TearDown()
 
-	sub, err := crawler.Subscribe()
+	sub, err := crawler.Subscribe(false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -321,3 +331,28 @@ func TestCrawler_BanNode(t *testing.T) {
 		t.Error("Peer should not have been set to banned in the db")
 	}
 }
+
+func TestCrawler_RecrawlBatch(t *testing.T) {
+	tests := []struct {
+		active   int32
+		pending  int32
+		expected int
+	}{
+		{0, 0, 10},
+		{4, 0, 8},
+		{4, 3, 5},
+		{12, 0, 0},
+		{10, 5, 0},
+	}
+	for _, test := range tests {
+		c := &Crawler{
+			numWorkers:       12,
+			recrawlBatchSize: 10,
+			activeWorkers:    test.active,
+			pendingRecrawls:  test.pending,
+		}
+		if batch := c.recrawlBatch(); batch != test.expected {
+			t.Errorf("Expected batch of %d with %d active and %d pending, got %d", test.expected, test.active, test.pending, batch)
+		}
+	}
+}
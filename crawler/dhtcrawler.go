@@ -0,0 +1,155 @@
+package crawler
+
+import (
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	obnet "github.com/cpacia/openbazaar3.0/net"
+	"github.com/libp2p/go-libp2p-core/host"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	dhtcrawler "github.com/libp2p/go-libp2p-kad-dht/crawler"
+	"gorm.io/gorm"
+	mrand "math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// runDHTCrawler periodically enumerates the DHT from a random node.
+func (c *Crawler) runDHTCrawler() {
+	ticker := time.NewTicker(c.dhtCrawlInterval)
+	for {
+		select {
+		case <-ticker.C:
+			r := mrand.Intn(len(c.nodes))
+			log.Debugf("Node %d starting DHT crawl", r)
+			found, err := c.crawlDHT(r)
+			if err != nil {
+				log.Errorf("Error crawling DHT: %s", err)
+				continue
+			}
+			log.Infof("DHT crawl found %d OpenBazaar peers", found)
+		case <-c.shutdown:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// crawlDHT walks the routing table of every DHT server reachable from the
+// node's current peers, querying each for the closest peers to random keys
+// in each of its buckets. The OpenBazaar nodes found are recorded and those
+// due for a crawl are queued. It returns the number of OpenBazaar nodes
+// found.
+//
+// The OpenBazaar DHT runs under its own protocol prefix so any peer that
+// answers is an OpenBazaar node. Peers that couldn't be queried are
+// identified by the protocols and user agent they reported to identify.
+func (c *Crawler) crawlDHT(node int) (int, error) {
+	h := c.nodes[node].IPFSNode().PeerHost
+	dc, err := dhtcrawler.New(h,
+		dhtcrawler.WithProtocols([]protocol.ID{protocol.ID(core.ProtocolDHT + "/kad/1.0.0")}),
+		dhtcrawler.WithParallelism(int(c.dhtCrawlParallel)),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var seeds []*peer.AddrInfo
+	for _, p := range h.Network().Peers() {
+		pi := h.Peerstore().PeerInfo(p)
+		seeds = append(seeds, &pi)
+	}
+
+	var found []peer.ID
+	dc.Run(c.ctx, seeds, func(p peer.ID, _ []*peer.AddrInfo) {
+		found = append(found, p)
+	}, func(p peer.ID, _ error) {
+		if isOpenBazaarPeer(h, p) {
+			found = append(found, p)
+		}
+	})
+
+	n := 0
+	for _, p := range found {
+		if c.isOwnNode(p) {
+			continue
+		}
+		n++
+		if _, err := c.markSeen(p); err != nil {
+			return n, err
+		}
+		// The walk connects to the peers it finds so they are usually
+		// already marked seen by the connection notifier. Whether they
+		// are queued is decided by their crawl state instead.
+		due, err := c.dueForCrawl(p)
+		if err != nil {
+			return n, err
+		}
+		if due {
+			// The job is counted with the pending re-crawls until a worker
+			// takes it so the re-crawl batches shrink while the walk's jobs
+			// are queued.
+			atomic.AddInt32(&c.pendingRecrawls, 1)
+			go func(p peer.ID) {
+				defer atomic.AddInt32(&c.pendingRecrawls, -1)
+				select {
+				case c.workChan <- &job{
+					Peer:           p,
+					FetchNewRecord: true,
+					PinRecord:      c.pinRecords,
+				}:
+				case <-c.shutdown:
+				}
+			}(p)
+		}
+	}
+	return n, nil
+}
+
+// dueForCrawl returns whether the peer should be queued for a crawl. Stores
+// are crawled once their next crawl is due unless they are banned. Peers
+// which aren't known stores are crawled so they can be promoted if they
+// publish a store, but at most once every recrawlMinAge. Their attempt is
+// recorded when they are found to be due.
+func (c *Crawler) dueForCrawl(pid peer.ID) (bool, error) {
+	var due bool
+	err := c.db.Update(func(db *gorm.DB) error {
+		var p repo.Peer
+		err := db.Where("peer_id=?", pid.Pretty()).First(&p).Error
+		if err == nil {
+			due = !p.Banned && p.NextCrawlAt.Before(time.Now())
+			return nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var observed repo.ObservedPeer
+		err = db.Where("peer_id=?", pid.Pretty()).First(&observed).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if time.Since(observed.LastCrawlAttempt) < c.recrawlMinAge {
+			return nil
+		}
+		due = true
+		return db.Model(&repo.ObservedPeer{}).Where("peer_id=?", pid.Pretty()).Update("last_crawl_attempt", time.Now()).Error
+	})
+	return due, err
+}
+
+// isOpenBazaarPeer returns whether the peer reported an OpenBazaar
+// protocol or user agent when it was identified.
+func isOpenBazaarPeer(h host.Host, p peer.ID) bool {
+	protos, err := h.Peerstore().SupportsProtocols(p, obnet.ProtocolAppMainnetTwo, obnet.ProtocolAppTestnetTwo)
+	if err == nil && len(protos) > 0 {
+		return true
+	}
+	agent, err := h.Peerstore().Get(p, "AgentVersion")
+	if err != nil {
+		return false
+	}
+	s, ok := agent.(string)
+	return ok && strings.Contains(strings.ToLower(s), "openbazaar")
+}
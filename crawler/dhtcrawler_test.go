@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"context"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCrawler_CrawlDHT(t *testing.T) {
+	mn, err := core.NewMocknet(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		nodes:            mn.Nodes()[4:],
+		db:               db,
+		ctx:              context.Background(),
+		workChan:         make(chan *job, 10),
+		shutdown:         make(chan struct{}),
+		dhtCrawlParallel: 10,
+		recrawlMinAge:    time.Hour,
+	}
+	defer close(c.shutdown)
+	c.listenPeers(c.nodes[0].IPFSNode())
+
+	// The connection notifier has usually seen the peers before the walk
+	// finds them. That must not stop them from being crawled.
+	for _, n := range mn.Nodes()[:4] {
+		if _, err := c.markSeen(n.Identity()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A store which isn't due for a crawl is recorded but not queued.
+	fresh := mn.Nodes()[0].Identity()
+	err = db.Update(func(db *gorm.DB) error {
+		return db.Create(&repo.Peer{PeerID: fresh.Pretty(), NextCrawlAt: time.Now().Add(time.Hour)}).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := c.crawlDHT(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != 4 {
+		t.Errorf("Expected 4 peers found, got %d", found)
+	}
+
+	for _, n := range mn.Nodes()[:4] {
+		err := db.View(func(db *gorm.DB) error {
+			var peer repo.ObservedPeer
+			return db.Where("peer_id=?", n.Identity().Pretty()).First(&peer).Error
+		})
+		if err != nil {
+			t.Errorf("Peer %s not recorded: %s", n.Identity(), err)
+		}
+	}
+
+	queued := make(map[peer.ID]bool)
+	for len(queued) < 3 {
+		select {
+		case j := <-c.workChan:
+			queued[j.Peer] = true
+		case <-time.After(time.Second * 5):
+			t.Fatalf("Expected 3 peers to be queued, got %d", len(queued))
+		}
+	}
+	for _, n := range mn.Nodes()[1:4] {
+		if !queued[n.Identity()] {
+			t.Errorf("Expected peer %s to be queued", n.Identity())
+		}
+	}
+	select {
+	case j := <-c.workChan:
+		t.Errorf("Expected peer %s not to be queued", j.Peer)
+	case <-time.After(time.Millisecond * 500):
+	}
+	if pending := atomic.LoadInt32(&c.pendingRecrawls); pending != 0 {
+		t.Errorf("Expected no pending jobs once the workers took them, got %d", pending)
+	}
+
+	// Peers which aren't stores are not queued again by the next walk
+	// until recrawlMinAge has passed.
+	if _, err := c.crawlDHT(0); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case j := <-c.workChan:
+		t.Errorf("Expected peer %s not to be queued again", j.Peer)
+	case <-time.After(time.Millisecond * 500):
+	}
+}
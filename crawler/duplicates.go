@@ -0,0 +1,299 @@
+package crawler
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"gorm.io/gorm"
+	"hash/fnv"
+	mrand "math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Listing fingerprints are MinHash signatures of fingerprintSize hashes
+	// over the shingles of the listing's text. They are split into
+	// fingerprintBands bands and listings sharing a band are compared.
+	fingerprintSize  = 32
+	fingerprintBands = 8
+	shingleSize      = 3
+
+	// duplicateThreshold is the estimated similarity of the text of two
+	// listings above which they are copies.
+	duplicateThreshold = 0.8
+
+	featureBandPrefix  = "band:"
+	featureImagePrefix = "image:"
+)
+
+// minHashSeeds are the multipliers and offsets of the MinHash functions.
+// They are fixed so fingerprints are comparable across restarts.
+var minHashSeeds = func() [fingerprintSize][2]uint64 {
+	var seeds [fingerprintSize][2]uint64
+	r := mrand.New(mrand.NewSource(1))
+	for i := range seeds {
+		seeds[i] = [2]uint64{r.Uint64() | 1, r.Uint64()}
+	}
+	return seeds
+}()
+
+// shingles returns the distinct runs of shingleSize consecutive terms in
+// the text. Text with fewer terms is a single shingle.
+func shingles(text string) []string {
+	terms := tokenize(text)
+	if len(terms) == 0 {
+		return nil
+	}
+	if len(terms) < shingleSize {
+		return []string{strings.Join(terms, " ")}
+	}
+	var ret []string
+	seen := make(map[string]bool)
+	for i := 0; i+shingleSize <= len(terms); i++ {
+		s := strings.Join(terms[i:i+shingleSize], " ")
+		if !seen[s] {
+			ret = append(ret, s)
+			seen[s] = true
+		}
+	}
+	return ret
+}
+
+// fingerprint returns the MinHash signature of the text or nil if the
+// text has no terms.
+func fingerprint(text string) []byte {
+	shingles := shingles(text)
+	if len(shingles) == 0 {
+		return nil
+	}
+	var mins [fingerprintSize]uint64
+	for i := range mins {
+		mins[i] = ^uint64(0)
+	}
+	for _, s := range shingles {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		x := h.Sum64()
+		for i, seed := range minHashSeeds {
+			if v := x*seed[0] + seed[1]; v < mins[i] {
+				mins[i] = v
+			}
+		}
+	}
+	fp := make([]byte, fingerprintSize*8)
+	for i, v := range mins {
+		binary.BigEndian.PutUint64(fp[i*8:], v)
+	}
+	return fp
+}
+
+// similarity estimates the Jaccard similarity of the shingles of two
+// fingerprinted texts.
+func similarity(a, b []byte) float64 {
+	if len(a) != fingerprintSize*8 || len(b) != fingerprintSize*8 {
+		return 0
+	}
+	equal := 0
+	for i := 0; i < len(a); i += 8 {
+		if binary.BigEndian.Uint64(a[i:]) == binary.BigEndian.Uint64(b[i:]) {
+			equal++
+		}
+	}
+	return float64(equal) / fingerprintSize
+}
+
+// listingFeatures returns the bands of the listing's fingerprint and the
+// hashes of the images it references.
+func listingFeatures(l *obpb.Listing, fp []byte) []string {
+	var features []string
+	if len(fp) == fingerprintSize*8 {
+		size := len(fp) / fingerprintBands
+		for i := 0; i < fingerprintBands; i++ {
+			h := fnv.New64a()
+			h.Write(fp[i*size : (i+1)*size])
+			features = append(features, fmt.Sprintf("%s%d:%x", featureBandPrefix, i, h.Sum64()))
+		}
+	}
+	seen := make(map[string]bool)
+	for _, img := range l.GetItem().GetImages() {
+		if img.Original != "" && !seen[img.Original] {
+			features = append(features, featureImagePrefix+img.Original)
+			seen[img.Original] = true
+		}
+	}
+	return features
+}
+
+// setListingFeatures replaces the features of the listing.
+func setListingFeatures(db *gorm.DB, peerID, slug string, features []string) error {
+	if err := db.Where("peer_id=?", peerID).Where("slug=?", slug).Delete(&repo.ListingFeature{}).Error; err != nil {
+		return err
+	}
+	rows := make([]repo.ListingFeature, 0, len(features))
+	for _, f := range features {
+		rows = append(rows, repo.ListingFeature{
+			Feature: f,
+			PeerID:  peerID,
+			Slug:    slug,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.Create(&rows).Error
+}
+
+// FindDuplicates returns the clusters of listings copied across stores.
+// Listings are copies if the similarity of their text is above the
+// threshold or they share an image. If the query names a listing only
+// the cluster of its copies is returned and only its features are
+// looked up. Expired listings are ignored.
+func (c *Crawler) FindDuplicates(query *rpc.DuplicateQuery) ([]*rpc.DuplicateCluster, error) {
+	var (
+		features []repo.ListingFeature
+		listings []repo.Listing
+	)
+	err := c.db.View(func(db *gorm.DB) error {
+		// Only the features of the named listing are grouped so looking up
+		// the copies of one listing doesn't scan every feature.
+		shared := db.Model(&repo.ListingFeature{}).Select("feature")
+		if query.PeerID != "" {
+			own := db.Model(&repo.ListingFeature{}).
+				Select("feature").
+				Where("peer_id=?", query.PeerID).
+				Where("slug=?", query.Slug)
+			shared = shared.Where("feature IN (?)", own)
+		}
+		shared = shared.Group("feature").Having("COUNT(DISTINCT peer_id) > 1")
+		if err := db.Where("feature IN (?)", shared).Find(&features).Error; err != nil {
+			return err
+		}
+		if len(features) == 0 {
+			return nil
+		}
+
+		// Load only the listings which have one of the shared features.
+		keys := db.Model(&repo.ListingFeature{}).
+			Select("DISTINCT peer_id, slug").
+			Where("feature IN (?)", shared)
+		return db.Select("listings.peer_id, listings.slug, listings.c_id, listings.title, listings.fingerprint, listings.created_at").
+			Joins("JOIN (?) AS shared ON shared.peer_id = listings.peer_id AND shared.slug = listings.slug", keys).
+			Where("listings.expiration>?", time.Now()).
+			Find(&listings).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*repo.Listing)
+	for i := range listings {
+		byKey[listings[i].PeerID+"/"+listings[i].Slug] = &listings[i]
+	}
+
+	// Union the listings sharing an image or with similar text.
+	parent := make(map[string]string)
+	var find func(k string) string
+	find = func(k string) string {
+		if p, ok := parent[k]; ok && p != k {
+			parent[k] = find(p)
+			return parent[k]
+		}
+		parent[k] = k
+		return k
+	}
+	union := func(a, b string) {
+		parent[find(a)] = find(b)
+	}
+
+	groups := make(map[string][]string)
+	images := make(map[string]map[string]bool)
+	for _, f := range features {
+		k := f.PeerID + "/" + f.Slug
+		if _, ok := byKey[k]; !ok {
+			continue
+		}
+		groups[f.Feature] = append(groups[f.Feature], k)
+		if strings.HasPrefix(f.Feature, featureImagePrefix) {
+			if images[k] == nil {
+				images[k] = make(map[string]bool)
+			}
+			images[k][f.Feature] = true
+		}
+	}
+	for feature, keys := range groups {
+		for i := 0; i < len(keys); i++ {
+			for j := i + 1; j < len(keys); j++ {
+				a, b := byKey[keys[i]], byKey[keys[j]]
+				if a.PeerID == b.PeerID {
+					continue
+				}
+				if strings.HasPrefix(feature, featureImagePrefix) || similarity(a.Fingerprint, b.Fingerprint) >= duplicateThreshold {
+					union(keys[i], keys[j])
+				}
+			}
+		}
+	}
+
+	members := make(map[string][]*repo.Listing)
+	for k := range parent {
+		root := find(k)
+		members[root] = append(members[root], byKey[k])
+	}
+
+	var clusters []*rpc.DuplicateCluster
+	for _, ls := range members {
+		if len(ls) < 2 {
+			continue
+		}
+		if query.PeerID != "" && !containsListing(ls, query.PeerID, query.Slug) {
+			continue
+		}
+		sort.Slice(ls, func(i, j int) bool {
+			if !ls[i].CreatedAt.Equal(ls[j].CreatedAt) {
+				return ls[i].CreatedAt.Before(ls[j].CreatedAt)
+			}
+			return ls[i].PeerID < ls[j].PeerID
+		})
+		first := ls[0]
+		cluster := new(rpc.DuplicateCluster)
+		for _, l := range ls {
+			shared := 0
+			for img := range images[l.PeerID+"/"+l.Slug] {
+				if images[first.PeerID+"/"+first.Slug][img] {
+					shared++
+				}
+			}
+			cluster.Listings = append(cluster.Listings, &rpc.DuplicateListing{
+				PeerID:       l.PeerID,
+				Slug:         l.Slug,
+				CID:          l.CID,
+				Title:        l.Title,
+				FirstCrawled: l.CreatedAt,
+				Similarity:   similarity(first.Fingerprint, l.Fingerprint),
+				SharedImages: shared,
+			})
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].Listings) != len(clusters[j].Listings) {
+			return len(clusters[i].Listings) > len(clusters[j].Listings)
+		}
+		return clusters[i].Listings[0].FirstCrawled.Before(clusters[j].Listings[0].FirstCrawled)
+	})
+	return clusters, nil
+}
+
+// containsListing returns whether the listing is in the set.
+func containsListing(listings []*repo.Listing, peerID, slug string) bool {
+	for _, l := range listings {
+		if l.PeerID == peerID && l.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
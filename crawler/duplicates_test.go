@@ -0,0 +1,125 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"testing"
+	"time"
+)
+
+func TestSimilarity(t *testing.T) {
+	text := "Hand thrown stoneware coffee mug with a speckled glaze. Holds twelve ounces and is dishwasher safe."
+	if s := similarity(fingerprint(text), fingerprint(text)); s != 1 {
+		t.Errorf("Expected identical text to have similarity 1, got %f", s)
+	}
+	if s := similarity(fingerprint(text), fingerprint("HAND THROWN stoneware coffee mug, with a speckled glaze! Holds twelve ounces and is dishwasher safe")); s != 1 {
+		t.Errorf("Expected normalized text to have similarity 1, got %f", s)
+	}
+	if s := similarity(fingerprint(text), fingerprint("Vintage leather messenger bag with brass buckles and a padded laptop sleeve.")); s >= duplicateThreshold {
+		t.Errorf("Expected different text to be below the threshold, got %f", s)
+	}
+	if fingerprint("!!") != nil {
+		t.Error("Expected no fingerprint for text without terms")
+	}
+}
+
+func TestCrawler_FindDuplicates(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{db: db}
+
+	newListing := func(slug, title, description string, images ...string) *pb.SignedListing {
+		l := &pb.SignedListing{
+			Cid: slug,
+			Listing: &pb.Listing{
+				Slug:     slug,
+				Metadata: &pb.Listing_Metadata{},
+				Item:     &pb.Listing_Item{Title: title, Description: description},
+			},
+		}
+		for _, img := range images {
+			l.Listing.Item.Images = append(l.Listing.Item.Images, &pb.Listing_Item_Image{Original: img})
+		}
+		return l
+	}
+	description := "Hand thrown stoneware coffee mug with a speckled glaze. Holds twelve ounces and is dishwasher safe."
+	expiration := time.Now().Add(time.Hour)
+	for _, l := range []struct {
+		peerID  string
+		listing *pb.SignedListing
+	}{
+		{"QmOriginal", newListing("mug", "Speckled coffee mug", description, "QmMugPhoto")},
+		{"QmOriginal", newListing("mug-2", "Speckled coffee mug", description)},
+		{"QmCopycat", newListing("mug", "Speckled coffee mug", description)},
+		{"QmPhotoThief", newListing("cup", "Nice cup", "A cup for drinking things.", "QmMugPhoto")},
+		{"QmOther", newListing("bag", "Leather bag", "Vintage leather messenger bag with brass buckles.")},
+		{"QmExpired", newListing("mug", "Speckled coffee mug", description)},
+	} {
+		exp := expiration
+		if l.peerID == "QmExpired" {
+			exp = time.Now().Add(-time.Hour)
+		}
+		if err := c.indexListing(l.peerID, l.listing, exp); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	clusters, err := c.FindDuplicates(&rpc.DuplicateQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("Expected 1 cluster, got %d", len(clusters))
+	}
+	got := make(map[string]*rpc.DuplicateListing)
+	for _, l := range clusters[0].Listings {
+		got[l.PeerID+"/"+l.Slug] = l
+	}
+	if len(got) != 4 || got["QmOriginal/mug"] == nil || got["QmOriginal/mug-2"] == nil || got["QmCopycat/mug"] == nil || got["QmPhotoThief/cup"] == nil {
+		t.Fatalf("Unexpected cluster %v", got)
+	}
+	if first := clusters[0].Listings[0]; first.PeerID != "QmOriginal" || first.Slug != "mug" {
+		t.Errorf("Expected the original listing first, got %s/%s", first.PeerID, first.Slug)
+	}
+	if got["QmPhotoThief/cup"].SharedImages != 1 {
+		t.Errorf("Expected 1 shared image, got %d", got["QmPhotoThief/cup"].SharedImages)
+	}
+	if got["QmCopycat/mug"].Similarity != 1 {
+		t.Errorf("Expected similarity 1, got %f", got["QmCopycat/mug"].Similarity)
+	}
+
+	clusters, err = c.FindDuplicates(&rpc.DuplicateQuery{PeerID: "QmCopycat", Slug: "mug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 1 || len(clusters[0].Listings) != 3 {
+		t.Fatalf("Expected the copycat's text matches, got %v", clusters)
+	}
+
+	clusters, err = c.FindDuplicates(&rpc.DuplicateQuery{PeerID: "QmOther", Slug: "bag"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 0 {
+		t.Errorf("Expected no duplicates, got %d clusters", len(clusters))
+	}
+
+	// Removed listings are no longer clustered.
+	if err := c.removeFromIndex("QmCopycat"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.removeListings("QmPhotoThief", nil); err != nil {
+		t.Fatal(err)
+	}
+	clusters, err = c.FindDuplicates(&rpc.DuplicateQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 0 {
+		t.Errorf("Expected no duplicates, got %d clusters", len(clusters))
+	}
+}
@@ -0,0 +1,267 @@
+package crawler
+
+import (
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/ipfs/go-ipfs/core/corerepo"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// gcCheckInterval is how often the GC schedule and disk usage of the
+	// nodes are checked.
+	gcCheckInterval = time.Minute * 10
+
+	// evictBatchSize is the number of peers whose pins are released in
+	// each round of eviction.
+	evictBatchSize = 10
+)
+
+// runGC garbage collects the nodes every gcInterval and whenever a node's
+// disk usage exceeds the high watermark. When staggered the nodes are due
+// at evenly spaced times across the interval and only one node is
+// collected at a time. If the blockstore is shared it is collected as a
+// single unit.
+func (c *Crawler) runGC() {
+	units := len(c.nodes)
+	if c.sharedBlockstore {
+		units = 1
+	}
+	lastGC := make([]time.Time, units)
+	for i := range lastGC {
+		lastGC[i] = time.Now()
+		if c.gcStagger {
+			lastGC[i] = lastGC[i].Add(-c.gcInterval + time.Duration(i+1)*c.gcInterval/time.Duration(units))
+		}
+	}
+
+	ticker := time.NewTicker(gcCheckInterval)
+	for {
+		select {
+		case <-ticker.C:
+			var wg sync.WaitGroup
+			for i := range lastGC {
+				due := time.Since(lastGC[i]) >= c.gcInterval
+				if !due && c.gcHighWatermark > 0 {
+					usage, err := c.diskUsage(i)
+					if err != nil {
+						log.Errorf("Error loading disk usage for node %d: %s", i, err)
+						continue
+					}
+					due = usage >= c.gcHighWatermark
+				}
+				if !due {
+					continue
+				}
+				lastGC[i] = time.Now()
+				if c.gcStagger {
+					c.collectGarbage(i)
+					break
+				}
+				wg.Add(1)
+				go func(i int) {
+					c.collectGarbage(i)
+					wg.Done()
+				}(i)
+			}
+			wg.Wait()
+		case <-c.shutdown:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// collectGarbage garbage collects the node. If the node is still over the
+// high watermark afterwards the pins of the least recently seen peers are
+// released and the node collected again until it drops below the low
+// watermark. Only peers not seen within gcEvictAfter are evicted so the
+// data of active stores is kept even if the watermark can't be reached.
+// Once the node is below the low watermark again the evicted peers are
+// allowed to be pinned on their next crawl.
+func (c *Crawler) collectGarbage(node int) {
+	if err := c.gcNode(node); err != nil {
+		log.Errorf("Error garbage collecting node %d: %s", node, err)
+		return
+	}
+	if c.gcHighWatermark == 0 {
+		return
+	}
+	usage, err := c.diskUsage(node)
+	if err != nil {
+		log.Errorf("Error loading disk usage for node %d: %s", node, err)
+		return
+	}
+	target := c.gcLowWatermark
+	if target == 0 {
+		target = c.gcHighWatermark
+	}
+	if usage < target {
+		n, err := c.restoreEvictedPeers(node)
+		if err != nil {
+			log.Errorf("Error restoring evicted peers on node %d: %s", node, err)
+		} else if n > 0 {
+			log.Infof("Node %d is using %d bytes, %d evicted peers will be pinned again", node, usage, n)
+		}
+		return
+	}
+	if usage < c.gcHighWatermark {
+		return
+	}
+	log.Warningf("Node %d is using %d bytes after garbage collection, evicting least recently seen peers", node, usage)
+
+	evicted := 0
+	for usage >= target {
+		n, err := c.evictPeers(node)
+		if err != nil {
+			log.Errorf("Error evicting peers from node %d: %s", node, err)
+			return
+		}
+		if n == 0 {
+			log.Warningf("Node %d is still using %d bytes after evicting %d peers. The remaining peers were seen within %s.", node, usage, evicted, c.gcEvictAfter)
+			return
+		}
+		evicted += n
+		if err := c.gcNode(node); err != nil {
+			log.Errorf("Error garbage collecting node %d: %s", node, err)
+			return
+		}
+		usage, err = c.diskUsage(node)
+		if err != nil {
+			log.Errorf("Error loading disk usage for node %d: %s", node, err)
+			return
+		}
+	}
+	log.Infof("Evicted data of %d peers from node %d, now using %d bytes", evicted, node, usage)
+}
+
+// evictPeers releases the pins of the least recently seen peers with data
+// pinned on the node which have not been seen within gcEvictAfter. The
+// peers are marked as evicted so they aren't pinned again when they are
+// next crawled. It returns the number of peers released.
+func (c *Crawler) evictPeers(node int) (int, error) {
+	var peers []repo.Peer
+	err := c.db.View(func(db *gorm.DB) error {
+		refs := db.Model(&repo.PinRef{}).Select("peer_id")
+		if !c.sharedBlockstore {
+			refs = refs.Where("node=?", node)
+		}
+		return db.Where("peer_id IN (?)", refs).
+			Where("last_seen<?", time.Now().Add(-c.gcEvictAfter)).
+			Order("last_seen asc").
+			Limit(evictBatchSize).
+			Find(&peers).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	for _, p := range peers {
+		pid, err := peer.Decode(p.PeerID)
+		if err != nil {
+			return 0, err
+		}
+		err = c.db.Update(func(db *gorm.DB) error {
+			return db.Model(&repo.Peer{}).Where("peer_id=?", p.PeerID).Update("evicted", true).Error
+		})
+		if err != nil {
+			return 0, err
+		}
+		if err := c.pins.releasePeer(pid); err != nil {
+			return 0, err
+		}
+		log.Debugf("Evicted data of peer %s last seen %s", p.PeerID, p.LastSeen)
+	}
+	return len(peers), nil
+}
+
+// restoreEvictedPeers clears the evicted flag of the peers assigned to the
+// node so their data is pinned again on their next crawl. It returns the
+// number of peers restored.
+func (c *Crawler) restoreEvictedPeers(node int) (int, error) {
+	var peers []repo.Peer
+	err := c.db.View(func(db *gorm.DB) error {
+		return db.Where("evicted=?", true).Find(&peers).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	var restore []string
+	for _, p := range peers {
+		pid, err := peer.Decode(p.PeerID)
+		if err != nil {
+			continue
+		}
+		if c.sharedBlockstore || containsNode(c.ring.owners(pid), uint(node)) {
+			restore = append(restore, p.PeerID)
+		}
+	}
+	if len(restore) == 0 {
+		return 0, nil
+	}
+	err = c.db.Update(func(db *gorm.DB) error {
+		for _, pid := range restore {
+			if err := db.Model(&repo.Peer{}).Where("peer_id=?", pid).Update("evicted", false).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(restore), nil
+}
+
+// gcNode garbage collects the node, or the shared blockstore if it is
+// enabled, and waits for it to finish.
+func (c *Crawler) gcNode(node int) error {
+	if c.sharedBlockstore {
+		removed, err := c.collectSharedGarbage(c.ctx)
+		log.Infof("Garbage collected %d blocks from shared blockstore", removed)
+		return err
+	}
+	var (
+		removed  int
+		firstErr error
+	)
+	for res := range corerepo.GarbageCollectAsync(c.nodes[node].IPFSNode(), c.ctx) {
+		if res.Error != nil {
+			if firstErr == nil {
+				firstErr = res.Error
+			}
+			continue
+		}
+		removed++
+	}
+	log.Infof("Garbage collected %d blocks from node %d", removed, node)
+	return firstErr
+}
+
+// diskUsage returns the number of bytes stored by the node's repo. The
+// accounting of a shared blockstore is kept separately by each node and
+// misses the blocks written by the others, so it is measured on disk.
+func (c *Crawler) diskUsage(node int) (uint64, error) {
+	if c.sharedBlockstore {
+		return dirSize(c.sharedBlocksPath)
+	}
+	return c.nodes[node].IPFSNode().Repo.GetStorageUsage()
+}
+
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size, err
+}
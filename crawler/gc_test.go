@@ -0,0 +1,112 @@
+package crawler
+
+import (
+	"context"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/ipfs/go-cid"
+	files "github.com/ipfs/go-ipfs-files"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCrawler_CollectGarbage(t *testing.T) {
+	mn, err := core.NewMocknet(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	c := &Crawler{
+		nodes:           mn.Nodes()[:2],
+		ring:            newHashRing(2, 0),
+		db:              db,
+		ctx:             ctx,
+		gcHighWatermark: 1,
+		gcEvictAfter:    time.Hour,
+	}
+	c.pins = newPinManager(ctx, c.nodes, c.ring, db)
+
+	pinData := func(pid peer.ID, node uint, data string, lastSeen time.Time) cid.Cid {
+		capi, err := coreapi.NewCoreAPI(c.nodes[node].IPFSNode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pth, err := capi.Unixfs().Add(ctx, files.NewBytesFile([]byte(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = db.Update(func(db *gorm.DB) error {
+			return db.Save(&repo.Peer{PeerID: pid.Pretty(), LastSeen: lastSeen}).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.pins.setPeerPins(pid, []uint{node}, map[cid.Cid]bool{pth.Cid(): true}); err != nil {
+			t.Fatal(err)
+		}
+		return pth.Cid()
+	}
+
+	var (
+		stale    = mn.Nodes()[0].Identity()
+		active   = mn.Nodes()[1].Identity()
+		assigned = mn.Nodes()[2].Identity()
+	)
+	pinData(stale, 0, "peer 0", time.Now().Add(-time.Hour*2))
+	activeCID := pinData(active, 0, "peer 1", time.Now())
+	keptCID := pinData(assigned, 1, "peer 2", time.Now().Add(-time.Hour*2))
+
+	// The watermark can't be reached but only the peer which hasn't been
+	// seen recently is evicted.
+	c.collectGarbage(0)
+
+	var refs []repo.PinRef
+	err = db.View(func(db *gorm.DB) error {
+		return db.Order("peer_id asc").Find(&refs).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining := make(map[string]bool)
+	for _, ref := range refs {
+		remaining[ref.CID] = true
+	}
+	if len(refs) != 2 || !remaining[activeCID.String()] || !remaining[keptCID.String()] {
+		t.Errorf("Expected the active peer and the pin on node 1 to remain, got %v", refs)
+	}
+
+	evicted := func() map[string]bool {
+		var peers []repo.Peer
+		err := db.View(func(db *gorm.DB) error {
+			return db.Where("evicted=?", true).Find(&peers).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret := make(map[string]bool)
+		for _, p := range peers {
+			ret[p.PeerID] = true
+		}
+		return ret
+	}
+	if e := evicted(); len(e) != 1 || !e[stale.Pretty()] {
+		t.Errorf("Expected only the stale peer to be marked evicted, got %v", e)
+	}
+
+	// Once the nodes have room again the evicted peers may be pinned.
+	c.gcHighWatermark = 1 << 40
+	c.collectGarbage(0)
+	c.collectGarbage(1)
+	if e := evicted(); len(e) != 0 {
+		t.Errorf("Expected no peers to remain evicted, got %v", e)
+	}
+}
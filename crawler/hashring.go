@@ -0,0 +1,78 @@
+package crawler
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"sort"
+	"strconv"
+)
+
+// ringVirtualNodes is the number of points each IPFS node is given on
+// the hash ring. More points give a more even spread of peers.
+const ringVirtualNodes = 128
+
+// hashRing assigns peers to the crawler's IPFS nodes using consistent
+// hashing. Each peer is owned by a stable node which fetches and pins its
+// data. When the number of nodes changes only around 1/n of the peers
+// move to a different node.
+type hashRing struct {
+	points   []uint64
+	nodes    map[uint64]uint
+	numNodes uint
+	replicas uint
+}
+
+// newHashRing returns a ring over numNodes nodes. Each peer is assigned
+// to an owner plus up to replicas additional nodes.
+func newHashRing(numNodes, replicas uint) *hashRing {
+	r := &hashRing{
+		nodes:    make(map[uint64]uint),
+		numNodes: numNodes,
+		replicas: replicas,
+	}
+	for i := uint(0); i < numNodes; i++ {
+		for v := 0; v < ringVirtualNodes; v++ {
+			p := ringHash([]byte("node-" + strconv.Itoa(int(i)) + "-" + strconv.Itoa(v)))
+			if _, ok := r.nodes[p]; ok {
+				continue
+			}
+			r.nodes[p] = i
+			r.points = append(r.points, p)
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+// owners returns the nodes assigned to the peer. The first node is the
+// owner and the rest are replicas. The nodes are distinct.
+func (r *hashRing) owners(pid peer.ID) []uint {
+	want := r.replicas + 1
+	if want > r.numNodes {
+		want = r.numNodes
+	}
+	if len(r.points) == 0 {
+		return nil
+	}
+
+	h := ringHash([]byte(pid))
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+
+	ret := make([]uint, 0, want)
+	seen := make(map[uint]bool)
+	for j := 0; j < len(r.points) && uint(len(ret)) < want; j++ {
+		n := r.nodes[r.points[(i+j)%len(r.points)]]
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		ret = append(ret, n)
+	}
+	return ret
+}
+
+func ringHash(b []byte) uint64 {
+	h := sha256.Sum256(b)
+	return binary.BigEndian.Uint64(h[:8])
+}
@@ -0,0 +1,55 @@
+package crawler
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"strconv"
+	"testing"
+)
+
+func TestHashRing(t *testing.T) {
+	peers := make([]peer.ID, 1000)
+	for i := range peers {
+		peers[i] = peer.ID("peer-" + strconv.Itoa(i))
+	}
+
+	ring := newHashRing(10, 1)
+	counts := make(map[uint]int)
+	for _, pid := range peers {
+		owners := ring.owners(pid)
+		if len(owners) != 2 {
+			t.Fatalf("Expected 2 owners, got %d", len(owners))
+		}
+		if owners[0] == owners[1] {
+			t.Fatal("Expected replica to differ from owner")
+		}
+		if owners[0] != ring.owners(pid)[0] {
+			t.Error("Expected owner to be stable")
+		}
+		counts[owners[0]]++
+	}
+	for n := uint(0); n < 10; n++ {
+		if counts[n] < 50 || counts[n] > 150 {
+			t.Errorf("Node %d owns %d of %d peers", n, counts[n], len(peers))
+		}
+	}
+
+	// Adding a node should only move the peers which the new node takes over.
+	grown := newHashRing(11, 1)
+	moved := 0
+	for _, pid := range peers {
+		if o := grown.owners(pid)[0]; o != ring.owners(pid)[0] {
+			if o != 10 {
+				t.Errorf("Peer %s moved to existing node %d", pid, o)
+			}
+			moved++
+		}
+	}
+	if moved > len(peers)/5 {
+		t.Errorf("Expected around 1/11 of the peers to move, %d of %d moved", moved, len(peers))
+	}
+
+	// Replicas are capped by the number of nodes.
+	if owners := newHashRing(1, 3).owners(peers[0]); len(owners) != 1 {
+		t.Errorf("Expected 1 owner, got %d", len(owners))
+	}
+}
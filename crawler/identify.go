@@ -0,0 +1,103 @@
+package crawler
+
+import (
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/host"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+	"time"
+)
+
+// listenIdentify records the metadata each peer reports to the identify
+// protocol once identification of the peer has completed.
+func (c *Crawler) listenIdentify(h host.Host) error {
+	sub, err := h.EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer sub.Close()
+		for {
+			select {
+			case e, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				pid := e.(event.EvtPeerIdentificationCompleted).Peer
+				if c.isOwnNode(pid) {
+					continue
+				}
+				if err := c.recordIdentity(h, pid); err != nil {
+					log.Errorf("Error recording identity of peer %s: %s", pid, err)
+				}
+			case <-c.shutdown:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// recordIdentity saves the agent version, protocols, listen addresses
+// and public key type found in the host's peerstore for the peer.
+func (c *Crawler) recordIdentity(h host.Host, pid peer.ID) error {
+	ps := h.Peerstore()
+
+	var agentVersion string
+	if agent, err := ps.Get(pid, "AgentVersion"); err == nil {
+		agentVersion, _ = agent.(string)
+	}
+	protocols, err := ps.GetProtocols(pid)
+	if err != nil {
+		return err
+	}
+	sort.Strings(protocols)
+
+	var (
+		addrs    = ps.Addrs(pid)
+		addrStrs = make([]string, 0, len(addrs))
+		public   bool
+		relayed  bool
+	)
+	for _, addr := range addrs {
+		addrStrs = append(addrStrs, addr.String())
+		if manet.IsPublicAddr(addr) {
+			public = true
+		}
+		if strings.Contains(addr.String(), "/p2p-circuit") {
+			relayed = true
+		}
+	}
+	sort.Strings(addrStrs)
+
+	var keyType string
+	if pubkey := ps.PubKey(pid); pubkey != nil {
+		keyType = pubkey.Type().String()
+	}
+
+	return c.db.Update(func(db *gorm.DB) error {
+		var peer repo.ObservedPeer
+		err := db.Where("peer_id=?", pid.Pretty()).First(&peer).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			peer.PeerID = pid.Pretty()
+			peer.FirstSeen = time.Now()
+			peer.LastSeen = time.Now()
+		}
+		peer.AgentVersion = agentVersion
+		peer.Protocols = strings.Join(protocols, ",")
+		peer.ListenAddrs = strings.Join(addrStrs, ",")
+		peer.PublicKeyType = keyType
+		peer.OpenBazaar = isOpenBazaarPeer(h, pid)
+		peer.PublicAddr = public
+		peer.Relayed = relayed
+		peer.LastIdentified = time.Now()
+		return db.Save(&peer).Error
+	})
+}
@@ -0,0 +1,74 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCrawler_RecordIdentity(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		nodes: mn.Nodes()[:1],
+		db:    db,
+	}
+
+	h := mn.Nodes()[0].IPFSNode().PeerHost
+	pid := mn.Nodes()[1].Identity()
+
+	// Wait for identify to complete.
+	for i := 0; ; i++ {
+		protos, err := h.Peerstore().GetProtocols(pid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(protos) > 0 {
+			break
+		}
+		if i == 100 {
+			t.Fatal("Timed out waiting for identify")
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+
+	if err := c.recordIdentity(h, pid); err != nil {
+		t.Fatal(err)
+	}
+
+	var peer repo.ObservedPeer
+	err = db.View(func(db *gorm.DB) error {
+		return db.Where("peer_id=?", pid.Pretty()).First(&peer).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peer.Protocols == "" {
+		t.Error("Expected protocols to be recorded")
+	}
+	if peer.ListenAddrs == "" {
+		t.Error("Expected listen addrs to be recorded")
+	}
+	if peer.PublicKeyType == "" {
+		t.Error("Expected public key type to be recorded")
+	}
+	if !peer.OpenBazaar {
+		t.Error("Expected peer to be identified as an OpenBazaar node")
+	}
+	if peer.Relayed {
+		t.Error("Expected peer not to be relayed")
+	}
+	if peer.LastIdentified.IsZero() {
+		t.Error("Expected identify time to be recorded")
+	}
+}
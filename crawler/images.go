@@ -0,0 +1,314 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/obcrawler/imagehash"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	"gorm.io/gorm"
+	"sort"
+)
+
+const (
+	// defaultImageDistance is the largest distance between the hashes of
+	// near-duplicate images if the query doesn't set one.
+	defaultImageDistance = 8
+
+	// maxImageDistance is the largest distance FindSimilarImages accepts.
+	maxImageDistance = 16
+
+	// maxImageResults is the most images returned by FindSimilarImages.
+	maxImageResults = 100
+
+	// imageHashChunks is the number of 16 bit chunks each hash is split
+	// into. If two hashes differ by at most d bits then at least one of
+	// their chunks differs by at most d/imageHashChunks bits.
+	imageHashChunks = 4
+
+	// maxChunkBatch is the most chunk values looked up in one query.
+	maxChunkBatch = 500
+)
+
+// errImageNotFound is returned when looking up an image which hasn't
+// been hashed.
+var errImageNotFound = errors.New("image not found")
+
+// profileImages adds the CIDs of the profile's avatar and header images.
+func profileImages(images map[string]bool, p *models.Profile) {
+	for _, id := range []string{p.AvatarHashes.Original, p.HeaderHashes.Original} {
+		if id != "" {
+			images[id] = true
+		}
+	}
+}
+
+// listingImages adds the CIDs of the listing's product and option images.
+func listingImages(images map[string]bool, l *obpb.Listing) {
+	for _, img := range l.GetItem().GetImages() {
+		if img.Original != "" {
+			images[img.Original] = true
+		}
+	}
+	for _, opt := range l.GetItem().GetOptions() {
+		for _, v := range opt.Variants {
+			if id := v.GetImage().GetOriginal(); id != "" {
+				images[id] = true
+			}
+		}
+	}
+}
+
+// setImageRefs replaces the images referenced by the peer.
+func (c *Crawler) setImageRefs(peerID string, images map[string]bool) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		if err := db.Where("peer_id=?", peerID).Delete(&repo.ImageRef{}).Error; err != nil {
+			return err
+		}
+		refs := make([]repo.ImageRef, 0, len(images))
+		for id := range images {
+			refs = append(refs, repo.ImageRef{CID: id, PeerID: peerID})
+		}
+		if len(refs) == 0 {
+			return nil
+		}
+		return db.Create(&refs).Error
+	})
+}
+
+// hashChunk returns the i-th 16 bit chunk of the hash.
+func hashChunk(h uint64, i int) int {
+	return int(h >> (16 * i) & 0xffff)
+}
+
+// chunkVariants returns the 16 bit values which differ from the chunk by
+// at most radius bits.
+func chunkVariants(chunk, radius int) []int {
+	var (
+		variants []int
+		flip     func(v, from, left int)
+	)
+	flip = func(v, from, left int) {
+		variants = append(variants, v)
+		if left == 0 {
+			return
+		}
+		for b := from; b < 16; b++ {
+			flip(v^1<<b, b+1, left-1)
+		}
+	}
+	flip(chunk, 0, radius)
+	return variants
+}
+
+// newImageHash returns the database model of the image's hashes.
+func newImageHash(id string, dHash, pHash uint64, width, height int) *repo.ImageHash {
+	return &repo.ImageHash{
+		CID:     id,
+		DHash:   int64(dHash),
+		PHash:   int64(pHash),
+		DChunk0: hashChunk(dHash, 0),
+		DChunk1: hashChunk(dHash, 1),
+		DChunk2: hashChunk(dHash, 2),
+		DChunk3: hashChunk(dHash, 3),
+		PChunk0: hashChunk(pHash, 0),
+		PChunk1: hashChunk(pHash, 1),
+		PChunk2: hashChunk(pHash, 2),
+		PChunk3: hashChunk(pHash, 3),
+		Width:   width,
+		Height:  height,
+	}
+}
+
+// hashImages computes the perceptual hashes of the images which haven't
+// been hashed before. The images should already be cached by the node.
+// Files which aren't JPEG, PNG or GIF images are recorded as rejected so
+// they aren't fetched again.
+func (c *Crawler) hashImages(node uint, images map[string]bool) error {
+	ids := make([]string, 0, len(images))
+	for id := range images {
+		ids = append(ids, id)
+	}
+	var hashed, rejected []string
+	err := c.db.View(func(db *gorm.DB) error {
+		if err := db.Model(&repo.ImageHash{}).Where("c_id IN ?", ids).Pluck("c_id", &hashed).Error; err != nil {
+			return err
+		}
+		return db.Model(&repo.RejectedImage{}).Where("c_id IN ?", ids).Pluck("c_id", &rejected).Error
+	})
+	if err != nil {
+		return err
+	}
+	done := make(map[string]bool)
+	for _, id := range append(hashed, rejected...) {
+		done[id] = true
+	}
+
+	for _, id := range ids {
+		if done[id] {
+			continue
+		}
+		imageCID, err := cid.Decode(id)
+		if err != nil {
+			continue
+		}
+		b, err := c.cat(c.ctx, c.nodes[node].IPFSNode(), path.IpfsPath(imageCID))
+		if err != nil {
+			log.Debugf("Unable to load image %s: %s", id, err)
+			continue
+		}
+		img, decodeErr := imagehash.Decode(b)
+		if decodeErr != nil {
+			log.Debugf("Unable to decode image %s: %s", id, decodeErr)
+			err = c.db.Update(func(db *gorm.DB) error {
+				return db.Clauses(upsert).Create(&repo.RejectedImage{CID: id, Reason: decodeErr.Error()}).Error
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err = c.db.Update(func(db *gorm.DB) error {
+			return db.Clauses(upsert).Create(newImageHash(id, imagehash.DHash(img), imagehash.PHash(img), img.Bounds().Dx(), img.Bounds().Dy())).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// similarHashes loads the images with a chunk of the given kind of hash
+// within range of the same chunk of h. Every image whose hash is within
+// maxDistance of h is among them.
+func similarHashes(db *gorm.DB, kind string, h uint64, maxDistance int) ([]repo.ImageHash, error) {
+	var (
+		hashes []repo.ImageHash
+		seen   = make(map[string]bool)
+	)
+	for i := 0; i < imageHashChunks; i++ {
+		variants := chunkVariants(hashChunk(h, i), maxDistance/imageHashChunks)
+		for len(variants) > 0 {
+			n := len(variants)
+			if n > maxChunkBatch {
+				n = maxChunkBatch
+			}
+			var candidates []repo.ImageHash
+			if err := db.Where(fmt.Sprintf("%s_chunk%d IN ?", kind, i), variants[:n]).Find(&candidates).Error; err != nil {
+				return nil, err
+			}
+			variants = variants[n:]
+			for _, c := range candidates {
+				if seen[c.CID] {
+					continue
+				}
+				seen[c.CID] = true
+				hashes = append(hashes, c)
+			}
+		}
+	}
+	return hashes, nil
+}
+
+// FindSimilarImages returns the crawled images whose perceptual hashes are
+// within the query's distance of the image or hashes given, closest first.
+// An image is similar only if every hash given is within the distance.
+func (c *Crawler) FindSimilarImages(query *rpc.ImageQuery) ([]*rpc.SimilarImage, error) {
+	maxDistance := query.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = defaultImageDistance
+	} else if maxDistance > maxImageDistance {
+		return nil, fmt.Errorf("max distance must be at most %d", maxImageDistance)
+	}
+
+	var (
+		hashes       []repo.ImageHash
+		refs         []repo.ImageRef
+		dHash, pHash = query.DHash, query.PHash
+	)
+	err := c.db.View(func(db *gorm.DB) error {
+		if query.CID != "" {
+			var target repo.ImageHash
+			err := db.Where("c_id=?", query.CID).First(&target).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errImageNotFound
+			} else if err != nil {
+				return err
+			}
+			d, p := uint64(target.DHash), uint64(target.PHash)
+			dHash, pHash = &d, &p
+		}
+		if dHash == nil && pHash == nil {
+			return errors.New("an image CID or hash is required")
+		}
+
+		// Every result is within range of each hash given so the
+		// candidates are only looked up by one of them.
+		var err error
+		if dHash != nil {
+			hashes, err = similarHashes(db, "d", *dHash, maxDistance)
+		} else {
+			hashes, err = similarHashes(db, "p", *pHash, maxDistance)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*rpc.SimilarImage
+	for _, h := range hashes {
+		result := &rpc.SimilarImage{CID: h.CID, Width: h.Width, Height: h.Height, DHashDistance: -1, PHashDistance: -1}
+		if dHash != nil {
+			result.DHashDistance = imagehash.Distance(*dHash, uint64(h.DHash))
+			if result.DHashDistance > maxDistance {
+				continue
+			}
+		}
+		if pHash != nil {
+			result.PHashDistance = imagehash.Distance(*pHash, uint64(h.PHash))
+			if result.PHashDistance > maxDistance {
+				continue
+			}
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		// Unset distances are -1 in every result so they cancel out.
+		di := results[i].DHashDistance + results[i].PHashDistance
+		dj := results[j].DHashDistance + results[j].PHashDistance
+		if di != dj {
+			return di < dj
+		}
+		return results[i].CID < results[j].CID
+	})
+	if len(results) > maxImageResults {
+		results = results[:maxImageResults]
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.CID)
+	}
+	if len(ids) == 0 {
+		return results, nil
+	}
+	err = c.db.View(func(db *gorm.DB) error {
+		return db.Where("c_id IN ?", ids).Order("peer_id").Find(&refs).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	peers := make(map[string][]string)
+	for _, ref := range refs {
+		peers[ref.CID] = append(peers[ref.CID], ref.PeerID)
+	}
+	for _, r := range results {
+		r.PeerIDs = peers[r.CID]
+	}
+	return results, nil
+}
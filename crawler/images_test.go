@@ -0,0 +1,163 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/core"
+	files "github.com/ipfs/go-ipfs-files"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"gorm.io/gorm"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestCrawler_FindSimilarImages(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{db: db}
+
+	var base uint64 = 0xf0f0f0f0f0f0f0f0
+	err = db.Update(func(tx *gorm.DB) error {
+		for _, h := range []*repo.ImageHash{
+			newImageHash("QmStock", base, base, 0, 0),
+			newImageHash("QmResized", base^0x3, base^0x1, 0, 0),
+			newImageHash("QmCropped", base^0x3ff, base^0x7, 0, 0),
+			// Differs by 2 bits in every chunk.
+			newImageHash("QmRecolored", base^0x0003000300030003, base^0x0003000300030003, 0, 0),
+			newImageHash("QmOther", ^base, ^base, 0, 0),
+		} {
+			if err := tx.Create(h).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setImageRefs("QmVendor", map[string]bool{"QmStock": true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setImageRefs("QmCopycat", map[string]bool{"QmStock": true, "QmResized": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := c.FindSimilarImages(&rpc.ImageQuery{CID: "QmStock"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 3 || images[0].CID != "QmStock" || images[1].CID != "QmResized" || images[2].CID != "QmRecolored" {
+		t.Fatalf("Expected the stock photo and its resized and recolored copies, got %v", images)
+	}
+	if len(images[0].PeerIDs) != 2 || images[0].PeerIDs[0] != "QmCopycat" || images[0].PeerIDs[1] != "QmVendor" {
+		t.Errorf("Expected both peers to reference the stock photo, got %v", images[0].PeerIDs)
+	}
+	if images[1].DHashDistance != 2 || images[1].PHashDistance != 1 {
+		t.Errorf("Expected distances 2 and 1, got %d and %d", images[1].DHashDistance, images[1].PHashDistance)
+	}
+
+	// Only the given hash is compared.
+	pHash := base
+	images, err = c.FindSimilarImages(&rpc.ImageQuery{PHash: &pHash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 4 {
+		t.Fatalf("Expected 4 images, got %d", len(images))
+	}
+	if images[2].CID != "QmCropped" || images[2].DHashDistance != -1 {
+		t.Errorf("Expected the cropped image last without a dHash distance, got %+v", images[2])
+	}
+
+	images, err = c.FindSimilarImages(&rpc.ImageQuery{CID: "QmStock", MaxDistance: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 1 {
+		t.Errorf("Expected 1 image, got %d", len(images))
+	}
+
+	if _, err := c.FindSimilarImages(&rpc.ImageQuery{CID: "QmStock", MaxDistance: maxImageDistance + 1}); err == nil {
+		t.Error("Expected error with too large a distance")
+	}
+	if _, err := c.FindSimilarImages(&rpc.ImageQuery{CID: "QmUnknown"}); err != errImageNotFound {
+		t.Errorf("Expected errImageNotFound, got %v", err)
+	}
+	if _, err := c.FindSimilarImages(&rpc.ImageQuery{}); err == nil {
+		t.Error("Expected error without an image")
+	}
+
+	if err := c.removeFromIndex("QmCopycat"); err != nil {
+		t.Fatal(err)
+	}
+	images, err = c.FindSimilarImages(&rpc.ImageQuery{CID: "QmResized"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, img := range images {
+		if len(img.PeerIDs) > 1 || (len(img.PeerIDs) == 1 && img.PeerIDs[0] != "QmVendor") {
+			t.Errorf("Expected references of removed peer to be deleted, got %v", img.PeerIDs)
+		}
+	}
+}
+
+func TestCrawler_HashImages(t *testing.T) {
+	mn, err := core.NewMocknet(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	c := &Crawler{db: db, ctx: ctx, nodes: mn.Nodes()}
+
+	capi, err := coreapi.NewCoreAPI(mn.Nodes()[0].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 16, 8))); err != nil {
+		t.Fatal(err)
+	}
+	img, err := capi.Unixfs().Add(ctx, files.NewBytesFile(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := capi.Unixfs().Add(ctx, files.NewBytesFile([]byte("not an image")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.hashImages(0, map[string]bool{img.Cid().String(): true, text.Cid().String(): true}); err != nil {
+		t.Fatal(err)
+	}
+	err = db.View(func(db *gorm.DB) error {
+		var hashes []repo.ImageHash
+		if err := db.Find(&hashes).Error; err != nil {
+			return err
+		}
+		if len(hashes) != 1 || hashes[0].CID != img.Cid().String() || hashes[0].Width != 16 || hashes[0].Height != 8 {
+			t.Errorf("Expected the image to be hashed, got %+v", hashes)
+		}
+		var rejected []repo.RejectedImage
+		if err := db.Find(&rejected).Error; err != nil {
+			return err
+		}
+		if len(rejected) != 1 || rejected[0].CID != text.Cid().String() {
+			t.Errorf("Expected the text file to be rejected, got %+v", rejected)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,152 @@
+package crawler
+
+import (
+	"encoding/json"
+	"github.com/cpacia/obcrawler/pricing"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+	"time"
+)
+
+// feeTypeNames are the names moderator fee types are stored under.
+var feeTypeNames = map[models.ModeratorFeeType]string{
+	models.FixedFee:               "FixedFee",
+	models.PercentageFee:          "PercentageFee",
+	models.FixedPlusPercentageFee: "FixedPlusPercentageFee",
+}
+
+// setModerator saves the moderation terms of the profile or deletes them
+// if the profile no longer offers moderation.
+func (c *Crawler) setModerator(db *gorm.DB, peerID string, profile *models.Profile, expiration time.Time) error {
+	if !profile.Moderator || profile.ModeratorInfo == nil {
+		return db.Where("peer_id=?", peerID).Delete(&repo.Moderator{}).Error
+	}
+	info := profile.ModeratorInfo
+	mod := repo.Moderator{
+		PeerID:             peerID,
+		Languages:          strings.Join(info.Languages, ","),
+		AcceptedCurrencies: strings.ToUpper(strings.Join(info.AcceptedCurrencies, ",")),
+		FeeType:            feeTypeNames[info.Fee.FeeType],
+		Percentage:         info.Fee.Percentage,
+		Expiration:         expiration,
+	}
+	if fee := info.Fee.FixedFee; fee != nil && fee.Currency != nil && info.Fee.FeeType != models.PercentageFee {
+		if amount, err := pricing.Amount(fee.Amount.String(), fee.Currency.Divisibility); err == nil {
+			mod.FixedFee = &amount
+			mod.FixedFeeCurrency = strings.ToUpper(fee.Currency.Code.String())
+			if c.prices != nil {
+				if n, ok := c.prices.Normalize(amount, mod.FixedFeeCurrency); ok {
+					mod.NormalizedFixedFee = &n
+				}
+			}
+		}
+	}
+	return db.Clauses(upsert).Create(&mod).Error
+}
+
+// ListModerators returns the crawled profiles which offer moderation and
+// match the query, most recently seen first. Expired profiles are never
+// returned.
+func (c *Crawler) ListModerators(query *rpc.ModeratorQuery) (*rpc.ModeratorResults, error) {
+	var (
+		moderators []repo.Moderator
+		profiles   []repo.Profile
+		peers      []repo.Peer
+	)
+	err := c.db.View(func(db *gorm.DB) error {
+		if err := db.Where("expiration>?", time.Now()).Find(&moderators).Error; err != nil {
+			return err
+		}
+		peerIDs := make([]string, 0, len(moderators))
+		for _, m := range moderators {
+			peerIDs = append(peerIDs, m.PeerID)
+		}
+		if len(peerIDs) == 0 {
+			return nil
+		}
+		if err := db.Where("peer_id IN ?", peerIDs).Find(&profiles).Error; err != nil {
+			return err
+		}
+		return db.Where("peer_id IN ?", peerIDs).Find(&peers).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	profileMap := make(map[string]repo.Profile)
+	for _, p := range profiles {
+		profileMap[p.PeerID] = p
+	}
+	lastSeen := make(map[string]time.Time)
+	for _, p := range peers {
+		lastSeen[p.PeerID] = p.LastSeen
+	}
+
+	ret := new(rpc.ModeratorResults)
+	if c.prices != nil {
+		ret.PriceCurrency = c.prices.Base()
+	}
+	for _, m := range moderators {
+		p, ok := profileMap[m.PeerID]
+		if !ok {
+			continue
+		}
+		if !matchesModerator(&m, query, c.prices != nil) {
+			continue
+		}
+		seen := lastSeen[m.PeerID]
+		if query.SeenWithin > 0 && time.Since(seen) > query.SeenWithin {
+			continue
+		}
+		profile := new(models.Profile)
+		if err := json.Unmarshal(p.Profile, profile); err != nil {
+			return nil, err
+		}
+		ret.Moderators = append(ret.Moderators, &rpc.Moderator{
+			Profile:            profile,
+			LastSeen:           seen,
+			NormalizedFixedFee: m.NormalizedFixedFee,
+			ExpirationDate:     m.Expiration,
+		})
+	}
+	sort.SliceStable(ret.Moderators, func(i, j int) bool {
+		return ret.Moderators[i].LastSeen.After(ret.Moderators[j].LastSeen)
+	})
+	return ret, nil
+}
+
+// matchesModerator returns whether the moderator's terms are accepted by
+// the query. A fixed fee can only be compared to the ceiling if it could
+// be normalized.
+func matchesModerator(m *repo.Moderator, query *rpc.ModeratorQuery, normalized bool) bool {
+	if query.Currency != "" && !containsFold(m.AcceptedCurrencies, query.Currency) {
+		return false
+	}
+	if query.Language != "" && !containsFold(m.Languages, query.Language) {
+		return false
+	}
+	if query.MaxPercentage > 0 && m.Percentage > query.MaxPercentage {
+		return false
+	}
+	if query.MaxFixedFee > 0 && m.FixedFee != nil {
+		if !normalized || m.NormalizedFixedFee == nil || *m.NormalizedFixedFee > query.MaxFixedFee {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold returns whether the comma separated list contains the
+// value. Values are compared case insensitively.
+func containsFold(list, value string) bool {
+	for _, v := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/pricing"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	"gorm.io/gorm"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestCrawler_ListModerators(t *testing.T) {
+	dir, err := ioutil.TempDir("", "moderators")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ratesPath := path.Join(dir, "rates.json")
+	if err := ioutil.WriteFile(ratesPath, []byte(`{"BTC": 40000}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		db:     db,
+		prices: pricing.NewNormalizer("USD", &pricing.FileProvider{Path: ratesPath}),
+	}
+	if err := c.prices.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+
+	newModerator := func(name string, fee models.ModeratorFee, currencies, languages []string) *models.Profile {
+		return &models.Profile{
+			Name:      name,
+			Moderator: true,
+			ModeratorInfo: &models.ModeratorInfo{
+				Languages:          languages,
+				AcceptedCurrencies: currencies,
+				Fee:                fee,
+			},
+		}
+	}
+	expiration := time.Now().Add(time.Hour)
+	profiles := map[string]*models.Profile{
+		"QmPercent": newModerator("Percent", models.ModeratorFee{
+			FeeType:    models.PercentageFee,
+			Percentage: 5,
+		}, []string{"btc", "bch"}, []string{"en"}),
+		"QmFixed": newModerator("Fixed", models.ModeratorFee{
+			FeeType:  models.FixedFee,
+			FixedFee: models.NewCurrencyValueFromInt(500000, &models.Currency{Code: "BTC", Divisibility: 8}),
+		}, []string{"BTC"}, []string{"en", "es"}),
+		"QmCheap": newModerator("Cheap", models.ModeratorFee{
+			FeeType:    models.FixedPlusPercentageFee,
+			Percentage: 1,
+			FixedFee:   models.NewCurrencyValueFromInt(500, &models.Currency{Code: "USD", Divisibility: 2}),
+		}, []string{"ZEC"}, []string{"fr"}),
+		"QmVendor": {Name: "Vendor", Vendor: true},
+	}
+	for peerID, profile := range profiles {
+		if err := c.indexProfile(peerID, profile, expiration); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.indexProfile("QmExpired", newModerator("Expired", models.ModeratorFee{FeeType: models.PercentageFee}, []string{"BTC"}, []string{"en"}), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	err = db.Update(func(tx *gorm.DB) error {
+		for peerID, lastSeen := range map[string]time.Time{
+			"QmPercent": now.Add(-time.Hour * 48),
+			"QmFixed":   now.Add(-time.Minute),
+			"QmCheap":   now.Add(-time.Hour),
+		} {
+			if err := tx.Save(&repo.Peer{PeerID: peerID, LastSeen: lastSeen}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(res *rpc.ModeratorResults) []string {
+		var ret []string
+		for _, m := range res.Moderators {
+			ret = append(ret, m.Profile.Name)
+		}
+		return ret
+	}
+	tests := []struct {
+		query    rpc.ModeratorQuery
+		expected []string
+	}{
+		{rpc.ModeratorQuery{}, []string{"Fixed", "Cheap", "Percent"}},
+		{rpc.ModeratorQuery{Currency: "btc"}, []string{"Fixed", "Percent"}},
+		{rpc.ModeratorQuery{Language: "ES"}, []string{"Fixed"}},
+		{rpc.ModeratorQuery{MaxPercentage: 2}, []string{"Fixed", "Cheap"}},
+		{rpc.ModeratorQuery{MaxFixedFee: 100}, []string{"Cheap", "Percent"}},
+		{rpc.ModeratorQuery{SeenWithin: time.Hour * 2}, []string{"Fixed", "Cheap"}},
+	}
+	for i, test := range tests {
+		res, err := c.ListModerators(&test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := names(res)
+		if len(got) != len(test.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i, test.expected, got)
+		}
+		for j := range got {
+			if got[j] != test.expected[j] {
+				t.Errorf("Test %d: expected %v, got %v", i, test.expected, got)
+			}
+		}
+	}
+
+	res, err := c.ListModerators(&rpc.ModeratorQuery{Language: "es"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PriceCurrency != "USD" {
+		t.Errorf("Expected price currency USD, got %s", res.PriceCurrency)
+	}
+	if fee := res.Moderators[0].NormalizedFixedFee; fee == nil || *fee != 200 {
+		t.Errorf("Expected normalized fixed fee of 200, got %v", fee)
+	}
+
+	// Moderators which stop offering moderation are removed.
+	if err := c.indexProfile("QmFixed", &models.Profile{Name: "Fixed"}, expiration); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.removeFromIndex("QmCheap"); err != nil {
+		t.Fatal(err)
+	}
+	res, err = c.ListModerators(&rpc.ModeratorQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(res); len(got) != 1 || got[0] != "Percent" {
+		t.Errorf("Expected only Percent to remain, got %v", got)
+	}
+}
@@ -0,0 +1,172 @@
+package crawler
+
+import (
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/gogo/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	// pruneInterval is how often observations of peers which were never
+	// promoted to OpenBazaar stores are pruned.
+	pruneInterval = time.Hour
+
+	// migrateBatchSize is the number of legacy peers moved to the observed
+	// peers in each transaction.
+	migrateBatchSize = 1000
+)
+
+// markSeen updates the last seen time of the peer, adding it to the
+// observed peers if it's new. It returns whether the peer is new. If the
+// peer is a known store its last seen time is updated too.
+func (c *Crawler) markSeen(pid peer.ID) (bool, error) {
+	var isNew bool
+	err := c.db.Update(func(db *gorm.DB) error {
+		var observed repo.ObservedPeer
+		err := db.Where("peer_id=?", pid.Pretty()).First(&observed).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			observed.FirstSeen = time.Now()
+			observed.PeerID = pid.Pretty()
+			isNew = true
+			log.Debugf("Observed new peer: %s", pid.Pretty())
+		}
+		observed.LastSeen = time.Now()
+		if err := db.Save(&observed).Error; err != nil {
+			return err
+		}
+		return db.Model(&repo.Peer{}).Where("peer_id=?", pid.Pretty()).Update("last_seen", observed.LastSeen).Error
+	})
+	return isNew, err
+}
+
+// promotePeer records the peer as an OpenBazaar store, or refreshes it
+// if it already is one, saving the IPNS record of the job. It must only
+// be called once the record has been found to resolve to a valid
+// OpenBazaar root.
+func (c *Crawler) promotePeer(job *job) error {
+	ser, err := proto.Marshal(job.IPNSRecord)
+	if err != nil {
+		return err
+	}
+	return c.db.Update(func(db *gorm.DB) error {
+		var peer repo.Peer
+		err := db.Where("peer_id=?", job.Peer.Pretty()).First(&peer).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			var observed repo.ObservedPeer
+			err := db.Where("peer_id=?", job.Peer.Pretty()).First(&observed).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			peer.PeerID = job.Peer.Pretty()
+			peer.FirstSeen = time.Now()
+			peer.LastSeen = time.Now()
+			if !observed.FirstSeen.IsZero() {
+				peer.FirstSeen = observed.FirstSeen
+				peer.LastSeen = observed.LastSeen
+			}
+			log.Infof("Detected new OpenBazaar store: %s", job.Peer.Pretty())
+		}
+		peer.IPNSRecord = ser
+		peer.IPNSExpiration = job.Expiration
+		return db.Save(&peer).Error
+	})
+}
+
+// runPruner periodically prunes observations of peers which haven't been
+// promoted to OpenBazaar stores. Peers recorded as stores before observed
+// peers were split from them are first moved to the observed peers.
+func (c *Crawler) runPruner() {
+	n, err := c.migrateObservedPeers()
+	if err != nil {
+		log.Errorf("Error migrating observed peers: %s", err)
+	} else if n > 0 {
+		log.Infof("Moved %d peers which were never found to be stores to the observed peers", n)
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	for {
+		select {
+		case <-ticker.C:
+			n, err := c.pruneObservedPeers()
+			if err != nil {
+				log.Errorf("Error pruning observed peers: %s", err)
+				continue
+			}
+			log.Debugf("Pruned %d observed peers", n)
+		case <-c.shutdown:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// pruneObservedPeers deletes the observed peers which haven't been seen
+// within the observed peer expiry and were never promoted to OpenBazaar
+// stores. It returns the number of peers deleted.
+func (c *Crawler) pruneObservedPeers() (int64, error) {
+	var n int64
+	err := c.db.Update(func(db *gorm.DB) error {
+		tx := db.Where("last_seen<?", time.Now().Add(-c.observedExpiry)).
+			Where("peer_id NOT IN (?)", db.Model(&repo.Peer{}).Select("peer_id")).
+			Delete(&repo.ObservedPeer{})
+		n = tx.RowsAffected
+		return tx.Error
+	})
+	return n, err
+}
+
+// migrateObservedPeers moves the peers which were recorded as stores when
+// every connection created a Peer, but never had an IPNS record, to the
+// observed peers so they are pruned like any other observation. Banned and
+// quarantined peers are kept. It returns the number of peers moved.
+func (c *Crawler) migrateObservedPeers() (int64, error) {
+	var total int64
+	for {
+		var peers []repo.Peer
+		err := c.db.Update(func(db *gorm.DB) error {
+			err := db.Where("ip_ns_record IS NULL OR LENGTH(ip_ns_record)=0").
+				Where("banned=?", false).
+				Where("quarantined=?", false).
+				Limit(migrateBatchSize).
+				Find(&peers).Error
+			if err != nil {
+				return err
+			}
+			for _, p := range peers {
+				var observed repo.ObservedPeer
+				err := db.Where("peer_id=?", p.PeerID).First(&observed).Error
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				observed.PeerID = p.PeerID
+				if observed.FirstSeen.IsZero() || p.FirstSeen.Before(observed.FirstSeen) {
+					observed.FirstSeen = p.FirstSeen
+				}
+				if p.LastSeen.After(observed.LastSeen) {
+					observed.LastSeen = p.LastSeen
+				}
+				if err := db.Save(&observed).Error; err != nil {
+					return err
+				}
+				if err := db.Where("peer_id=?", p.PeerID).Delete(&repo.Peer{}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += int64(len(peers))
+		if len(peers) < migrateBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/repo"
+	ipnspb "github.com/ipfs/go-ipns/pb"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCrawler_PromoteAndPrune(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		db:             db,
+		observedExpiry: time.Hour,
+	}
+
+	var (
+		stale    = peer.ID("stale")
+		fresh    = peer.ID("fresh")
+		promoted = peer.ID("promoted")
+	)
+	for _, pid := range []peer.ID{stale, fresh, promoted} {
+		isNew, err := c.markSeen(pid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isNew {
+			t.Errorf("Expected peer %s to be new", pid)
+		}
+	}
+
+	err = c.promotePeer(&job{
+		Peer:       promoted,
+		IPNSRecord: &ipnspb.IpnsEntry{Value: []byte("/ipfs/root")},
+		Expiration: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var peers []repo.Peer
+	err = db.View(func(db *gorm.DB) error {
+		return db.Find(&peers).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || peers[0].PeerID != promoted.Pretty() {
+		t.Fatalf("Expected only the promoted peer to be a store, got %v", peers)
+	}
+	if len(peers[0].IPNSRecord) == 0 || peers[0].FirstSeen.IsZero() {
+		t.Error("Expected store to be saved with its record and first seen time")
+	}
+
+	// Age the observations of everything but the fresh peer.
+	err = db.Update(func(db *gorm.DB) error {
+		return db.Model(&repo.ObservedPeer{}).
+			Where("peer_id<>?", fresh.Pretty()).
+			Update("last_seen", time.Now().Add(-time.Hour*2)).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := c.pruneObservedPeers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 observed peer pruned, got %d", n)
+	}
+
+	var observed []repo.ObservedPeer
+	err = db.View(func(db *gorm.DB) error {
+		return db.Order("peer_id asc").Find(&observed).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(observed) != 2 || observed[0].PeerID != fresh.Pretty() || observed[1].PeerID != promoted.Pretty() {
+		t.Errorf("Expected fresh and promoted peers to remain, got %v", observed)
+	}
+}
+
+func TestCrawler_MigrateObservedPeers(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{db: db}
+
+	lastSeen := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err = db.Update(func(db *gorm.DB) error {
+		for _, p := range []*repo.Peer{
+			{PeerID: "legacy", FirstSeen: lastSeen.Add(-time.Hour), LastSeen: lastSeen},
+			{PeerID: "store", IPNSRecord: []byte{0x01}},
+			{PeerID: "banned", Banned: true},
+			{PeerID: "quarantined", Quarantined: true},
+		} {
+			if err := db.Create(p).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := c.migrateObservedPeers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 peer migrated, got %d", n)
+	}
+
+	err = db.View(func(db *gorm.DB) error {
+		var peers []repo.Peer
+		if err := db.Order("peer_id asc").Find(&peers).Error; err != nil {
+			return err
+		}
+		if len(peers) != 3 || peers[0].PeerID != "banned" || peers[1].PeerID != "quarantined" || peers[2].PeerID != "store" {
+			t.Errorf("Expected the store, banned and quarantined peers to remain, got %v", peers)
+		}
+		var observed repo.ObservedPeer
+		if err := db.Where("peer_id=?", "legacy").First(&observed).Error; err != nil {
+			return err
+		}
+		if !observed.LastSeen.Equal(lastSeen) {
+			t.Errorf("Expected last seen %s, got %s", lastSeen, observed.LastSeen)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,570 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/ipfs/go-namesys"
+	"github.com/ipfs/interface-go-ipfs-core/options"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pinManager keeps track of the CIDs pinned by the crawler's IPFS nodes.
+// A peer's data is pinned on the nodes the hash ring assigns the peer to.
+// Each pin is reference counted by the peers whose data includes the CID
+// and is only unpinned from a node once no peer assigned to that node
+// references it anymore.
+type pinManager struct {
+	nodes []*core.OpenBazaarNode
+	ring  *hashRing
+	db    *repo.Database
+	ctx   context.Context
+
+	// mtx is held while the pin table is updated and while unpinning so
+	// that an unpin can never race with a new reference to the same CID.
+	mtx sync.Mutex
+}
+
+// pinOp is an operation to perform against the IPFS pin set
+// of a node once the pin table has been updated.
+type pinOp struct {
+	id        cid.Cid
+	node      uint
+	recursive bool
+	unpin     bool
+}
+
+// upsert is used to save pins and references. Save can't be used as it
+// inserts rather than updates when the node in the primary key is zero.
+var upsert = clause.OnConflict{UpdateAll: true}
+
+// pinKey identifies a CID pinned by a node.
+type pinKey struct {
+	cid  string
+	node uint
+}
+
+func newPinManager(ctx context.Context, nodes []*core.OpenBazaarNode, ring *hashRing, db *repo.Database) *pinManager {
+	return &pinManager{
+		nodes: nodes,
+		ring:  ring,
+		db:    db,
+		ctx:   ctx,
+		mtx:   sync.Mutex{},
+	}
+}
+
+// setPeerPins replaces the set of pins referenced by the peer with the
+// provided one, held on each of the given nodes. The map value is whether
+// the CID should be pinned recursively. Pins no longer referenced by any
+// peer are removed.
+func (pm *pinManager) setPeerPins(pid peer.ID, nodes []uint, pins map[cid.Cid]bool) error {
+	pm.mtx.Lock()
+	var ops []pinOp
+	err := pm.db.Update(func(db *gorm.DB) error {
+		var refs []repo.PinRef
+		err := db.Where("peer_id=?", pid.Pretty()).Find(&refs).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		existing := make(map[pinKey]repo.PinRef)
+		for _, ref := range refs {
+			existing[pinKey{ref.CID, ref.Node}] = ref
+		}
+
+		var (
+			wanted  = make(map[pinKey]bool)
+			changed = make(map[pinKey]bool)
+		)
+		for id, recursive := range pins {
+			for _, node := range nodes {
+				key := pinKey{id.String(), node}
+				wanted[key] = true
+				ref, ok := existing[key]
+				if ok && ref.Recursive == recursive {
+					continue
+				}
+				ref.CID = key.cid
+				ref.PeerID = pid.Pretty()
+				ref.Node = node
+				ref.Recursive = recursive
+				if err := db.Clauses(upsert).Create(&ref).Error; err != nil {
+					return err
+				}
+				changed[key] = true
+			}
+		}
+		for key, ref := range existing {
+			if wanted[key] {
+				continue
+			}
+			if err := db.Where("c_id=?", ref.CID).Where("peer_id=?", ref.PeerID).Where("node=?", ref.Node).Delete(&repo.PinRef{}).Error; err != nil {
+				return err
+			}
+			changed[key] = true
+		}
+
+		for key := range changed {
+			pinOps, err := pm.updatePin(db, key.cid, key.node)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, pinOps...)
+		}
+		return nil
+	})
+	if err != nil {
+		pm.mtx.Unlock()
+		return err
+	}
+
+	// Unpins are done before releasing the lock. Pins can be slow as the
+	// data may need to be downloaded so they are done after.
+	var toPin []pinOp
+	for _, op := range ops {
+		if op.unpin {
+			pm.apply(op)
+		} else {
+			toPin = append(toPin, op)
+		}
+	}
+	pm.mtx.Unlock()
+
+	for _, op := range toPin {
+		pm.apply(op)
+	}
+	return nil
+}
+
+// releasePeer releases all of the pins referenced by the peer.
+func (pm *pinManager) releasePeer(pid peer.ID) error {
+	return pm.setPeerPins(pid, nil, nil)
+}
+
+// rebalance moves the pins of peers which are not held by the nodes the
+// hash ring assigns them to. This happens when the number of nodes or
+// replicas changes. The data is pinned on the new nodes before it is
+// unpinned from the old ones. It returns the number of peers moved.
+func (pm *pinManager) rebalance() (int, error) {
+	var assigned []repo.PinRef
+	err := pm.db.View(func(db *gorm.DB) error {
+		return db.Model(&repo.PinRef{}).Distinct("peer_id", "node").Find(&assigned).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	current := make(map[string]map[uint]bool)
+	for _, ref := range assigned {
+		if current[ref.PeerID] == nil {
+			current[ref.PeerID] = make(map[uint]bool)
+		}
+		current[ref.PeerID][ref.Node] = true
+	}
+
+	moved := 0
+	for p, nodes := range current {
+		pid, err := peer.Decode(p)
+		if err != nil {
+			continue
+		}
+		owners := pm.ring.owners(pid)
+		if len(owners) == len(nodes) {
+			same := true
+			for _, n := range owners {
+				same = same && nodes[n]
+			}
+			if same {
+				continue
+			}
+		}
+
+		var refs []repo.PinRef
+		err = pm.db.View(func(db *gorm.DB) error {
+			return db.Where("peer_id=?", p).Find(&refs).Error
+		})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return moved, err
+		}
+		pins := make(map[cid.Cid]bool)
+		for _, ref := range refs {
+			id, err := cid.Decode(ref.CID)
+			if err != nil {
+				continue
+			}
+			pins[id] = pins[id] || ref.Recursive
+		}
+
+		union := append([]uint{}, owners...)
+		for n := range nodes {
+			if int(n) < len(pm.nodes) && !containsNode(owners, n) {
+				union = append(union, n)
+			}
+		}
+		if err := pm.setPeerPins(pid, union, pins); err != nil {
+			return moved, err
+		}
+		if err := pm.setPeerPins(pid, owners, pins); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	if moved > 0 {
+		log.Infof("Rebalanced pins for %d peers across %d nodes", moved, len(pm.nodes))
+	}
+	return moved, nil
+}
+
+// updatePin recomputes the node's pin for the CID from its references
+// and returns the operations needed to bring the IPFS pin set in line.
+// A pin is recursive if any peer references it recursively.
+func (pm *pinManager) updatePin(db *gorm.DB, c string, node uint) ([]pinOp, error) {
+	id, err := cid.Decode(c)
+	if err != nil {
+		return nil, err
+	}
+	var refCount, recursiveCount int64
+	if err := db.Model(&repo.PinRef{}).Where("c_id=?", c).Where("node=?", node).Count(&refCount).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&repo.PinRef{}).Where("c_id=?", c).Where("node=?", node).Where("recursive=?", true).Count(&recursiveCount).Error; err != nil {
+		return nil, err
+	}
+
+	var pin repo.Pin
+	err = db.Where("c_id=?", c).Where("node=?", node).First(&pin).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	found := err == nil
+
+	if refCount == 0 {
+		if !found {
+			return nil, nil
+		}
+		if err := db.Where("c_id=?", c).Where("node=?", node).Delete(&repo.Pin{}).Error; err != nil {
+			return nil, err
+		}
+		return []pinOp{{id: id, node: node, unpin: true}}, nil
+	}
+
+	var (
+		ops       []pinOp
+		recursive = recursiveCount > 0
+	)
+	if !found {
+		pin = repo.Pin{CID: c, Node: node}
+		ops = append(ops, pinOp{id: id, node: node, recursive: recursive})
+	} else if pin.Recursive != recursive {
+		// A recursive pin has to be removed before the CID can be pinned
+		// directly. Going the other way IPFS replaces the direct pin.
+		if pin.Recursive {
+			ops = append(ops, pinOp{id: id, node: node, unpin: true})
+		}
+		ops = append(ops, pinOp{id: id, node: node, recursive: recursive})
+	}
+	pin.Recursive = recursive
+	pin.RefCount = uint(refCount)
+	if err := db.Clauses(upsert).Create(&pin).Error; err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// apply performs the pin operation against the node's IPFS pin set.
+func (pm *pinManager) apply(op pinOp) {
+	if int(op.node) >= len(pm.nodes) {
+		if op.unpin {
+			// The node no longer exists so there is nothing to unpin.
+			return
+		}
+		log.Errorf("Error applying pin for %s: node %d does not exist", op.id, op.node)
+		return
+	}
+	capi, err := coreapi.NewCoreAPI(pm.nodes[op.node].IPFSNode())
+	if err != nil {
+		log.Errorf("Error loading core API for node %d: %s", op.node, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(pm.ctx, catTimeout)
+	defer cancel()
+
+	if op.unpin {
+		if err := capi.Pin().Rm(ctx, path.IpfsPath(op.id)); err != nil {
+			log.Errorf("Error unpinning %s on node %d: %s", op.id, op.node, err)
+		}
+		return
+	}
+	if err := capi.Pin().Add(ctx, path.IpfsPath(op.id), options.Pin.Recursive(op.recursive)); err != nil {
+		log.Errorf("Error pinning %s on node %d: %s", op.id, op.node, err)
+	}
+}
+
+// nodePins returns the recursive and direct pins held by the node. The
+// map value is whether the pin is recursive. The root of the node's own
+// published data is left out as it is not managed by the pin manager.
+func (pm *pinManager) nodePins(n *core.OpenBazaarNode) (map[string]bool, error) {
+	capi, err := coreapi.NewCoreAPI(n.IPFSNode())
+	if err != nil {
+		return nil, err
+	}
+	var ownRoot cid.Cid
+	rec, err := namesys.NewIpnsPublisher(n.IPFSNode().Routing, n.IPFSNode().Repo.Datastore()).GetPublished(pm.ctx, n.Identity(), false)
+	if err == nil && rec != nil {
+		ownRoot, _ = cid.Decode(strings.TrimPrefix(string(rec.GetValue()), "/ipfs/"))
+	}
+	ret := make(map[string]bool)
+	for _, typ := range []options.PinLsOption{options.Pin.Ls.Recursive(), options.Pin.Ls.Direct()} {
+		ch, err := capi.Pin().Ls(pm.ctx, typ)
+		if err != nil {
+			return nil, err
+		}
+		for p := range ch {
+			if p.Err() != nil {
+				return nil, p.Err()
+			}
+			if p.Path().Cid().Equals(ownRoot) {
+				continue
+			}
+			ret[p.Path().Cid().String()] = p.Type() == "recursive"
+		}
+	}
+	return ret, nil
+}
+
+// PinDiff is a single difference between the pin table and the
+// pin set of one of the crawler's IPFS nodes.
+type PinDiff struct {
+	CID    string
+	Node   uint
+	PeerID string
+}
+
+// PinReport describes the differences found and repaired by a pin
+// reconciliation.
+type PinReport struct {
+	// Missing pins are in the pin table but were not pinned by the node
+	// holding them. They have been pinned again.
+	Missing []PinDiff
+
+	// Orphaned pins were pinned by a node but not referenced by any peer.
+	// They have been unpinned.
+	Orphaned []PinDiff
+
+	// Duplicate pins were held by a node which is not assigned to any of
+	// the peers referencing the CID. They have been unpinned.
+	Duplicate []PinDiff
+
+	// Stale references were held by peers which are banned or quarantined
+	// or no longer have an unquarantined CIDRecord for the CID. They have
+	// been released.
+	Stale []PinDiff
+
+	// Adopted pins were pinned by a node and referenced by a crawled peer
+	// but missing from the pin table. They have been added to it.
+	Adopted []PinDiff
+}
+
+// reconcile compares the pin table and the CIDRecords it is derived from
+// against the pins actually held by each node and repairs the differences.
+//
+// Peers are first rebalanced onto the nodes the hash ring assigns them to.
+// References held by banned or quarantined peers or peers which no longer
+// have an unquarantined CIDRecord for the CID are then released. Pins
+// missing from their node are pinned again and pins not in the table are
+// removed.
+//
+// A pin that is not in the table but belongs to a non-banned, unquarantined
+// peer that was crawled is adopted rather than removed if the node is
+// assigned to the peer, so that data pinned before the pin table existed
+// is not thrown away.
+func (pm *pinManager) reconcile() (*PinReport, error) {
+	if _, err := pm.rebalance(); err != nil {
+		return nil, err
+	}
+
+	pm.mtx.Lock()
+	actual := make([]map[string]bool, len(pm.nodes))
+	for i, n := range pm.nodes {
+		pins, err := pm.nodePins(n)
+		if err != nil {
+			pm.mtx.Unlock()
+			return nil, err
+		}
+		actual[i] = pins
+	}
+
+	var (
+		ops    []pinOp
+		report = &PinReport{}
+	)
+	err := pm.db.Update(func(db *gorm.DB) error {
+		var stale []repo.PinRef
+		err := db.Where("peer_id IN (?)", db.Model(&repo.Peer{}).Select("peer_id").Where("banned=? OR quarantined=?", true, true)).
+			Or("NOT EXISTS (?)", db.Model(&repo.CIDRecord{}).Select("1").Where("c_id_records.c_id = pin_refs.c_id AND c_id_records.peer_id = pin_refs.peer_id AND c_id_records.quarantined = ?", false)).
+			Find(&stale).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		for _, ref := range stale {
+			if err := db.Where("c_id=?", ref.CID).Where("peer_id=?", ref.PeerID).Where("node=?", ref.Node).Delete(&repo.PinRef{}).Error; err != nil {
+				return err
+			}
+			pinOps, err := pm.updatePin(db, ref.CID, ref.Node)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, pinOps...)
+			report.Stale = append(report.Stale, PinDiff{CID: ref.CID, Node: ref.Node, PeerID: ref.PeerID})
+		}
+
+		released := make(map[PinDiff]bool)
+		for _, op := range ops {
+			if op.unpin {
+				released[PinDiff{CID: op.id.String(), Node: op.node}] = true
+			}
+		}
+
+		var pins []repo.Pin
+		if err := db.Find(&pins).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		table := make(map[pinKey]bool)
+		for _, pin := range pins {
+			id, err := cid.Decode(pin.CID)
+			if err != nil {
+				continue
+			}
+			var refCount int64
+			if err := db.Model(&repo.PinRef{}).Where("c_id=?", pin.CID).Where("node=?", pin.Node).Count(&refCount).Error; err != nil {
+				return err
+			}
+			if refCount == 0 || int(pin.Node) >= len(pm.nodes) {
+				// Not referenced by any peer or held by a node which no
+				// longer exists. Leaving it out of the table means it is
+				// unpinned below as an orphan.
+				if err := db.Where("c_id=?", pin.CID).Where("node=?", pin.Node).Delete(&repo.Pin{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			table[pinKey{pin.CID, pin.Node}] = true
+			if recursive, ok := actual[pin.Node][pin.CID]; !ok || recursive != pin.Recursive {
+				// As in updatePin a recursive pin has to be removed before
+				// the CID can be pinned directly.
+				if ok && recursive {
+					ops = append(ops, pinOp{id: id, node: pin.Node, unpin: true})
+				}
+				ops = append(ops, pinOp{id: id, node: pin.Node, recursive: pin.Recursive})
+				report.Missing = append(report.Missing, PinDiff{CID: pin.CID, Node: pin.Node})
+			}
+		}
+
+		for i, nodePins := range actual {
+			for c, recursive := range nodePins {
+				id, err := cid.Decode(c)
+				if err != nil {
+					continue
+				}
+				if released[PinDiff{CID: c, Node: uint(i)}] {
+					continue
+				}
+				if table[pinKey{c, uint(i)}] {
+					continue
+				}
+
+				var recs []repo.CIDRecord
+				err = db.Where("c_id=?", c).Where("quarantined=?", false).
+					Where("peer_id NOT IN (?)", db.Model(&repo.Peer{}).Select("peer_id").Where("banned=? OR quarantined=?", true, true)).
+					Find(&recs).Error
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				if len(recs) == 0 {
+					ops = append(ops, pinOp{id: id, node: uint(i), unpin: true})
+					report.Orphaned = append(report.Orphaned, PinDiff{CID: c, Node: uint(i)})
+					continue
+				}
+				peers := make(map[string]bool)
+				for _, rec := range recs {
+					pid, err := peer.Decode(rec.PeerID)
+					if err != nil || peers[rec.PeerID] || !containsNode(pm.ring.owners(pid), uint(i)) {
+						continue
+					}
+					if err := db.Clauses(upsert).Create(&repo.PinRef{CID: c, PeerID: rec.PeerID, Node: uint(i), Recursive: recursive}).Error; err != nil {
+						return err
+					}
+					peers[rec.PeerID] = true
+				}
+				if len(peers) == 0 {
+					ops = append(ops, pinOp{id: id, node: uint(i), unpin: true})
+					report.Duplicate = append(report.Duplicate, PinDiff{CID: c, Node: uint(i)})
+					continue
+				}
+				pin := repo.Pin{CID: c, Node: uint(i), Recursive: recursive, RefCount: uint(len(peers))}
+				if err := db.Clauses(upsert).Create(&pin).Error; err != nil {
+					return err
+				}
+				table[pinKey{c, uint(i)}] = true
+				report.Adopted = append(report.Adopted, PinDiff{CID: c, Node: uint(i)})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		pm.mtx.Unlock()
+		return nil, err
+	}
+
+	var toPin []pinOp
+	for _, op := range ops {
+		if op.unpin {
+			pm.apply(op)
+		} else {
+			toPin = append(toPin, op)
+		}
+	}
+	pm.mtx.Unlock()
+
+	for _, op := range toPin {
+		pm.apply(op)
+	}
+	log.Infof("Reconciled pins: %d missing, %d orphaned, %d duplicate, %d stale, %d adopted",
+		len(report.Missing), len(report.Orphaned), len(report.Duplicate), len(report.Stale), len(report.Adopted))
+	return report, nil
+}
+
+// containsNode returns whether the node is in the list.
+func containsNode(nodes []uint, node uint) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// runPinReconciler periodically reconciles the pin table against the
+// pins held by the IPFS nodes.
+func (c *Crawler) runPinReconciler() {
+	ticker := time.NewTicker(c.reconcileInterval)
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.pins.reconcile(); err != nil {
+				log.Errorf("Error reconciling pins: %s", err)
+			}
+		case <-c.shutdown:
+			ticker.Stop()
+			return
+		}
+	}
+}
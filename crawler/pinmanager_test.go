@@ -0,0 +1,277 @@
+package crawler
+
+import (
+	"context"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/ipfs/go-cid"
+	files "github.com/ipfs/go-ipfs-files"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/ipfs/interface-go-ipfs-core/options"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestPinManager_RefCount(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pm := newPinManager(context.Background(), mn.Nodes(), newHashRing(2, 0), db)
+
+	capi, err := coreapi.NewCoreAPI(mn.Nodes()[0].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pth, err := capi.Unixfs().Add(context.Background(), files.NewBytesFile([]byte("shared data")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := pth.Cid()
+
+	isPinned := func() bool {
+		_, pinned, err := capi.Pin().IsPinned(context.Background(), path.IpfsPath(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pinned
+	}
+
+	peer1, peer2 := mn.Nodes()[0].Identity(), mn.Nodes()[1].Identity()
+
+	if err := pm.setPeerPins(peer1, []uint{0}, map[cid.Cid]bool{id: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.setPeerPins(peer2, []uint{0}, map[cid.Cid]bool{id: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !isPinned() {
+		t.Fatal("Expected cid to be pinned")
+	}
+
+	var pin repo.Pin
+	err = db.View(func(db *gorm.DB) error {
+		return db.Where("c_id=?", id.String()).First(&pin).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pin.RefCount != 2 {
+		t.Errorf("Expected refcount of 2, got %d", pin.RefCount)
+	}
+
+	if err := pm.releasePeer(peer1); err != nil {
+		t.Fatal(err)
+	}
+	if !isPinned() {
+		t.Fatal("Expected cid to remain pinned while referenced")
+	}
+
+	if err := pm.releasePeer(peer2); err != nil {
+		t.Fatal(err)
+	}
+	if isPinned() {
+		t.Fatal("Expected cid to be unpinned")
+	}
+}
+
+func TestPinManager_Reconcile(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := newHashRing(2, 0)
+	pm := newPinManager(context.Background(), mn.Nodes(), ring, db)
+
+	pid := mn.Nodes()[0].Identity()
+	owner := ring.owners(pid)[0]
+
+	capi, err := coreapi.NewCoreAPI(mn.Nodes()[owner].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherAPI, err := coreapi.NewCoreAPI(mn.Nodes()[1-owner].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	orphan, err := capi.Unixfs().Add(context.Background(), files.NewBytesFile([]byte("orphan")), options.Unixfs.Pin(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracked, err := capi.Unixfs().Add(context.Background(), files.NewBytesFile([]byte("tracked")), options.Unixfs.Pin(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, err := capi.Unixfs().Add(context.Background(), files.NewBytesFile([]byte("stale")), options.Unixfs.Pin(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Pinned recursively by IPFS but tracked as a direct pin.
+	direct, err := capi.Unixfs().Add(context.Background(), files.NewBytesFile([]byte("direct")), options.Unixfs.Pin(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The peer is not assigned to the other node so its copy is a duplicate.
+	if _, err := otherAPI.Unixfs().Add(context.Background(), files.NewBytesFile([]byte("tracked")), options.Unixfs.Pin(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	peerID := pid.Pretty()
+	err = db.Update(func(db *gorm.DB) error {
+		if err := db.Save(&repo.CIDRecord{CID: tracked.Cid().String(), PeerID: peerID}).Error; err != nil {
+			return err
+		}
+		if err := db.Save(&repo.PinRef{CID: tracked.Cid().String(), PeerID: peerID, Node: owner, Recursive: true}).Error; err != nil {
+			return err
+		}
+		if err := db.Save(&repo.Pin{CID: tracked.Cid().String(), Node: owner, Recursive: true, RefCount: 1}).Error; err != nil {
+			return err
+		}
+		if err := db.Save(&repo.CIDRecord{CID: direct.Cid().String(), PeerID: peerID}).Error; err != nil {
+			return err
+		}
+		if err := db.Save(&repo.PinRef{CID: direct.Cid().String(), PeerID: peerID, Node: owner}).Error; err != nil {
+			return err
+		}
+		if err := db.Save(&repo.Pin{CID: direct.Cid().String(), Node: owner, RefCount: 1}).Error; err != nil {
+			return err
+		}
+		// The stale pin is referenced by a peer without a CIDRecord for it.
+		if err := db.Save(&repo.PinRef{CID: stale.Cid().String(), PeerID: peerID, Node: owner, Recursive: true}).Error; err != nil {
+			return err
+		}
+		return db.Save(&repo.Pin{CID: stale.Cid().String(), Node: owner, Recursive: true, RefCount: 1}).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := pm.reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := make(map[string]bool)
+	for _, diff := range report.Missing {
+		missing[diff.CID] = true
+	}
+	if len(report.Missing) != 2 || !missing[tracked.Cid().String()] || !missing[direct.Cid().String()] {
+		t.Errorf("Expected tracked and direct cids to be reported missing, got %v", report.Missing)
+	}
+	if len(report.Orphaned) != 1 || report.Orphaned[0].CID != orphan.Cid().String() {
+		t.Errorf("Expected orphan to be reported orphaned, got %v", report.Orphaned)
+	}
+	if len(report.Stale) != 1 || report.Stale[0].CID != stale.Cid().String() {
+		t.Errorf("Expected stale reference to be reported, got %v", report.Stale)
+	}
+	if len(report.Duplicate) != 1 || report.Duplicate[0].CID != tracked.Cid().String() || report.Duplicate[0].Node != 1-owner {
+		t.Errorf("Expected copy on other node to be reported duplicate, got %v", report.Duplicate)
+	}
+
+	if _, pinned, err := capi.Pin().IsPinned(context.Background(), orphan); err != nil || pinned {
+		t.Error("Expected orphan to be unpinned")
+	}
+	if _, pinned, err := capi.Pin().IsPinned(context.Background(), stale); err != nil || pinned {
+		t.Error("Expected stale cid to be unpinned")
+	}
+	if _, pinned, err := capi.Pin().IsPinned(context.Background(), tracked); err != nil || !pinned {
+		t.Error("Expected tracked cid to be pinned")
+	}
+	if _, pinned, err := otherAPI.Pin().IsPinned(context.Background(), tracked); err != nil || pinned {
+		t.Error("Expected duplicate to be unpinned")
+	}
+	if mode, pinned, err := capi.Pin().IsPinned(context.Background(), direct); err != nil || !pinned || mode != "direct" {
+		t.Errorf("Expected direct cid to be pinned directly, got %s", mode)
+	}
+
+	// Once reconciled the pin set matches the table.
+	report, err = pm.reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Missing) != 0 {
+		t.Errorf("Expected no missing pins after reconciling, got %v", report.Missing)
+	}
+}
+
+func TestPinManager_Rebalance(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := newHashRing(2, 0)
+	pm := newPinManager(context.Background(), mn.Nodes(), ring, db)
+
+	pid := mn.Nodes()[0].Identity()
+	owner := ring.owners(pid)[0]
+	other := 1 - owner
+
+	isPinned := func(node uint, pth path.Path) bool {
+		capi, err := coreapi.NewCoreAPI(mn.Nodes()[node].IPFSNode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, pinned, err := capi.Pin().IsPinned(context.Background(), pth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pinned
+	}
+
+	capi, err := coreapi.NewCoreAPI(mn.Nodes()[other].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pth, err := capi.Unixfs().Add(context.Background(), files.NewBytesFile([]byte("rebalanced")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pin on the node the peer was assigned to before the node count changed.
+	if err := pm.setPeerPins(pid, []uint{other}, map[cid.Cid]bool{pth.Cid(): true}); err != nil {
+		t.Fatal(err)
+	}
+	if !isPinned(other, pth) {
+		t.Fatal("Expected cid to be pinned on the old node")
+	}
+
+	moved, err := pm.rebalance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != 1 {
+		t.Errorf("Expected 1 peer to be moved, got %d", moved)
+	}
+	if !isPinned(owner, pth) {
+		t.Error("Expected cid to be pinned on the owner node")
+	}
+	if isPinned(other, pth) {
+		t.Error("Expected cid to be unpinned from the old node")
+	}
+
+	moved, err = pm.rebalance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != 0 {
+		t.Errorf("Expected no peers to be moved, got %d", moved)
+	}
+}
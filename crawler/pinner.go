@@ -0,0 +1,121 @@
+package crawler
+
+import (
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/gogo/protobuf/proto"
+	ipnspb "github.com/ipfs/go-ipns/pb"
+	"github.com/ipfs/go-namesys"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+// pinRetryDelay is the amount of time to wait before retrying
+// a failed IPNS record pin.
+const pinRetryDelay = time.Minute * 30
+
+// runRecordPinner periodically puts the non-expired IPNS records of all
+// non-banned peers back to the DHT so that they remain resolvable even
+// if the DHT nodes holding them drop them.
+func (c *Crawler) runRecordPinner() {
+	ticker := time.NewTicker(time.Minute)
+	for {
+		select {
+		case <-ticker.C:
+			c.pinRecordBatch()
+		case <-c.shutdown:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// pinRecordBatch loads the next batch of records that are due to be pinned
+// and spreads them across the IPFS nodes. It returns once all records in
+// the batch have been attempted.
+func (c *Crawler) pinRecordBatch() {
+	var peers []repo.Peer
+	err := c.db.View(func(db *gorm.DB) error {
+		return db.Where("banned=?", false).
+			Where("ip_ns_expiration>?", time.Now()).
+			Where("last_pinned<?", time.Now().Add(-c.ipnsPinInterval)).
+			Where("last_pin_attempt<?", time.Now().Add(-pinRetryDelay)).
+			Order("last_pinned asc").
+			Limit(int(c.ipnsPinBatchSize)).
+			Find(&peers).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorf("Error loading IPNS records to pin: %s", err)
+		return
+	}
+	if len(peers) == 0 {
+		return
+	}
+
+	peerChan := make(chan repo.Peer)
+	var wg sync.WaitGroup
+	for _, n := range c.nodes {
+		wg.Add(1)
+		go func(n *core.OpenBazaarNode) {
+			defer wg.Done()
+			for p := range peerChan {
+				pid, err := peer.Decode(p.PeerID)
+				if err != nil {
+					log.Errorf("Error decoding peerID in record pinner: %s", err)
+					continue
+				}
+				rec := new(ipnspb.IpnsEntry)
+				if err := proto.Unmarshal(p.IPNSRecord, rec); err != nil {
+					log.Errorf("Error unmarshalling IPNS record for peer %s: %s", p.PeerID, err)
+					continue
+				}
+				c.pinRecord(n, pid, rec)
+			}
+		}(n)
+	}
+
+	for _, p := range peers {
+		select {
+		case peerChan <- p:
+		case <-c.shutdown:
+		}
+	}
+	close(peerChan)
+	wg.Wait()
+	log.Debugf("Pinned batch of %d IPNS records", len(peers))
+}
+
+// pinRecord puts the IPNS record to the DHT using the given node and
+// saves the outcome on the peer. LastPinned is only updated if the put
+// succeeds. Otherwise the failure count is incremented.
+func (c *Crawler) pinRecord(n *core.OpenBazaarNode, pid peer.ID, rec *ipnspb.IpnsEntry) error {
+	pubkey, pinErr := pid.ExtractPublicKey()
+	if pinErr == nil {
+		pinErr = namesys.PutRecordToRouting(c.ctx, n.IPFSNode().Routing, pubkey, rec)
+	}
+	if pinErr != nil {
+		log.Errorf("Error pinning IPNS record for peer %s: %s", pid.Pretty(), pinErr)
+	}
+	err := c.db.Update(func(db *gorm.DB) error {
+		var peer repo.Peer
+		err := db.Where("peer_id=?", pid.Pretty()).First(&peer).Error
+		if err != nil {
+			return err
+		}
+		peer.LastPinAttempt = time.Now()
+		if pinErr != nil {
+			peer.PinFailures++
+		} else {
+			peer.LastPinned = time.Now()
+			peer.PinFailures = 0
+		}
+		return db.Save(&peer).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorf("Error saving pin status for peer %s: %s", pid.Pretty(), err)
+	}
+	return pinErr
+}
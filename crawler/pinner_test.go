@@ -0,0 +1,99 @@
+package crawler
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/ipfs/go-ipns"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCrawler_PinRecord(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Crawler{
+		nodes: mn.Nodes()[:1],
+		db:    db,
+		ctx:   ctx,
+	}
+
+	// A store which isn't part of the network so its record is only ever
+	// put by the crawler.
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := ipns.Create(priv, []byte("/ipfs/QmRoot"), 1, time.Now().Add(time.Hour*24), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Update(func(db *gorm.DB) error {
+		return db.Create(&repo.Peer{PeerID: pid.Pretty(), PinFailures: 2}).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loadPeer := func() repo.Peer {
+		var p repo.Peer
+		err := db.View(func(db *gorm.DB) error {
+			return db.Where("peer_id=?", pid.Pretty()).First(&p).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	// A failed put is counted and doesn't update LastPinned.
+	cancel()
+	if err := c.pinRecord(c.nodes[0], pid, rec); err == nil {
+		t.Fatal("Expected the pin to fail")
+	}
+	p := loadPeer()
+	if p.PinFailures != 3 {
+		t.Errorf("Expected 3 pin failures, got %d", p.PinFailures)
+	}
+	if p.LastPinAttempt.IsZero() || !p.LastPinned.IsZero() {
+		t.Errorf("Expected only the pin attempt to be recorded, got attempt %s pinned %s", p.LastPinAttempt, p.LastPinned)
+	}
+
+	// The nodes' routing tables fill in the background after bootstrap so
+	// retry until the put succeeds.
+	c.ctx = context.Background()
+	start := time.Now()
+	for {
+		err := c.pinRecord(c.nodes[0], pid, rec)
+		if err == nil {
+			break
+		}
+		if time.Since(start) > time.Second*10 {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	p = loadPeer()
+	if p.PinFailures != 0 {
+		t.Errorf("Expected the pin failures to be reset, got %d", p.PinFailures)
+	}
+	if p.LastPinned.Before(start) || p.LastPinAttempt.Before(start) {
+		t.Errorf("Expected the pin to be recorded, got attempt %s pinned %s", p.LastPinAttempt, p.LastPinned)
+	}
+}
@@ -0,0 +1,73 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/repo"
+	"gorm.io/gorm"
+	"time"
+)
+
+// runRateRefresher periodically reloads the exchange rates and updates the
+// normalized prices of the listings and fees of the moderators.
+func (c *Crawler) runRateRefresher() {
+	ticker := time.NewTicker(c.rateInterval)
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.refreshPrices(); err != nil {
+				log.Errorf("Error refreshing exchange rates: %s", err)
+			}
+		case <-c.shutdown:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// refreshPrices loads the latest exchange rates and updates the normalized
+// price of every listing and the normalized fixed fee of every moderator.
+// Amounts in a currency without a rate have their normalized value cleared.
+func (c *Crawler) refreshPrices() error {
+	if err := c.prices.Refresh(); err != nil {
+		return err
+	}
+	return c.db.Update(func(db *gorm.DB) error {
+		n, err := c.normalizeColumn(db, &repo.Listing{}, "price", "currency", "normalized_price")
+		if err != nil {
+			return err
+		}
+		log.Debugf("Normalized listing prices in %d currencies to %s", n, c.prices.Base())
+
+		n, err = c.normalizeColumn(db, &repo.Moderator{}, "fixed_fee", "fixed_fee_currency", "normalized_fixed_fee")
+		if err != nil {
+			return err
+		}
+		log.Debugf("Normalized moderator fees in %d currencies to %s", n, c.prices.Base())
+		return nil
+	})
+}
+
+// normalizeColumn sets the normalized column of the model to the amount
+// column converted from the currency column to the base currency. It
+// returns the number of currencies found.
+func (c *Crawler) normalizeColumn(db *gorm.DB, model interface{}, amount, currency, normalized string) (int, error) {
+	var currencies []string
+	err := db.Model(model).
+		Where(amount+" IS NOT NULL").
+		Distinct().
+		Pluck(currency, &currencies).Error
+	if err != nil {
+		return 0, err
+	}
+	for _, code := range currencies {
+		tx := db.Model(model).Where(currency+"=?", code).Where(amount + " IS NOT NULL")
+		if rate, ok := c.prices.Normalize(1, code); ok {
+			err = tx.Update(normalized, gorm.Expr(amount+" * ?", rate)).Error
+		} else {
+			err = tx.Update(normalized, nil).Error
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(currencies), nil
+}
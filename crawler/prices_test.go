@@ -0,0 +1,93 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/pricing"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestCrawler_RefreshPrices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ratesPath := path.Join(dir, "rates.json")
+	if err := ioutil.WriteFile(ratesPath, []byte(`{"BTC": 40000}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		db:     db,
+		prices: pricing.NewNormalizer("USD", &pricing.FileProvider{Path: ratesPath}),
+	}
+
+	newListing := func(slug, price string, currency *pb.Currency) *pb.SignedListing {
+		return &pb.SignedListing{
+			Listing: &pb.Listing{
+				Slug:     slug,
+				Metadata: &pb.Listing_Metadata{PricingCurrency: currency},
+				Item:     &pb.Listing_Item{Title: "Widget", Price: price},
+			},
+		}
+	}
+	expiration := time.Now().Add(time.Hour)
+	for _, l := range []*pb.SignedListing{
+		newListing("usd", "1500", &pb.Currency{Code: "USD", Divisibility: 2}),
+		newListing("btc", "100000", &pb.Currency{Code: "BTC", Divisibility: 8}),
+		newListing("jpy", "100", &pb.Currency{Code: "JPY", Divisibility: 0}),
+	} {
+		if err := c.indexListing("QmVendor", l, expiration); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Before the rates are loaded only the USD listing is priced.
+	res, err := c.Search(&rpc.SearchQuery{MinPrice: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 {
+		t.Fatalf("Expected 1 priced listing, got %d", res.Total)
+	}
+
+	if err := c.refreshPrices(); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err = c.Search(&rpc.SearchQuery{SortBy: rpc.SortByPriceAscending})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 3 || res.PriceCurrency != "USD" {
+		t.Fatalf("Expected 3 results in USD, got %d in %s", res.Total, res.PriceCurrency)
+	}
+	expected := []float64{15, 40}
+	for i, price := range expected {
+		if res.Results[i].Price == nil || *res.Results[i].Price != price {
+			t.Errorf("Result %d: expected price %f", i, price)
+		}
+	}
+	if res.Results[2].Price != nil {
+		t.Error("Expected the listing without a rate to be last and unpriced")
+	}
+
+	res, err = c.Search(&rpc.SearchQuery{MinPrice: 20, MaxPrice: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || res.Results[0].Data.(*pb.SignedListing).Listing.Slug != "btc" {
+		t.Error("Expected only the BTC listing within the price range")
+	}
+}
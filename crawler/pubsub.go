@@ -2,15 +2,18 @@ package crawler
 
 import (
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
 	"github.com/gogo/protobuf/proto"
 	"github.com/ipfs/go-ipfs/core/coreapi"
 	"github.com/ipfs/go-ipns"
 	ipnspb "github.com/ipfs/go-ipns/pb"
 	iface "github.com/ipfs/interface-go-ipfs-core"
 	caopts "github.com/ipfs/interface-go-ipfs-core/options"
+	peer "github.com/libp2p/go-libp2p-core/peer"
 	"gorm.io/gorm"
 	"sync"
 	"time"
@@ -18,32 +21,37 @@ import (
 
 const ipnsPubsubTopic = "/ipns/all"
 
-func (c *Crawler) listenPubsub() error {
-	messageChan := make(chan iface.PubSubMessage)
+// peerTopic is an active subscription to the IPNS pubsub
+// topic of a single peer.
+type peerTopic struct {
+	pid      peer.ID
+	sub      iface.PubSubSubscription
+	lastSeen time.Time
+}
+
+// ipnsPeerTopic returns the pubsub topic the go-ipfs pubsub router
+// uses to publish IPNS records for the given peer.
+func ipnsPeerTopic(pid peer.ID) string {
+	return "/record/" + base64.RawURLEncoding.EncodeToString([]byte(ipns.RecordKey(pid)))
+}
 
-	subs := make([]iface.PubSubSubscription, c.numPubsub)
+func (c *Crawler) listenPubsub() error {
 	for _, n := range c.nodes[:c.numPubsub] {
-		api, err := coreapi.NewCoreAPI(n.IPFSNode())
-		if err != nil {
-			return err
-		}
-		sub, err := api.PubSub().Subscribe(c.ctx, ipnsPubsubTopic, caopts.PubSub.Discover(true))
-		if err != nil {
-			return err
+		for _, topic := range c.pubsubTopics {
+			sub, err := c.subscribeTopic(n, topic)
+			if err != nil {
+				return err
+			}
+			c.pubsubMtx.Lock()
+			c.pubsubSubs = append(c.pubsubSubs, sub)
+			c.pubsubMtx.Unlock()
 		}
-		subs = append(subs, sub)
+	}
 
-		go func() {
-			for {
-				message, err := sub.Next(c.ctx)
-				if err != nil {
-					log.Errorf("Error fetching next subscription message %s", err.Error())
-					return
-				}
-				messageChan <- message
-			}
-		}()
+	if c.peerTopics {
+		go c.joinKnownPeerTopics()
 	}
+
 	go func() {
 		mtx := sync.Mutex{}
 		recentMessasges := make(map[string]struct{})
@@ -51,12 +59,26 @@ func (c *Crawler) listenPubsub() error {
 		for {
 			select {
 			case <-c.shutdown:
-				for _, sub := range subs {
+				c.pubsubMtx.Lock()
+				for _, sub := range c.pubsubSubs {
 					sub.Close()
 				}
+				for _, pt := range c.peerSubs {
+					if pt.sub != nil {
+						pt.sub.Close()
+					}
+				}
+				c.pubsubMtx.Unlock()
 				return
-			case message := <-messageChan:
-				h := sha256.Sum256(append([]byte(message.From()), message.Data()...))
+			case message := <-c.messageChan:
+				// Ignore the records we republished ourselves.
+				if c.isOwnNode(message.From()) {
+					continue
+				}
+
+				from := c.messageOwner(message)
+
+				h := sha256.Sum256(append([]byte(from), message.Data()...))
 				id := hex.EncodeToString(h[:])
 
 				mtx.Lock()
@@ -74,61 +96,239 @@ func (c *Crawler) listenPubsub() error {
 					mtx.Unlock()
 				})
 
-				rec := new(ipnspb.IpnsEntry)
-				if err := proto.Unmarshal(message.Data(), rec); err != nil {
-					log.Errorf("Error unmarshalling IPNS record for peer %s: %s", message.From().Pretty(), err)
-					continue
-				}
+				c.handleIPNSRecord(from, message.Data())
+			}
+		}
+	}()
+	return nil
+}
 
-				pubkey, err := message.From().ExtractPublicKey()
-				if err != nil {
-					log.Errorf("Error extracting public key for %s: %s", message.From().Pretty(), err)
-					continue
-				}
+// messageOwner returns the peer whose IPNS record the message carries.
+// Records published on a peer's own topic may be relayed by any node so
+// the owner of the record is taken from the topic rather than the sender.
+func (c *Crawler) messageOwner(message iface.PubSubMessage) peer.ID {
+	for _, topic := range message.Topics() {
+		if pid, ok := c.peerTopicOwner(topic); ok {
+			return pid
+		}
+	}
+	return message.From()
+}
 
-				if err := ipns.Validate(pubkey, rec); err != nil {
-					log.Errorf("Received invalid IPNS record for %s: %s", message.From().Pretty(), err)
-					continue
-				}
+// handleIPNSRecord validates an IPNS record received over pubsub and
+// queues a crawl of the peer. Since any node can relay a peer's records,
+// the record of a known store is only accepted if it is newer than the
+// one we have. Otherwise an old record could roll the store back.
+func (c *Crawler) handleIPNSRecord(from peer.ID, data []byte) {
+	rec := new(ipnspb.IpnsEntry)
+	if err := proto.Unmarshal(data, rec); err != nil {
+		log.Errorf("Error unmarshalling IPNS record for peer %s: %s", from.Pretty(), err)
+		return
+	}
 
-				expiration, err := ipns.GetEOL(rec)
-				if err != nil {
-					log.Errorf("Error extracting IPNS record eol for %s: %s", message.From().Pretty(), err)
-					continue
-				}
+	pubkey, err := from.ExtractPublicKey()
+	if err != nil {
+		log.Errorf("Error extracting public key for %s: %s", from.Pretty(), err)
+		return
+	}
 
-				banned := false
-				err = c.db.Update(func(db *gorm.DB) error {
-					var peer repo.Peer
-					err := db.Where("peer_id=?", message.From().Pretty()).First(&peer).Error
-					if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-						return err
-					} else if errors.Is(err, gorm.ErrRecordNotFound) {
-						peer.PeerID = message.From().Pretty()
-						peer.FirstSeen = time.Now()
-						log.Infof("Detected new peer: %s", message.From().Pretty())
-					}
-					peer.LastSeen = time.Now()
-					peer.IPNSExpiration = expiration
-					peer.IPNSRecord = message.Data()
-					banned = peer.Banned
-					return db.Save(&peer).Error
-				})
-				if err != nil {
-					log.Errorf("Error saving IPNS record for peer %s: %s", message.From().Pretty(), err)
-				}
-				if !banned {
-					log.Debugf("Received new IPNS record from %s. Expiration %s", message.From().Pretty(), expiration)
-					go func() {
-						c.workChan <- &job{
-							Peer:       message.From(),
-							Expiration: expiration,
-							IPNSRecord: rec,
-						}
-					}()
+	if err := ipns.Validate(pubkey, rec); err != nil {
+		log.Errorf("Received invalid IPNS record for %s: %s", from.Pretty(), err)
+		return
+	}
+
+	expiration, err := ipns.GetEOL(rec)
+	if err != nil {
+		log.Errorf("Error extracting IPNS record eol for %s: %s", from.Pretty(), err)
+		return
+	}
+
+	if _, err := c.markSeen(from); err != nil {
+		log.Errorf("Error updating database for peer %s: %s", from.Pretty(), err)
+	}
+
+	// The record is saved by the worker once the peer is found to
+	// be a store. Only known stores are updated here.
+	var banned, stale bool
+	err = c.db.Update(func(db *gorm.DB) error {
+		var peer repo.Peer
+		err := db.Where("peer_id=?", from.Pretty()).First(&peer).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		banned = peer.Banned
+		if len(peer.IPNSRecord) > 0 {
+			stored := new(ipnspb.IpnsEntry)
+			if err := proto.Unmarshal(peer.IPNSRecord, stored); err == nil {
+				if n, err := ipns.Compare(rec, stored); err != nil || n <= 0 {
+					stale = true
+					return nil
 				}
 			}
 		}
+		peer.IPNSExpiration = expiration
+		peer.IPNSRecord = data
+		return db.Save(&peer).Error
+	})
+	if err != nil {
+		log.Errorf("Error saving IPNS record for peer %s: %s", from.Pretty(), err)
+	}
+	if stale {
+		log.Debugf("Ignoring IPNS record from %s which is not newer than the saved record", from.Pretty())
+		return
+	}
+	if !banned {
+		log.Debugf("Received new IPNS record from %s. Expiration %s", from.Pretty(), expiration)
+		go c.joinPeerTopic(from)
+		go func() {
+			c.workChan <- &job{
+				Peer:       from,
+				Expiration: expiration,
+				IPNSRecord: rec,
+			}
+		}()
+	}
+}
+
+// subscribeTopic subscribes the node to the given pubsub topic and
+// forwards all messages received on it to the message chan.
+func (c *Crawler) subscribeTopic(n *core.OpenBazaarNode, topic string) (iface.PubSubSubscription, error) {
+	api, err := coreapi.NewCoreAPI(n.IPFSNode())
+	if err != nil {
+		return nil, err
+	}
+	sub, err := api.PubSub().Subscribe(c.ctx, topic, caopts.PubSub.Discover(true))
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			message, err := sub.Next(c.ctx)
+			if err != nil {
+				log.Debugf("Subscription to topic %s closed: %s", topic, err)
+				return
+			}
+			select {
+			case c.messageChan <- message:
+			case <-c.shutdown:
+				return
+			}
+		}
 	}()
-	return nil
+	return sub, nil
+}
+
+// joinPeerTopic subscribes to the IPNS pubsub topic of the given peer
+// if we are not already subscribed. The subscriptions are spread across
+// the pubsub nodes. Once maxPeerTopics are joined the topic of the peer
+// we received a record from least recently is left to make room.
+func (c *Crawler) joinPeerTopic(pid peer.ID) {
+	if !c.peerTopics || c.maxPeerTopics == 0 {
+		return
+	}
+	topic := ipnsPeerTopic(pid)
+
+	c.pubsubMtx.Lock()
+	if pt, ok := c.peerSubs[topic]; ok {
+		pt.lastSeen = time.Now()
+		c.pubsubMtx.Unlock()
+		return
+	}
+	if uint(len(c.peerSubs)) >= c.maxPeerTopics {
+		var oldest string
+		for t, pt := range c.peerSubs {
+			if oldest == "" || pt.lastSeen.Before(c.peerSubs[oldest].lastSeen) {
+				oldest = t
+			}
+		}
+		if pt := c.peerSubs[oldest]; pt.sub != nil {
+			pt.sub.Close()
+		}
+		log.Debugf("Left pubsub topic for peer %s", c.peerSubs[oldest].pid.Pretty())
+		delete(c.peerSubs, oldest)
+	}
+	pt := &peerTopic{pid: pid, lastSeen: time.Now()}
+	c.peerSubs[topic] = pt
+	n := c.nodes[len(c.peerSubs)%int(c.numPubsub)]
+	c.pubsubMtx.Unlock()
+
+	sub, err := c.subscribeTopic(n, topic)
+	if err != nil {
+		log.Errorf("Error joining pubsub topic for peer %s: %s", pid.Pretty(), err)
+		c.pubsubMtx.Lock()
+		if c.peerSubs[topic] == pt {
+			delete(c.peerSubs, topic)
+		}
+		c.pubsubMtx.Unlock()
+		return
+	}
+
+	c.pubsubMtx.Lock()
+	defer c.pubsubMtx.Unlock()
+	if c.peerSubs[topic] != pt {
+		// The topic was left while we were subscribing.
+		sub.Close()
+		return
+	}
+	pt.sub = sub
+	log.Debugf("Joined pubsub topic for peer %s", pid.Pretty())
+}
+
+// leavePeerTopic closes the subscription to the IPNS pubsub topic of
+// the given peer if one exists.
+func (c *Crawler) leavePeerTopic(pid peer.ID) {
+	topic := ipnsPeerTopic(pid)
+
+	c.pubsubMtx.Lock()
+	defer c.pubsubMtx.Unlock()
+
+	pt, ok := c.peerSubs[topic]
+	if !ok {
+		return
+	}
+	if pt.sub != nil {
+		pt.sub.Close()
+	}
+	delete(c.peerSubs, topic)
+}
+
+// peerTopicOwner returns the peer whose IPNS records are published on
+// the given topic if we have joined it.
+func (c *Crawler) peerTopicOwner(topic string) (peer.ID, bool) {
+	c.pubsubMtx.Lock()
+	defer c.pubsubMtx.Unlock()
+
+	pt, ok := c.peerSubs[topic]
+	if !ok {
+		return "", false
+	}
+	return pt.pid, true
+}
+
+// joinKnownPeerTopics joins the IPNS pubsub topics of the most recently
+// seen peers in the database that have a non-expired record.
+func (c *Crawler) joinKnownPeerTopics() {
+	var peers []repo.Peer
+	err := c.db.View(func(db *gorm.DB) error {
+		return db.Where("banned=?", false).
+			Where("ip_ns_expiration>?", time.Now()).
+			Order("last_seen desc").
+			Limit(int(c.maxPeerTopics)).
+			Find(&peers).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorf("Error loading known peers for pubsub: %s", err)
+		return
+	}
+	for _, p := range peers {
+		pid, err := peer.Decode(p.PeerID)
+		if err != nil {
+			log.Errorf("Error decoding peerID for pubsub: %s", err)
+			continue
+		}
+		c.joinPeerTopic(pid)
+	}
 }
@@ -0,0 +1,220 @@
+package crawler
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/gogo/protobuf/proto"
+	"github.com/ipfs/go-ipns"
+	iface "github.com/ipfs/interface-go-ipfs-core"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pubsubMessage is a pubsub message received on the given topics.
+type pubsubMessage struct {
+	from   peer.ID
+	data   []byte
+	topics []string
+}
+
+func (m *pubsubMessage) From() peer.ID    { return m.from }
+func (m *pubsubMessage) Data() []byte     { return m.data }
+func (m *pubsubMessage) Seq() []byte      { return nil }
+func (m *pubsubMessage) Topics() []string { return m.topics }
+
+func TestCrawler_JoinPeerTopic(t *testing.T) {
+	mn, err := core.NewMocknet(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	c := &Crawler{
+		nodes:         mn.Nodes()[:2],
+		ctx:           context.Background(),
+		shutdown:      make(chan struct{}),
+		numPubsub:     2,
+		peerTopics:    true,
+		maxPeerTopics: 2,
+		messageChan:   make(chan iface.PubSubMessage),
+		peerSubs:      make(map[string]*peerTopic),
+		pubsubMtx:     sync.Mutex{},
+	}
+	defer close(c.shutdown)
+
+	var (
+		first  = mn.Nodes()[2].Identity()
+		second = mn.Nodes()[3].Identity()
+		third  = mn.Nodes()[4].Identity()
+	)
+	c.joinPeerTopic(first)
+	c.joinPeerTopic(second)
+	for _, pid := range []peer.ID{first, second} {
+		owner, ok := c.peerTopicOwner(ipnsPeerTopic(pid))
+		if !ok || owner != pid {
+			t.Errorf("Expected topic to be owned by %s, got %s", pid, owner)
+		}
+		if pt := c.peerSubs[ipnsPeerTopic(pid)]; pt.sub == nil {
+			t.Errorf("Expected to be subscribed to the topic of %s", pid)
+		}
+	}
+	if _, ok := c.peerTopicOwner(ipnsPubsubTopic); ok {
+		t.Error("Expected the shared topic to have no owner")
+	}
+
+	// Receiving a record from the first peer again makes the second the
+	// least recently seen so it is left for the third.
+	time.Sleep(time.Millisecond)
+	c.joinPeerTopic(first)
+	c.joinPeerTopic(third)
+	if len(c.peerSubs) != 2 {
+		t.Errorf("Expected 2 topics joined, got %d", len(c.peerSubs))
+	}
+	for pid, joined := range map[peer.ID]bool{first: true, second: false, third: true} {
+		if _, ok := c.peerTopicOwner(ipnsPeerTopic(pid)); ok != joined {
+			t.Errorf("Expected topic of %s joined %t", pid, joined)
+		}
+	}
+
+	c.leavePeerTopic(first)
+	if _, ok := c.peerTopicOwner(ipnsPeerTopic(first)); ok {
+		t.Error("Expected topic to be left")
+	}
+
+	c.peerTopics = false
+	c.joinPeerTopic(second)
+	if _, ok := c.peerTopicOwner(ipnsPeerTopic(second)); ok {
+		t.Error("Expected no topic to be joined when peer topics are disabled")
+	}
+}
+
+func TestCrawler_MessageOwner(t *testing.T) {
+	owner, relay := peer.ID("owner"), peer.ID("relay")
+	c := &Crawler{
+		peerSubs: map[string]*peerTopic{
+			ipnsPeerTopic(owner): {pid: owner},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		topics   []string
+		expected peer.ID
+	}{
+		{
+			name:     "peer topic",
+			topics:   []string{ipnsPeerTopic(owner)},
+			expected: owner,
+		},
+		{
+			name:     "shared topic",
+			topics:   []string{ipnsPubsubTopic},
+			expected: relay,
+		},
+		{
+			name:     "topic not joined",
+			topics:   []string{ipnsPeerTopic(peer.ID("other"))},
+			expected: relay,
+		},
+	}
+	for _, test := range tests {
+		if from := c.messageOwner(&pubsubMessage{from: relay, topics: test.topics}); from != test.expected {
+			t.Errorf("%s: expected owner %s, got %s", test.name, test.expected, from)
+		}
+	}
+}
+
+func TestCrawler_HandleIPNSRecord(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		db:       db,
+		workChan: make(chan *job, 1),
+	}
+
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eol := time.Now().Add(time.Hour * 24)
+	records := make(map[uint64][]byte)
+	for _, seq := range []uint64{1, 2, 3} {
+		rec, err := ipns.Create(priv, []byte("/ipfs/QmRoot"), seq, eol, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records[seq], err = proto.Marshal(rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = db.Update(func(db *gorm.DB) error {
+		return db.Create(&repo.Peer{PeerID: pid.Pretty(), IPNSRecord: records[2]}).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	savedSequence := func() uint64 {
+		var p repo.Peer
+		err := db.View(func(db *gorm.DB) error {
+			return db.Where("peer_id=?", pid.Pretty()).First(&p).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for seq, rec := range records {
+			if string(rec) == string(p.IPNSRecord) {
+				return seq
+			}
+		}
+		return 0
+	}
+
+	// A relayed record which is not newer than the saved one is ignored.
+	for _, seq := range []uint64{1, 2} {
+		c.handleIPNSRecord(pid, records[seq])
+		select {
+		case <-c.workChan:
+			t.Errorf("Expected record %d not to queue a crawl", seq)
+		case <-time.After(time.Millisecond * 100):
+		}
+		if saved := savedSequence(); saved != 2 {
+			t.Errorf("Expected record 2 to remain saved, got %d", saved)
+		}
+	}
+
+	c.handleIPNSRecord(pid, records[3])
+	select {
+	case j := <-c.workChan:
+		if j.Peer != pid || j.IPNSRecord.GetSequence() != 3 {
+			t.Errorf("Expected crawl of %s at record 3, got %s at %d", pid, j.Peer, j.IPNSRecord.GetSequence())
+		}
+	case <-time.After(time.Second * 5):
+		t.Error("Expected newer record to queue a crawl")
+	}
+	if saved := savedSequence(); saved != 3 {
+		t.Errorf("Expected record 3 to be saved, got %d", saved)
+	}
+
+	// Records which don't validate against the owner's key are dropped.
+	c.handleIPNSRecord(peer.ID("other"), records[3])
+	select {
+	case <-c.workChan:
+		t.Error("Expected record of another peer not to queue a crawl")
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -0,0 +1,267 @@
+package crawler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/cpacia/obcrawler/classifier"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// Review statuses.
+const (
+	reviewPending  = "pending"
+	reviewApproved = "approved"
+	reviewRejected = "rejected"
+)
+
+// errReviewNotFound is returned when deciding on an item which isn't
+// pending review.
+var errReviewNotFound = errors.New("review not found")
+
+// reviewState returns the moderators' decisions on the peer's content
+// keyed by CID and whether the peer itself is quarantined.
+func (c *Crawler) reviewState(peerID string) (map[string]string, bool, error) {
+	var (
+		reviews     []repo.Review
+		quarantined bool
+	)
+	err := c.db.View(func(db *gorm.DB) error {
+		var p repo.Peer
+		err := db.Where("peer_id=?", peerID).First(&p).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		quarantined = p.Quarantined
+		return db.Where("peer_id=?", peerID).Where("c_id<>?", "").Where("status<>?", reviewPending).Find(&reviews).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	decisions := make(map[string]string, len(reviews))
+	for _, r := range reviews {
+		decisions[r.CID] = r.Status
+	}
+	return decisions, quarantined, nil
+}
+
+// reviewContent classifies the content and applies the moderators'
+// decision on it. Quarantined content a moderator approved is allowed and
+// content they rejected is blocked.
+func (c *Crawler) reviewContent(content *classifier.Content, id string, decisions map[string]string) *classifier.Result {
+	res := c.classify(content, id)
+	switch decisions[id] {
+	case reviewApproved:
+		if res.Verdict == classifier.Quarantine {
+			return &classifier.Result{Verdict: classifier.Allow, Labels: res.Labels}
+		}
+	case reviewRejected:
+		return &classifier.Result{Verdict: classifier.Block, Labels: res.Labels}
+	}
+	return res
+}
+
+// newReview returns a review queue item holding the quarantined content.
+func newReview(content *classifier.Content, id string, res *classifier.Result) (*repo.Review, error) {
+	review := &repo.Review{
+		PeerID: content.PeerID,
+		CID:    id,
+		Labels: strings.Join(res.Labels, ","),
+		Reason: "classified as " + res.Verdict.String(),
+		Status: reviewPending,
+	}
+	var err error
+	if content.Profile != nil {
+		review.Profile, err = json.Marshal(content.Profile)
+	} else if content.Listing != nil {
+		review.Slug = content.Listing.GetListing().GetSlug()
+		review.SignedListing, err = proto.Marshal(content.Listing)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// setPendingReviews replaces the peer's content pending review. Content
+// no longer pending is removed from the queue but the moderators'
+// decisions are kept.
+func (c *Crawler) setPendingReviews(peerID string, pending map[string]*repo.Review) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		var current []repo.Review
+		err := db.Where("peer_id=?", peerID).Where("c_id<>?", "").Where("status=?", reviewPending).Find(&current).Error
+		if err != nil {
+			return err
+		}
+		queued := make(map[string]bool)
+		for _, r := range current {
+			if pending[r.CID] == nil {
+				if err := db.Where("peer_id=?", peerID).Where("c_id=?", r.CID).Delete(&repo.Review{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			queued[r.CID] = true
+		}
+		for id, r := range pending {
+			if queued[id] {
+				err = db.Model(&repo.Review{}).Where("peer_id=?", peerID).Where("c_id=?", id).Updates(map[string]interface{}{
+					"labels":         r.Labels,
+					"reason":         r.Reason,
+					"profile":        r.Profile,
+					"signed_listing": r.SignedListing,
+				}).Error
+			} else {
+				err = db.Clauses(upsert).Create(r).Error
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// QuarantineNode holds the node's data for review. The node is still
+// crawled but its data is removed from the search index and unpinned and
+// it is only streamed to moderator subscribers until a moderator approves
+// it. Rejecting it bans the node.
+func (c *Crawler) QuarantineNode(pid peer.ID, reason string) error {
+	err := c.db.Update(func(db *gorm.DB) error {
+		var p repo.Peer
+		err := db.Where("peer_id=?", pid.Pretty()).First(&p).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if p.Banned {
+			return errors.New("peer is banned")
+		}
+		p.PeerID = pid.Pretty()
+		p.Quarantined = true
+		if err := db.Save(&p).Error; err != nil {
+			return err
+		}
+		return db.Clauses(upsert).Create(&repo.Review{
+			PeerID: pid.Pretty(),
+			Reason: reason,
+			Status: reviewPending,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.removeProfile(pid.Pretty()); err != nil {
+		return err
+	}
+	if err := c.removeListings(pid.Pretty(), nil); err != nil {
+		return err
+	}
+	err = c.db.Update(func(db *gorm.DB) error {
+		return db.Model(&repo.CIDRecord{}).Where("peer_id=?", pid.Pretty()).Update("quarantined", true).Error
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.pins.releasePeer(pid); err != nil {
+		log.Errorf("Error unpinning data for quarantined node %s: %s", pid.String(), err)
+	}
+	return nil
+}
+
+// ListReviews returns the items pending review, oldest first.
+func (c *Crawler) ListReviews(query *rpc.ReviewQuery) ([]*rpc.Review, error) {
+	var reviews []repo.Review
+	err := c.db.View(func(db *gorm.DB) error {
+		tx := db.Where("status=?", reviewPending)
+		if query.PeerID != "" {
+			tx = tx.Where("peer_id=?", query.PeerID)
+		}
+		return tx.Order("created_at").Find(&reviews).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*rpc.Review, 0, len(reviews))
+	for _, r := range reviews {
+		review := &rpc.Review{
+			PeerID:    r.PeerID,
+			CID:       r.CID,
+			Slug:      r.Slug,
+			Reason:    r.Reason,
+			CreatedAt: r.CreatedAt,
+		}
+		if r.Labels != "" {
+			review.Labels = strings.Split(r.Labels, ",")
+		}
+		if len(r.Profile) > 0 {
+			profile := new(models.Profile)
+			if err := json.Unmarshal(r.Profile, profile); err != nil {
+				return nil, err
+			}
+			review.Data = profile
+		} else if len(r.SignedListing) > 0 {
+			sl := new(obpb.SignedListing)
+			if err := proto.Unmarshal(r.SignedListing, sl); err != nil {
+				return nil, err
+			}
+			review.Data = sl
+		}
+		ret = append(ret, review)
+	}
+	return ret, nil
+}
+
+// ApproveReview approves the node's content with the given CID, or the
+// node itself if the CID is empty, and queues a crawl of the node so the
+// approved data is indexed, pinned and streamed to all subscribers.
+func (c *Crawler) ApproveReview(pid peer.ID, id string) error {
+	if err := c.decideReview(pid, id, reviewApproved); err != nil {
+		return err
+	}
+	return c.CrawlNode(pid)
+}
+
+// RejectReview rejects the node's content with the given CID, which is
+// then treated as if it were blocked by the classifiers. If the CID is
+// empty the node itself is rejected and banned.
+func (c *Crawler) RejectReview(pid peer.ID, id string) error {
+	if err := c.decideReview(pid, id, reviewRejected); err != nil {
+		return err
+	}
+	if id == "" {
+		return c.BanNode(pid)
+	}
+	return nil
+}
+
+// decideReview records the moderator's decision on a pending item. A
+// node's quarantine is lifted once it is decided. A rejected node is
+// marked banned in the same transaction so it is never crawled unquarantined.
+func (c *Crawler) decideReview(pid peer.ID, id, status string) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		// The CID of a node's review is empty so it is updated by its
+		// keys rather than saved.
+		tx := db.Model(&repo.Review{}).Where("peer_id=?", pid.Pretty()).Where("c_id=?", id).Where("status=?", reviewPending).Update("status", status)
+		if tx.Error != nil {
+			return tx.Error
+		}
+		if tx.RowsAffected == 0 {
+			return errReviewNotFound
+		}
+		if id == "" {
+			updates := map[string]interface{}{"quarantined": false}
+			if status == reviewRejected {
+				updates["banned"] = true
+			}
+			return db.Model(&repo.Peer{}).Where("peer_id=?", pid.Pretty()).Updates(updates).Error
+		}
+		return nil
+	})
+}
@@ -0,0 +1,289 @@
+package crawler
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/cpacia/obcrawler/classifier"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func newQuarantineCrawler(t *testing.T) (*Crawler, peer.ID) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ring := newHashRing(1, 0)
+	c := &Crawler{
+		ctx:      ctx,
+		db:       db,
+		ring:     ring,
+		pins:     newPinManager(ctx, nil, ring, db),
+		subs:     make(map[uint64]*rpc.Subscription),
+		workChan: make(chan *job, 10),
+	}
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, pid
+}
+
+func TestCrawler_ReviewQueue(t *testing.T) {
+	c, pid := newQuarantineCrawler(t)
+	rules, err := classifier.NewRuleClassifier([]classifier.Rule{
+		{Label: "weapons", Verdict: classifier.Quarantine, Keywords: []string{"rifle"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.classifier = rules
+
+	listing := &classifier.Content{
+		PeerID: pid.Pretty(),
+		Listing: &obpb.SignedListing{
+			Listing: &obpb.Listing{Slug: "rifle", Item: &obpb.Listing_Item{Title: "Hunting rifle"}},
+		},
+	}
+	profile := &classifier.Content{
+		PeerID:  pid.Pretty(),
+		Profile: &models.Profile{Name: "Rifle Shop"},
+	}
+
+	decisions, quarantined, err := c.reviewState(pid.Pretty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decisions) != 0 || quarantined {
+		t.Fatalf("Expected no decisions, got %v %t", decisions, quarantined)
+	}
+
+	pending := make(map[string]*repo.Review)
+	for id, content := range map[string]*classifier.Content{"QmListing": listing, "QmProfile": profile} {
+		res := c.reviewContent(content, id, decisions)
+		if res.Verdict != classifier.Quarantine {
+			t.Fatalf("Expected %s to be quarantined, got %s", id, res.Verdict)
+		}
+		pending[id], err = newReview(content, id, res)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.setPendingReviews(pid.Pretty(), pending); err != nil {
+		t.Fatal(err)
+	}
+
+	reviews, err := c.ListReviews(&rpc.ReviewQuery{PeerID: pid.Pretty()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("Expected 2 reviews, got %d", len(reviews))
+	}
+	for _, r := range reviews {
+		if len(r.Labels) != 1 || r.Labels[0] != "weapons" {
+			t.Errorf("Expected the weapons label, got %v", r.Labels)
+		}
+		switch r.CID {
+		case "QmListing":
+			sl, ok := r.Data.(*obpb.SignedListing)
+			if !ok || sl.GetListing().GetItem().GetTitle() != "Hunting rifle" || r.Slug != "rifle" {
+				t.Errorf("Expected the quarantined listing, got %v", r.Data)
+			}
+		case "QmProfile":
+			p, ok := r.Data.(*models.Profile)
+			if !ok || p.Name != "Rifle Shop" {
+				t.Errorf("Expected the quarantined profile, got %v", r.Data)
+			}
+		default:
+			t.Errorf("Unexpected review %s", r.CID)
+		}
+	}
+
+	if err := c.ApproveReview(pid, "QmListing"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RejectReview(pid, "QmProfile"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RejectReview(pid, "QmProfile"); err != errReviewNotFound {
+		t.Errorf("Expected %v, got %v", errReviewNotFound, err)
+	}
+	select {
+	case j := <-c.workChan:
+		if j.Peer != pid {
+			t.Errorf("Expected a crawl of %s, got %s", pid, j.Peer)
+		}
+	case <-time.After(time.Second * 5):
+		t.Error("Expected the approval to queue a crawl")
+	}
+
+	// The decisions apply to the same content when it is crawled again.
+	decisions, _, err = c.reviewState(pid.Pretty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := c.reviewContent(listing, "QmListing", decisions); res.Verdict != classifier.Allow {
+		t.Errorf("Expected the approved listing to be allowed, got %s", res.Verdict)
+	}
+	if res := c.reviewContent(profile, "QmProfile", decisions); res.Verdict != classifier.Block {
+		t.Errorf("Expected the rejected profile to be blocked, got %s", res.Verdict)
+	}
+
+	// Only pending items are replaced.
+	if err := c.setPendingReviews(pid.Pretty(), nil); err != nil {
+		t.Fatal(err)
+	}
+	reviews, err = c.ListReviews(&rpc.ReviewQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reviews) != 0 {
+		t.Errorf("Expected an empty review queue, got %d", len(reviews))
+	}
+	decisions, _, err = c.reviewState(pid.Pretty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decisions) != 2 {
+		t.Errorf("Expected the decisions to be kept, got %v", decisions)
+	}
+}
+
+func TestCrawler_QuarantineNode(t *testing.T) {
+	c, pid := newQuarantineCrawler(t)
+
+	err := c.db.Update(func(db *gorm.DB) error {
+		if err := db.Create(&repo.Peer{PeerID: pid.Pretty()}).Error; err != nil {
+			return err
+		}
+		return db.Create(&repo.CIDRecord{CID: "QmRoot", PeerID: pid.Pretty()}).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.indexProfile(pid.Pretty(), &models.Profile{Name: "Store"}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.QuarantineNode(pid, "reported"); err != nil {
+		t.Fatal(err)
+	}
+	_, quarantined, err := c.reviewState(pid.Pretty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !quarantined {
+		t.Fatal("Expected the node to be quarantined")
+	}
+	err = c.db.View(func(db *gorm.DB) error {
+		var count int64
+		if err := db.Model(&repo.Profile{}).Where("peer_id=?", pid.Pretty()).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != 0 {
+			t.Error("Expected the profile to be removed from the index")
+		}
+		var rec repo.CIDRecord
+		if err := db.Where("peer_id=?", pid.Pretty()).First(&rec).Error; err != nil {
+			return err
+		}
+		if !rec.Quarantined {
+			t.Error("Expected the node's CIDs to be quarantined")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reviews, err := c.ListReviews(&rpc.ReviewQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reviews) != 1 || reviews[0].CID != "" || reviews[0].Reason != "reported" || reviews[0].Data != nil {
+		t.Fatalf("Expected the node to be pending review, got %v", reviews)
+	}
+
+	if err := c.RejectReview(pid, ""); err != nil {
+		t.Fatal(err)
+	}
+	err = c.db.View(func(db *gorm.DB) error {
+		var p repo.Peer
+		if err := db.Where("peer_id=?", pid.Pretty()).First(&p).Error; err != nil {
+			return err
+		}
+		if !p.Banned || p.Quarantined {
+			t.Errorf("Expected the rejected node to be banned, got banned %t quarantined %t", p.Banned, p.Quarantined)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.QuarantineNode(pid, "reported"); err == nil {
+		t.Error("Expected quarantining a banned node to fail")
+	}
+}
+
+func TestCrawler_NotifyQuarantined(t *testing.T) {
+	c, _ := newQuarantineCrawler(t)
+
+	public, err := c.Subscribe(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer public.Close()
+	moderator, err := c.Subscribe(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer moderator.Close()
+
+	received := func(sub *rpc.Subscription) chan *rpc.Object {
+		ch := make(chan *rpc.Object, 2)
+		go func() {
+			for obj := range sub.Out {
+				ch <- obj
+			}
+		}()
+		return ch
+	}
+	publicObjs, moderatorObjs := received(public), received(moderator)
+
+	c.notifySubscribers(&rpc.Object{Data: "held", Quarantined: true})
+	c.notifySubscribers(&rpc.Object{Data: "allowed"})
+
+	for _, expected := range []string{"held", "allowed"} {
+		select {
+		case obj := <-moderatorObjs:
+			if obj.Data != expected {
+				t.Errorf("Expected moderator to receive %s, got %v", expected, obj.Data)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("Timed out waiting for %s", expected)
+		}
+	}
+	select {
+	case obj := <-publicObjs:
+		if obj.Data != "allowed" {
+			t.Errorf("Expected public subscriber to receive only allowed data, got %v", obj.Data)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Timed out waiting for allowed data")
+	}
+}
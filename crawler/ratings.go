@@ -0,0 +1,262 @@
+package crawler
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/cpacia/openbazaar3.0/orders/utils"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	// maxNewRatings is the most ratings fetched from a peer in one crawl.
+	maxNewRatings = 500
+
+	// ratingsTimeout is the most time spent fetching a peer's ratings in
+	// one crawl.
+	ratingsTimeout = time.Minute * 2
+
+	// The reputation score is the average overall rating weighted towards
+	// reputationPrior as if reputationPriorWeight ratings of it were added.
+	// This keeps vendors with few ratings from ranking above vendors with
+	// many slightly lower ratings.
+	reputationPrior       = 3.0
+	reputationPriorWeight = 5.0
+)
+
+// crawlRatings fetches the ratings in the peer's rating index which
+// haven't been fetched before and saves those which are valid. Ratings
+// which are invalid or superseded are recorded as rejected and skipped
+// by later crawls. Ratings which can't be loaded are retried on the next
+// crawl, but no more than ratingsTimeout is spent fetching ratings.
+func (c *Crawler) crawlRatings(node uint, pid peer.ID, indexCID cid.Cid) error {
+	ctx, cancel := context.WithTimeout(c.ctx, ratingsTimeout)
+	defer cancel()
+
+	ipfsNode := c.nodes[node].IPFSNode()
+	indexBytes, err := c.cat(ctx, ipfsNode, path.IpfsPath(indexCID))
+	if err != nil {
+		return err
+	}
+	var index models.RatingIndex
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return err
+	}
+
+	var known, rejected []string
+	err = c.db.View(func(db *gorm.DB) error {
+		if err := db.Model(&repo.Rating{}).Where("peer_id=?", pid.Pretty()).Pluck("c_id", &known).Error; err != nil {
+			return err
+		}
+		return db.Model(&repo.RejectedRating{}).Where("peer_id=?", pid.Pretty()).Pluck("c_id", &rejected).Error
+	})
+	if err != nil {
+		return err
+	}
+	fetched := make(map[string]bool)
+	for _, id := range append(known, rejected...) {
+		fetched[id] = true
+	}
+
+	// Forget the rejected ratings which are no longer in the index.
+	indexed := make(map[string]bool)
+	for _, info := range index {
+		for _, id := range info.Ratings {
+			indexed[id] = true
+		}
+	}
+	err = c.db.Update(func(db *gorm.DB) error {
+		for _, id := range rejected {
+			if indexed[id] {
+				continue
+			}
+			if err := db.Where("peer_id=?", pid.Pretty()).Where("c_id=?", id).Delete(&repo.RejectedRating{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	n := 0
+	for _, info := range index {
+		for _, id := range info.Ratings {
+			if fetched[id] {
+				continue
+			}
+			if n >= maxNewRatings {
+				return nil
+			}
+			if ctx.Err() != nil {
+				log.Debugf("Ran out of time fetching ratings for peer %s", pid.Pretty())
+				return nil
+			}
+			n++
+			fetched[id] = true
+
+			ratingCID, err := cid.Decode(id)
+			if err != nil {
+				log.Debugf("Invalid rating CID %s for peer %s", id, pid.Pretty())
+				if err := c.rejectRating(pid.Pretty(), id, err); err != nil {
+					return err
+				}
+				continue
+			}
+			ratingBytes, err := c.cat(ctx, ipfsNode, path.IpfsPath(ratingCID))
+			if err != nil {
+				log.Debugf("Unable to load rating %s for peer %s: %s", id, pid.Pretty(), err)
+				continue
+			}
+			rating := new(obpb.Rating)
+			if err := jsonpb.UnmarshalString(string(ratingBytes), rating); err != nil {
+				log.Debugf("Invalid rating %s for peer %s: %s", id, pid.Pretty(), err)
+				if err := c.rejectRating(pid.Pretty(), id, err); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := c.saveRating(pid.Pretty(), info.Slug, id, rating); err != nil {
+				log.Debugf("Rejected rating %s for peer %s: %s", id, pid.Pretty(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// rejectRating records the rating as rejected for the reason.
+func (c *Crawler) rejectRating(vendorID, ratingCID string, reason error) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		return db.Clauses(upsert).Create(&repo.RejectedRating{
+			PeerID: vendorID,
+			CID:    ratingCID,
+			Reason: reason.Error(),
+		}).Error
+	})
+}
+
+// saveRating verifies the rating and saves it. The rating must be signed
+// by the vendor for an order of the listing it is indexed under, and by
+// the buyer unless it is anonymous. If the order was rated before only the
+// most recent rating is kept. Invalid and superseded ratings are recorded
+// as rejected.
+//
+// Ratings are kept after they are removed from the vendor's index as they
+// are signed by the buyer and the vendor can't be trusted to publish
+// its bad ratings.
+func (c *Crawler) saveRating(vendorID, slug, ratingCID string, rating *obpb.Rating) error {
+	timestamp, err := verifyRating(vendorID, slug, rating)
+	if err != nil {
+		if err := c.rejectRating(vendorID, ratingCID, err); err != nil {
+			return err
+		}
+		return err
+	}
+
+	r := repo.Rating{
+		PeerID:          vendorID,
+		RatingKey:       hex.EncodeToString(rating.VendorSig.RatingKey),
+		CID:             ratingCID,
+		Slug:            slug,
+		Overall:         rating.Overall,
+		Quality:         rating.Quality,
+		Description:     rating.Description,
+		DeliverySpeed:   rating.DeliverySpeed,
+		CustomerService: rating.CustomerService,
+		Timestamp:       timestamp,
+	}
+	if rating.BuyerID != nil {
+		r.BuyerID = rating.BuyerID.PeerID
+	}
+	return c.db.Update(func(db *gorm.DB) error {
+		var existing repo.Rating
+		err := db.Where("peer_id=?", r.PeerID).Where("rating_key=?", r.RatingKey).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && existing.CID != r.CID {
+			// The older of the two ratings of the order is superseded.
+			superseded := existing.CID
+			if !existing.Timestamp.Before(r.Timestamp) {
+				superseded = r.CID
+			}
+			err := db.Clauses(upsert).Create(&repo.RejectedRating{
+				PeerID: vendorID,
+				CID:    superseded,
+				Reason: "superseded by a newer rating of the order",
+			}).Error
+			if err != nil {
+				return err
+			}
+			if superseded == r.CID {
+				return nil
+			}
+		}
+		return db.Clauses(upsert).Create(&r).Error
+	})
+}
+
+// verifyRating checks the rating's signatures and that it is for the
+// vendor's listing and returns its timestamp.
+func verifyRating(vendorID, slug string, rating *obpb.Rating) (time.Time, error) {
+	if err := utils.ValidateRating(rating); err != nil {
+		return time.Time{}, err
+	}
+	if rating.VendorID.PeerID != vendorID {
+		return time.Time{}, errors.New("rating is for a different vendor")
+	}
+	if rating.VendorSig.Slug != slug {
+		return time.Time{}, errors.New("rating is for a different listing")
+	}
+	return ptypes.Timestamp(rating.Timestamp)
+}
+
+// GetReputation returns the aggregate of the verified ratings of the
+// vendor.
+func (c *Crawler) GetReputation(pid peer.ID) (*rpc.Reputation, error) {
+	var ratings []repo.Rating
+	err := c.db.View(func(db *gorm.DB) error {
+		return db.Where("peer_id=?", pid.Pretty()).Find(&ratings).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rep := new(rpc.Reputation)
+	if len(ratings) == 0 {
+		rep.Score = reputationPrior
+		return rep, nil
+	}
+	var overall, quality, description, deliverySpeed, customerService float64
+	for _, r := range ratings {
+		overall += float64(r.Overall)
+		quality += float64(r.Quality)
+		description += float64(r.Description)
+		deliverySpeed += float64(r.DeliverySpeed)
+		customerService += float64(r.CustomerService)
+		if r.Timestamp.After(rep.LastRated) {
+			rep.LastRated = r.Timestamp
+		}
+	}
+	n := float64(len(ratings))
+	rep.RatingCount = len(ratings)
+	rep.Overall = overall / n
+	rep.Quality = quality / n
+	rep.Description = description / n
+	rep.DeliverySpeed = deliverySpeed / n
+	rep.CustomerService = customerService / n
+	rep.Score = (reputationPrior*reputationPriorWeight + overall) / (reputationPriorWeight + n)
+	return rep, nil
+}
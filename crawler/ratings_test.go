@@ -0,0 +1,298 @@
+package crawler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/ipfs/go-cid"
+	files "github.com/ipfs/go-ipfs-files"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+// newRating returns a rating signed by the vendor for an order of the
+// listing. A new order is rated if the rating key is nil.
+func newRating(t *testing.T, vendorKey crypto.PrivKey, ratingKey *btcec.PrivateKey, slug string, overall uint32, timestamp time.Time) *pb.Rating {
+	vendorID, err := peer.IDFromPrivateKey(vendorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := crypto.MarshalPublicKey(vendorKey.GetPublic())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ratingKey == nil {
+		ratingKey, err = btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	ts, err := ptypes.TimestampProto(timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rating := &pb.Rating{
+		VendorID: &pb.ID{
+			PeerID:  vendorID.Pretty(),
+			Pubkeys: &pb.ID_Pubkeys{Identity: identity},
+		},
+		VendorSig: &pb.RatingSignature{
+			Slug:      slug,
+			RatingKey: ratingKey.PubKey().SerializeCompressed(),
+		},
+		Timestamp:       ts,
+		Overall:         overall,
+		Quality:         overall,
+		Description:     overall,
+		DeliverySpeed:   overall,
+		CustomerService: overall,
+	}
+	signRating(t, vendorKey, ratingKey, rating)
+	return rating
+}
+
+// signRating signs the rating with the vendor and rating keys.
+func signRating(t *testing.T, vendorKey crypto.PrivKey, ratingKey *btcec.PrivateKey, rating *pb.Rating) {
+	rating.VendorSig.VendorSignature = nil
+	ser, err := proto.Marshal(rating.VendorSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rating.VendorSig.VendorSignature, err = vendorKey.Sign(ser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rating.RatingSignature = nil
+	ser, err = proto.Marshal(rating)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashed := sha256.Sum256(ser)
+	sig, err := ratingKey.Sign(hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	rating.RatingSignature = sig.Serialize()
+}
+
+func TestCrawler_Reputation(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{db: db}
+
+	vendorKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendorID, err := peer.IDFromPrivateKey(vendorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep, err := c.GetReputation(vendorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.RatingCount != 0 || rep.Score != reputationPrior {
+		t.Errorf("Expected an empty reputation, got %+v", rep)
+	}
+
+	now := time.Now()
+	orderKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.saveRating(vendorID.Pretty(), "widget", "QmRating1", newRating(t, vendorKey, orderKey, "widget", 4, now.Add(-time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.saveRating(vendorID.Pretty(), "widget", "QmRating2", newRating(t, vendorKey, nil, "widget", 3, now)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the most recent rating of an order is counted.
+	if err := c.saveRating(vendorID.Pretty(), "widget", "QmRating3", newRating(t, vendorKey, orderKey, "widget", 5, now.Add(-time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.saveRating(vendorID.Pretty(), "widget", "QmRating4", newRating(t, vendorKey, orderKey, "widget", 1, now.Add(-time.Hour*2))); err != nil {
+		t.Fatal(err)
+	}
+
+	rejected := []struct {
+		vendor string
+		slug   string
+		rating *pb.Rating
+	}{
+		{vendorID.Pretty(), "gadget", newRating(t, vendorKey, nil, "widget", 1, now)},
+		{vendorID.Pretty(), "widget", newRating(t, otherKey, nil, "widget", 1, now)},
+		{vendorID.Pretty(), "widget", func() *pb.Rating {
+			r := newRating(t, vendorKey, nil, "widget", 4, now)
+			r.Overall = 1
+			return r
+		}()},
+	}
+	for i, test := range rejected {
+		if err := c.saveRating(test.vendor, test.slug, "QmRejected", test.rating); err == nil {
+			t.Errorf("Test %d: expected rating to be rejected", i)
+		}
+	}
+
+	rep, err = c.GetReputation(vendorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.RatingCount != 2 {
+		t.Fatalf("Expected 2 ratings, got %d", rep.RatingCount)
+	}
+	if rep.Overall != 4 || rep.Quality != 4 || rep.CustomerService != 4 {
+		t.Errorf("Expected averages of 4, got %+v", rep)
+	}
+	if expected := (reputationPrior*reputationPriorWeight + 8) / (reputationPriorWeight + 2); rep.Score != expected {
+		t.Errorf("Expected score %f, got %f", expected, rep.Score)
+	}
+	if rep.LastRated.Unix() != now.Unix() {
+		t.Errorf("Expected last rated %s, got %s", now, rep.LastRated)
+	}
+
+	if err := c.removeFromIndex(vendorID.Pretty()); err != nil {
+		t.Fatal(err)
+	}
+	rep, err = c.GetReputation(vendorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.RatingCount != 0 {
+		t.Errorf("Expected ratings to be removed, got %d", rep.RatingCount)
+	}
+}
+
+func TestCrawler_CrawlRatings(t *testing.T) {
+	mn, err := core.NewMocknet(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		nodes:    mn.Nodes(),
+		db:       db,
+		ctx:      context.Background(),
+		shutdown: make(chan struct{}),
+	}
+	capi, err := coreapi.NewCoreAPI(mn.Nodes()[0].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	add := func(data []byte) cid.Cid {
+		pth, err := capi.Unixfs().Add(context.Background(), files.NewBytesFile(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pth.Cid()
+	}
+	addRating := func(rating *pb.Rating) string {
+		s, err := (&jsonpb.Marshaler{}).MarshalToString(rating)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return add([]byte(s)).String()
+	}
+	addIndex := func(ratings ...string) cid.Cid {
+		b, err := json.Marshal(models.RatingIndex{{Slug: "widget", Ratings: ratings}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return add(b)
+	}
+
+	vendorKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendorID, err := peer.IDFromPrivateKey(vendorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	var (
+		latest     = addRating(newRating(t, vendorKey, orderKey, "widget", 5, now))
+		superseded = addRating(newRating(t, vendorKey, orderKey, "widget", 1, now.Add(-time.Hour)))
+		invalid    = add([]byte("not a rating")).String()
+		badCID     = "not a cid"
+	)
+
+	if err := c.crawlRatings(0, vendorID, addIndex(latest, superseded, invalid, badCID)); err != nil {
+		t.Fatal(err)
+	}
+
+	loadRatings := func() (map[string]bool, map[string]bool) {
+		var (
+			ratings  []repo.Rating
+			rejected []repo.RejectedRating
+		)
+		err := db.View(func(db *gorm.DB) error {
+			if err := db.Where("peer_id=?", vendorID.Pretty()).Find(&ratings).Error; err != nil {
+				return err
+			}
+			return db.Where("peer_id=?", vendorID.Pretty()).Find(&rejected).Error
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		saved, skipped := make(map[string]bool), make(map[string]bool)
+		for _, r := range ratings {
+			saved[r.CID] = true
+		}
+		for _, r := range rejected {
+			skipped[r.CID] = true
+		}
+		return saved, skipped
+	}
+	saved, skipped := loadRatings()
+	if len(saved) != 1 || !saved[latest] {
+		t.Errorf("Expected only the latest rating to be saved, got %v", saved)
+	}
+	if len(skipped) != 3 || !skipped[superseded] || !skipped[invalid] || !skipped[badCID] {
+		t.Errorf("Expected the superseded and invalid ratings to be rejected, got %v", skipped)
+	}
+
+	// Rejected ratings removed from the index are forgotten.
+	if err := c.crawlRatings(0, vendorID, addIndex(latest, superseded)); err != nil {
+		t.Fatal(err)
+	}
+	saved, skipped = loadRatings()
+	if len(saved) != 1 || !saved[latest] {
+		t.Errorf("Expected only the latest rating to be saved, got %v", saved)
+	}
+	if len(skipped) != 1 || !skipped[superseded] {
+		t.Errorf("Expected only the superseded rating to remain rejected, got %v", skipped)
+	}
+}
@@ -0,0 +1,149 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/gogo/protobuf/proto"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/ipfs/go-ipns"
+	ipnspb "github.com/ipfs/go-ipns/pb"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"math/rand"
+	"time"
+)
+
+// republishLastSeenWindow is how recently a peer must have been seen
+// for its record to be republished.
+const republishLastSeenWindow = time.Hour * 24 * 7
+
+// runRepublisher periodically republishes the still-valid IPNS records
+// of recently seen peers to pubsub. This keeps the stores discoverable
+// while their owners are offline.
+func (c *Crawler) runRepublisher() {
+	ticker := time.NewTicker(time.Minute)
+	for {
+		select {
+		case <-ticker.C:
+			c.republishRecords()
+		case <-c.shutdown:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// republishRecords republishes up to republishRate records, starting
+// with the ones that were republished the longest time ago.
+func (c *Crawler) republishRecords() {
+	var peers []repo.Peer
+	err := c.db.View(func(db *gorm.DB) error {
+		return db.Where("banned=?", false).
+			Where("ip_ns_expiration>?", time.Now()).
+			Where("last_seen>?", time.Now().Add(-republishLastSeenWindow)).
+			Where("last_republished<?", time.Now().Add(-c.republishInterval)).
+			Order("last_republished asc").
+			Limit(int(c.republishRate)).
+			Find(&peers).Error
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorf("Error loading peers to republish: %s", err)
+		return
+	}
+	for _, p := range peers {
+		select {
+		case <-c.shutdown:
+			return
+		default:
+		}
+		if err := c.republishRecord(p); err != nil {
+			log.Debugf("Did not republish IPNS record for peer %s: %s", p.PeerID, err)
+		}
+	}
+}
+
+// republishRecord publishes the stored record for the peer on /ipns/all
+// and on the peer's own IPNS pubsub topic, which is where nodes resolving
+// the peer over pubsub listen.
+//
+// Before publishing, the record is checked against the DHT and is only
+// published if no newer record is found there.
+func (c *Crawler) republishRecord(p repo.Peer) error {
+	// We are going to update the LastRepublished time regardless of whether
+	// the publish succeeds so that a single peer can't stall the republisher.
+	defer func() {
+		err := c.db.Update(func(db *gorm.DB) error {
+			return db.Model(&repo.Peer{}).Where("peer_id=?", p.PeerID).Update("last_republished", time.Now()).Error
+		})
+		if err != nil {
+			log.Errorf("Error saving last republished time for peer %s: %s", p.PeerID, err)
+		}
+	}()
+
+	pid, err := peer.Decode(p.PeerID)
+	if err != nil {
+		return err
+	}
+	rec := new(ipnspb.IpnsEntry)
+	if err := proto.Unmarshal(p.IPNSRecord, rec); err != nil {
+		return err
+	}
+
+	n := c.nodes[rand.Intn(int(c.numPubsub))]
+
+	latest, err := fetchIPNSRecord(c.ctx, n.IPFSNode(), pid, int(c.ipnsQuorum))
+	if err != nil {
+		return fmt.Errorf("unable to confirm record sequence: %w", err)
+	}
+	cmp, err := ipns.Compare(latest, rec)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		// Our copy of the record is out of date. Save the newer one and queue
+		// up a crawl of the peer. It will be republished on a later round.
+		expiration, err := ipns.GetEOL(latest)
+		if err != nil {
+			return err
+		}
+		ser, err := proto.Marshal(latest)
+		if err != nil {
+			return err
+		}
+		err = c.db.Update(func(db *gorm.DB) error {
+			return db.Model(&repo.Peer{}).Where("peer_id=?", p.PeerID).Updates(map[string]interface{}{
+				"ip_ns_record":     ser,
+				"ip_ns_expiration": expiration,
+			}).Error
+		})
+		if err != nil {
+			return err
+		}
+		go func() {
+			c.workChan <- &job{
+				Peer:       pid,
+				IPNSRecord: latest,
+				Expiration: expiration,
+				PinRecord:  c.pinRecords,
+			}
+		}()
+		return errors.New("newer record found in the DHT")
+	}
+
+	capi, err := coreapi.NewCoreAPI(n.IPFSNode())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, catTimeout)
+	defer cancel()
+
+	for _, topic := range []string{ipnsPubsubTopic, ipnsPeerTopic(pid)} {
+		if err := capi.PubSub().Publish(ctx, topic, p.IPNSRecord); err != nil {
+			return err
+		}
+	}
+	log.Debugf("Republished IPNS record for peer %s", pid.Pretty())
+	return nil
+}
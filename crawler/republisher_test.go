@@ -0,0 +1,232 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/gogo/protobuf/proto"
+	"github.com/ipfs/go-ipfs/core/coreapi"
+	"github.com/ipfs/go-ipns"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCrawler_RepublishRecordNewerInDHT(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		nodes:      mn.Nodes()[:1],
+		db:         db,
+		ctx:        context.Background(),
+		workChan:   make(chan *job, 1),
+		shutdown:   make(chan struct{}),
+		numPubsub:  1,
+		ipnsQuorum: 1,
+	}
+	defer close(c.shutdown)
+
+	// A store which isn't part of the network so its record is only ever
+	// put by the crawler.
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eol := time.Now().Add(time.Hour * 24)
+	stale, err := ipns.Create(priv, []byte("/ipfs/QmStale"), 1, eol, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ipns.EmbedPublicKey(priv.GetPublic(), stale); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := ipns.Create(priv, []byte("/ipfs/QmLatest"), 2, eol, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ipns.EmbedPublicKey(priv.GetPublic(), latest); err != nil {
+		t.Fatal(err)
+	}
+	staleSer, err := proto.Marshal(stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latestSer, err := proto.Marshal(latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The nodes' routing tables fill in the background after bootstrap.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	for {
+		err := c.nodes[0].IPFSNode().Routing.PutValue(ctx, ipns.RecordKey(pid), latestSer)
+		if err == nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal(err)
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+
+	p := repo.Peer{
+		PeerID:         pid.Pretty(),
+		IPNSRecord:     staleSer,
+		IPNSExpiration: eol,
+		LastSeen:       time.Now(),
+	}
+	if err := db.Update(func(db *gorm.DB) error { return db.Create(&p).Error }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.republishRecord(p); err == nil {
+		t.Fatal("Expected the stale record not to be republished")
+	}
+
+	err = db.View(func(db *gorm.DB) error {
+		var saved repo.Peer
+		if err := db.Where("peer_id=?", p.PeerID).First(&saved).Error; err != nil {
+			return err
+		}
+		if !bytes.Equal(saved.IPNSRecord, latestSer) {
+			t.Error("Expected the newer record from the DHT to be saved")
+		}
+		if saved.LastRepublished.IsZero() {
+			t.Error("Expected the republish attempt to be recorded")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case j := <-c.workChan:
+		if j.Peer != pid || j.IPNSRecord.GetSequence() != 2 {
+			t.Errorf("Expected a crawl of %s with the newer record, got %s %d", pid, j.Peer, j.IPNSRecord.GetSequence())
+		}
+	case <-time.After(time.Second * 5):
+		t.Error("Expected the peer to be queued for crawl")
+	}
+}
+
+func TestCrawler_RepublishRecord(t *testing.T) {
+	mn, err := core.NewMocknet(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		nodes:      mn.Nodes()[:1],
+		db:         db,
+		ctx:        context.Background(),
+		workChan:   make(chan *job, 1),
+		shutdown:   make(chan struct{}),
+		numPubsub:  1,
+		ipnsQuorum: 1,
+	}
+	defer close(c.shutdown)
+
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eol := time.Now().Add(time.Hour * 24)
+	rec, err := ipns.Create(priv, []byte("/ipfs/QmLatest"), 1, eol, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ipns.EmbedPublicKey(priv.GetPublic(), rec); err != nil {
+		t.Fatal(err)
+	}
+	ser, err := proto.Marshal(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	for {
+		err := c.nodes[0].IPFSNode().Routing.PutValue(ctx, ipns.RecordKey(pid), ser)
+		if err == nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal(err)
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+
+	// A subscriber which only listens on the shared topic.
+	api, err := coreapi.NewCoreAPI(mn.Nodes()[1].IPFSNode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := api.PubSub().Subscribe(ctx, ipnsPubsubTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	p := repo.Peer{
+		PeerID:         pid.Pretty(),
+		IPNSRecord:     ser,
+		IPNSExpiration: eol,
+		LastSeen:       time.Now(),
+	}
+	if err := db.Update(func(db *gorm.DB) error { return db.Create(&p).Error }); err != nil {
+		t.Fatal(err)
+	}
+
+	// The subscription takes a moment to propagate to the publisher.
+	received := make(chan []byte, 1)
+	go func() {
+		msg, err := sub.Next(ctx)
+		if err == nil {
+			received <- msg.Data()
+		}
+	}()
+	for {
+		if err := c.republishRecord(p); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case data := <-received:
+			if !bytes.Equal(data, ser) {
+				t.Error("Expected the saved record to be published on /ipns/all")
+			}
+			return
+		case <-ctx.Done():
+			t.Fatal("Expected the record to be published on /ipns/all")
+		case <-time.After(time.Millisecond * 500):
+		}
+	}
+}
@@ -23,10 +23,28 @@ import (
 // the client to set a key value in the context metadata to 'AuthenticationToken: cfg.AuthToken'
 const AuthenticationTokenKey = "AuthenticationToken"
 
-var authToken string
+// ModeratorTokenKey is the key used in the context to authenticate moderators.
+// The moderation methods and moderator subscriptions require the client to also
+// set 'ModeratorToken: cfg.ModeratorToken'. They are disabled if the moderator
+// token is not set in the config.
+const ModeratorTokenKey = "ModeratorToken"
+
+var (
+	authToken      string
+	moderatorToken string
+)
+
+// moderatorMethods are the methods which require the moderator token.
+var moderatorMethods = map[string]bool{
+	"/pb.obcrawler/QuarantineNode": true,
+	"/pb.obcrawler/ListReviews":    true,
+	"/pb.obcrawler/ApproveReview":  true,
+	"/pb.obcrawler/RejectReview":   true,
+}
 
 func newGrpcServer(netAddrs []net.Addr, crawler *Crawler, cfg *repo.Config) (*rpc.GrpcServer, error) {
 	authToken = cfg.GrpcAuthToken
+	moderatorToken = cfg.ModeratorToken
 	for _, addr := range netAddrs {
 		opts := []grpc.ServerOption{grpc.StreamInterceptor(interceptStreaming), grpc.UnaryInterceptor(interceptUnary)}
 		creds, err := credentials.NewServerTLSFromFile(cfg.RPCCert, cfg.RPCKey)
@@ -82,7 +100,7 @@ func interceptStreaming(srv interface{}, ss grpc.ServerStream, info *grpc.Stream
 		return err
 	}
 
-	err = handler(srv, ss)
+	err = handler(srv, &moderatorStream{ss})
 	if err != nil && ok {
 		log.Errorf("Streaming method %s invoked by %s errored: %v",
 			info.FullMethod, p.Addr.String(), err)
@@ -101,6 +119,11 @@ func interceptUnary(ctx context.Context, req interface{}, info *grpc.UnaryServer
 	if err != nil {
 		return nil, err
 	}
+	if moderatorMethods[info.FullMethod] {
+		if err := validateModeratorToken(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err = handler(ctx, req)
 	if err != nil && ok {
@@ -118,6 +141,33 @@ func validateAuthenticationToken(ctx context.Context) error {
 	return nil
 }
 
+func validateModeratorToken(ctx context.Context) error {
+	if moderatorToken == "" {
+		return errors.New("moderation is disabled without a moderator token")
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(ModeratorTokenKey)) == 0 || md.Get(ModeratorTokenKey)[0] != moderatorToken {
+		return errors.New("invalid moderator token")
+	}
+	return nil
+}
+
+// moderatorStream validates the moderator token of streams requesting a
+// moderator subscription.
+type moderatorStream struct {
+	grpc.ServerStream
+}
+
+func (s *moderatorStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if req, ok := m.(*pb.SubscribeRequest); ok && req.Moderator {
+		return validateModeratorToken(s.Context())
+	}
+	return nil
+}
+
 // parseListeners determines whether each listen address is IPv4 and IPv6 and
 // returns a slice of appropriate net.Addrs to listen on with TCP. It also
 // properly detects addresses which apply to "all interfaces" and adds the
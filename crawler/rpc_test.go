@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"context"
+	"github.com/cpacia/obcrawler/rpc/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+// fakeStream is a server stream which receives a single request.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req *pb.SubscribeRequest
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func (s *fakeStream) RecvMsg(m interface{}) error {
+	*m.(*pb.SubscribeRequest) = *s.req
+	return nil
+}
+
+func TestValidateModeratorToken(t *testing.T) {
+	defer func(token string) { moderatorToken = token }(moderatorToken)
+
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(ModeratorTokenKey, token))
+	}
+	tests := []struct {
+		name       string
+		configured string
+		ctx        context.Context
+		valid      bool
+	}{
+		{
+			name:  "unconfigured without token",
+			ctx:   context.Background(),
+			valid: false,
+		},
+		{
+			name:  "unconfigured with empty token",
+			ctx:   withToken(""),
+			valid: false,
+		},
+		{
+			name:       "missing token",
+			configured: "secret",
+			ctx:        context.Background(),
+			valid:      false,
+		},
+		{
+			name:       "wrong token",
+			configured: "secret",
+			ctx:        withToken("guess"),
+			valid:      false,
+		},
+		{
+			name:       "valid token",
+			configured: "secret",
+			ctx:        withToken("secret"),
+			valid:      true,
+		},
+	}
+	for _, test := range tests {
+		moderatorToken = test.configured
+		if err := validateModeratorToken(test.ctx); (err == nil) != test.valid {
+			t.Errorf("%s: expected valid %t, got error %v", test.name, test.valid, err)
+		}
+
+		// Only moderator subscriptions require the moderator token.
+		for _, moderator := range []bool{false, true} {
+			stream := &moderatorStream{&fakeStream{ctx: test.ctx, req: &pb.SubscribeRequest{Moderator: moderator}}}
+			err := stream.RecvMsg(new(pb.SubscribeRequest))
+			if valid := test.valid || !moderator; (err == nil) != valid {
+				t.Errorf("%s: expected subscription with moderator %t to be valid %t, got error %v", test.name, moderator, valid, err)
+			}
+		}
+	}
+}
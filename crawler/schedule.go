@@ -0,0 +1,50 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/repo"
+	"time"
+)
+
+// changeSmoothing is the weight given to the latest observed interval
+// between root changes when updating a peer's average change interval.
+const changeSmoothing = 0.25
+
+// scheduleNextCrawl records the root CID seen by a crawl of the peer and
+// sets the peer's next crawl time from how often its root has changed.
+//
+// The interval between changes is tracked as a moving average and the
+// peer is re-crawled at twice that frequency. A peer which hasn't changed
+// for longer than usual backs off to half the time since its last change,
+// so dormant peers drift towards max. Peers without a change history use
+// the default interval. The result is clamped to [min, max].
+func scheduleNextCrawl(peer *repo.Peer, root string, now time.Time, def, min, max time.Duration) {
+	if root != "" && root != peer.RootCID {
+		if peer.RootCID != "" && !peer.RootChangedAt.IsZero() {
+			observed := now.Sub(peer.RootChangedAt)
+			if peer.ChangeInterval == 0 {
+				peer.ChangeInterval = observed
+			} else {
+				peer.ChangeInterval = time.Duration(float64(peer.ChangeInterval)*(1-changeSmoothing) + float64(observed)*changeSmoothing)
+			}
+		}
+		peer.RootCID = root
+		peer.RootChangedAt = now
+	}
+
+	interval := def
+	if peer.ChangeInterval > 0 {
+		interval = peer.ChangeInterval / 2
+	}
+	if !peer.RootChangedAt.IsZero() {
+		if backoff := now.Sub(peer.RootChangedAt) / 2; backoff > interval {
+			interval = backoff
+		}
+	}
+	if interval < min {
+		interval = min
+	}
+	if interval > max {
+		interval = max
+	}
+	peer.NextCrawlAt = now.Add(interval)
+}
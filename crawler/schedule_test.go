@@ -0,0 +1,66 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/repo"
+	"testing"
+	"time"
+)
+
+func TestScheduleNextCrawl(t *testing.T) {
+	var (
+		day   = time.Hour * 24
+		def   = day * 7
+		min   = day
+		max   = day * 30
+		start = time.Now()
+	)
+
+	// A new peer uses the default interval.
+	peer := &repo.Peer{}
+	scheduleNextCrawl(peer, "root1", start, def, min, max)
+	if peer.RootCID != "root1" || !peer.RootChangedAt.Equal(start) {
+		t.Errorf("Expected root to be recorded")
+	}
+	if got := peer.NextCrawlAt.Sub(start); got != def {
+		t.Errorf("Expected default interval, got %s", got)
+	}
+
+	// A peer changing every 4 days is crawled every 2.
+	now := start.Add(day * 4)
+	scheduleNextCrawl(peer, "root2", now, def, min, max)
+	if peer.ChangeInterval != day*4 {
+		t.Errorf("Expected change interval of 4 days, got %s", peer.ChangeInterval)
+	}
+	if got := peer.NextCrawlAt.Sub(now); got != day*2 {
+		t.Errorf("Expected interval of 2 days, got %s", got)
+	}
+
+	// A faster change moves the average towards it.
+	now = now.Add(day * 2)
+	scheduleNextCrawl(peer, "root3", now, def, min, max)
+	if peer.ChangeInterval != time.Duration(float64(day)*3.5) {
+		t.Errorf("Expected change interval of 3.5 days, got %s", peer.ChangeInterval)
+	}
+
+	// An unchanged peer backs off to half the time since its last change.
+	now = now.Add(day * 10)
+	scheduleNextCrawl(peer, "root3", now, def, min, max)
+	if got := peer.NextCrawlAt.Sub(now); got != day*5 {
+		t.Errorf("Expected interval of 5 days, got %s", got)
+	}
+
+	// The backoff is capped at max.
+	now = now.Add(day * 100)
+	scheduleNextCrawl(peer, "root3", now, def, min, max)
+	if got := peer.NextCrawlAt.Sub(now); got != max {
+		t.Errorf("Expected max interval, got %s", got)
+	}
+
+	// Very frequent changes are capped at min.
+	peer = &repo.Peer{RootCID: "a", RootChangedAt: start, ChangeInterval: time.Hour}
+	now = start.Add(time.Hour)
+	scheduleNextCrawl(peer, "b", now, def, min, max)
+	if got := peer.NextCrawlAt.Sub(now); got != min {
+		t.Errorf("Expected min interval, got %s", got)
+	}
+}
@@ -0,0 +1,496 @@
+package crawler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/cpacia/obcrawler/pricing"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+
+	// Terms outside these lengths are not indexed.
+	minTermLength = 2
+	maxTermLength = 64
+)
+
+// The weights of the fields a term can appear in. A result's score is the
+// sum of the weights of the fields its matching terms appear in.
+const (
+	weightTitle       = 4
+	weightName        = 4
+	weightTag         = 3
+	weightCategory    = 2
+	weightHandle      = 2
+	weightDescription = 1
+)
+
+// tokenize splits the text into lower case terms.
+func tokenize(text string) []string {
+	var terms []string
+	for _, term := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if n := len([]rune(term)); n >= minTermLength && n <= maxTermLength {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// termWeights adds the weight to every term in the text.
+func termWeights(weights map[string]uint, weight uint, text ...string) {
+	for _, t := range text {
+		seen := make(map[string]bool)
+		for _, term := range tokenize(t) {
+			if !seen[term] {
+				weights[term] += weight
+				seen[term] = true
+			}
+		}
+	}
+}
+
+// setSearchTerms replaces the indexed terms of the listing or profile.
+func setSearchTerms(db *gorm.DB, peerID, slug string, weights map[string]uint) error {
+	if err := db.Where("peer_id=?", peerID).Where("slug=?", slug).Delete(&repo.SearchTerm{}).Error; err != nil {
+		return err
+	}
+	terms := make([]repo.SearchTerm, 0, len(weights))
+	for term, weight := range weights {
+		terms = append(terms, repo.SearchTerm{
+			Term:   term,
+			PeerID: peerID,
+			Slug:   slug,
+			Weight: weight,
+		})
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+	return db.Create(&terms).Error
+}
+
+// indexListing saves the listing and adds it to the search index and
+// the duplicate detection features, replacing any previous version with
+// the same slug.
+func (c *Crawler) indexListing(peerID string, sl *obpb.SignedListing, expiration time.Time) error {
+	l := sl.GetListing()
+	if l.GetItem() == nil || l.GetMetadata() == nil || l.Slug == "" {
+		return errors.New("listing is missing required fields")
+	}
+	ser, err := proto.Marshal(sl)
+	if err != nil {
+		return err
+	}
+
+	currency := l.Metadata.GetPricingCurrency().GetCode()
+	if currency == "" {
+		currency = l.Item.CryptoListingCurrencyCode
+	}
+	facets := map[string][]string{
+		rpc.FacetCategory:     l.Item.Categories,
+		rpc.FacetContractType: {l.Metadata.ContractType.String()},
+	}
+	if currency != "" {
+		facets[rpc.FacetCurrency] = []string{currency}
+	}
+	if l.Item.Condition != "" {
+		facets[rpc.FacetCondition] = []string{l.Item.Condition}
+	}
+	for _, opt := range l.ShippingOptions {
+		for _, region := range opt.Regions {
+			facets[rpc.FacetShipsTo] = append(facets[rpc.FacetShipsTo], region.String())
+		}
+	}
+
+	var price, normalized *float64
+	if p, priceCurrency, err := pricing.ListingPrice(l); err == nil {
+		price = &p
+		if c.prices != nil {
+			if n, ok := c.prices.Normalize(p, priceCurrency); ok {
+				normalized = &n
+			}
+		}
+	}
+
+	fp := fingerprint(l.Item.Title + " " + l.Item.Description)
+	features := listingFeatures(l, fp)
+
+	weights := make(map[string]uint)
+	termWeights(weights, weightTitle, l.Item.Title)
+	termWeights(weights, weightTag, l.Item.Tags...)
+	termWeights(weights, weightCategory, l.Item.Categories...)
+	termWeights(weights, weightHandle, l.GetVendorID().GetHandle())
+	termWeights(weights, weightDescription, l.Item.Description)
+
+	return c.db.Update(func(db *gorm.DB) error {
+		listing := repo.Listing{
+			PeerID:          peerID,
+			Slug:            l.Slug,
+			CID:             sl.Cid,
+			Title:           l.Item.Title,
+			Description:     l.Item.Description,
+			Condition:       l.Item.Condition,
+			ContractType:    l.Metadata.ContractType.String(),
+			Currency:        currency,
+			Nsfw:            l.Item.Nsfw,
+			Price:           price,
+			NormalizedPrice: normalized,
+			Fingerprint:     fp,
+			SignedListing:   ser,
+			Expiration:      expiration,
+		}
+		if err := db.Clauses(upsert).Create(&listing).Error; err != nil {
+			return err
+		}
+		if err := setListingFacets(db, peerID, l.Slug, facets); err != nil {
+			return err
+		}
+		if err := setListingFeatures(db, peerID, l.Slug, features); err != nil {
+			return err
+		}
+		return setSearchTerms(db, peerID, l.Slug, weights)
+	})
+}
+
+// setListingFacets replaces the facet values of the listing.
+func setListingFacets(db *gorm.DB, peerID, slug string, facets map[string][]string) error {
+	if err := db.Where("peer_id=?", peerID).Where("slug=?", slug).Delete(&repo.ListingFacet{}).Error; err != nil {
+		return err
+	}
+	var rows []repo.ListingFacet
+	seen := make(map[repo.ListingFacet]bool)
+	for facet, values := range facets {
+		for _, v := range values {
+			row := repo.ListingFacet{PeerID: peerID, Slug: slug, Facet: facet, Value: v}
+			if v != "" && !seen[row] {
+				rows = append(rows, row)
+				seen[row] = true
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.Create(&rows).Error
+}
+
+// indexProfile saves the profile and adds it to the search index. The
+// terms of moderators are saved for the moderator directory.
+func (c *Crawler) indexProfile(peerID string, profile *models.Profile, expiration time.Time) error {
+	ser, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	weights := make(map[string]uint)
+	termWeights(weights, weightName, profile.Name)
+	termWeights(weights, weightHandle, profile.Handle)
+	termWeights(weights, weightDescription, profile.ShortDescription, profile.About, profile.Location)
+
+	return c.db.Update(func(db *gorm.DB) error {
+		p := repo.Profile{
+			PeerID:     peerID,
+			Name:       profile.Name,
+			Handle:     profile.Handle,
+			Vendor:     profile.Vendor,
+			Moderator:  profile.Moderator,
+			Profile:    ser,
+			Expiration: expiration,
+		}
+		if err := db.Clauses(upsert).Create(&p).Error; err != nil {
+			return err
+		}
+		if err := c.setModerator(db, peerID, profile, expiration); err != nil {
+			return err
+		}
+		return setSearchTerms(db, peerID, "", weights)
+	})
+}
+
+// removeListings removes the peer's listings which are not in the
+// given set of slugs from the database and the search index.
+func (c *Crawler) removeListings(peerID string, keep map[string]bool) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		var listings []repo.Listing
+		if err := db.Where("peer_id=?", peerID).Find(&listings).Error; err != nil {
+			return err
+		}
+		for _, l := range listings {
+			if keep[l.Slug] {
+				continue
+			}
+			if err := db.Where("peer_id=?", peerID).Where("slug=?", l.Slug).Delete(&repo.Listing{}).Error; err != nil {
+				return err
+			}
+			if err := db.Where("peer_id=?", peerID).Where("slug=?", l.Slug).Delete(&repo.ListingFacet{}).Error; err != nil {
+				return err
+			}
+			if err := db.Where("peer_id=?", peerID).Where("slug=?", l.Slug).Delete(&repo.SearchTerm{}).Error; err != nil {
+				return err
+			}
+			if err := db.Where("peer_id=?", peerID).Where("slug=?", l.Slug).Delete(&repo.ListingFeature{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// removeProfile removes the peer's profile from the database, the search
+// index and the moderator directory.
+func (c *Crawler) removeProfile(peerID string) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		if err := db.Where("peer_id=?", peerID).Delete(&repo.Profile{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("peer_id=?", peerID).Delete(&repo.Moderator{}).Error; err != nil {
+			return err
+		}
+		return db.Where("peer_id=?", peerID).Where("slug=?", "").Delete(&repo.SearchTerm{}).Error
+	})
+}
+
+// removeFromIndex removes the peer's profile, listings and ratings from
+// the database, the search index and the moderator directory.
+func (c *Crawler) removeFromIndex(peerID string) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		for _, model := range []interface{}{&repo.Listing{}, &repo.ListingFacet{}, &repo.Profile{}, &repo.Moderator{}, &repo.Rating{}, &repo.RejectedRating{}, &repo.RejectedListing{}, &repo.SearchTerm{}, &repo.ListingFeature{}, &repo.ImageRef{}} {
+			if err := db.Where("peer_id=?", peerID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// setIndexExpiration updates the expiration of the peer's profile,
+// listings and moderator terms.
+func (c *Crawler) setIndexExpiration(peerID string, expiration time.Time) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		for _, model := range []interface{}{&repo.Listing{}, &repo.Profile{}, &repo.Moderator{}} {
+			if err := db.Model(model).Where("peer_id=?", peerID).Update("expiration", expiration).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// searchHit is a listing or profile on the requested page of results.
+// Data is the serialized listing or profile.
+type searchHit struct {
+	PeerID          string
+	Score           uint
+	Data            []byte
+	NormalizedPrice *float64
+	Expiration      time.Time
+}
+
+// listingFacetNames are the facets listing results are counted by.
+var listingFacetNames = []string{
+	rpc.FacetCategory,
+	rpc.FacetCurrency,
+	rpc.FacetCondition,
+	rpc.FacetContractType,
+	rpc.FacetShipsTo,
+}
+
+// facetCount is the number of matching listings with a facet value.
+type facetCount struct {
+	Facet string
+	Value string
+	Count int
+}
+
+// Search runs a full-text search over the crawled listings or profiles.
+// Expired data is never returned. Matching, filtering, sorting and paging
+// are done by the database and only the page of results is loaded.
+func (c *Crawler) Search(query *rpc.SearchQuery) (*rpc.SearchResults, error) {
+	terms := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, term := range tokenize(query.Query) {
+		if !seen[term] {
+			terms = append(terms, term)
+			seen[term] = true
+		}
+	}
+	ret := &rpc.SearchResults{Facets: make(map[string]map[string]int)}
+	if c.prices != nil {
+		ret.PriceCurrency = c.prices.Base()
+	}
+	if query.Query != "" && len(terms) == 0 {
+		return ret, nil
+	}
+
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	} else if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+
+	profiles := query.Type == rpc.SearchProfiles
+	var (
+		model      interface{} = &repo.Listing{}
+		table                  = "listings"
+		joinSlug               = "hits.slug = listings.slug"
+		selectData             = "listings.peer_id, listings.signed_listing AS data, listings.normalized_price, listings.expiration"
+		title                  = "listings.title"
+	)
+	if profiles {
+		model, table, joinSlug = &repo.Profile{}, "profiles", "hits.slug = ''"
+		selectData = "profiles.peer_id, profiles.profile AS data, profiles.expiration"
+		title = "profiles.name"
+	}
+
+	var hits []searchHit
+	err := c.db.View(func(db *gorm.DB) error {
+		// matches returns a new query over the unexpired listings or profiles
+		// which match every term and filter of the query.
+		matches := func() *gorm.DB {
+			tx := db.Model(model).Where(table+".expiration>?", time.Now())
+			if len(terms) > 0 {
+				scores := db.Model(&repo.SearchTerm{}).
+					Select("peer_id, slug, SUM(weight) AS score").
+					Where("term IN ?", terms).
+					Group("peer_id, slug").
+					Having("COUNT(*) = ?", len(terms))
+				tx = tx.Joins("JOIN (?) AS hits ON hits.peer_id = "+table+".peer_id AND "+joinSlug, scores)
+			}
+			if !profiles {
+				tx = filterListings(db, tx, query)
+			}
+			return tx
+		}
+
+		var total int64
+		if err := matches().Count(&total).Error; err != nil {
+			return err
+		}
+		ret.Total = int(total)
+		if !profiles {
+			for _, name := range listingFacetNames {
+				ret.Facets[name] = make(map[string]int)
+			}
+			var counts []facetCount
+			err := db.Model(&repo.ListingFacet{}).
+				Select("listing_facets.facet, listing_facets.value, COUNT(*) AS count").
+				Joins("JOIN (?) AS matches ON matches.peer_id = listing_facets.peer_id AND matches.slug = listing_facets.slug", matches().Select("listings.peer_id, listings.slug")).
+				Group("listing_facets.facet, listing_facets.value").
+				Scan(&counts).Error
+			if err != nil {
+				return err
+			}
+			for _, fc := range counts {
+				if ret.Facets[fc.Facet] != nil {
+					ret.Facets[fc.Facet][fc.Value] = fc.Count
+				}
+			}
+		}
+		if query.Page < 0 {
+			return nil
+		}
+
+		score := "0 AS score"
+		if len(terms) > 0 {
+			score = "hits.score"
+		}
+		var order string
+		switch query.SortBy {
+		case rpc.SortByNewest:
+			order = table + ".created_at DESC"
+		case rpc.SortByTitle:
+			order = "LOWER(" + title + ")"
+		case rpc.SortByPriceAscending, rpc.SortByPriceDescending:
+			// Listings without a price come last.
+			order = table + ".created_at DESC"
+			if !profiles {
+				order = "listings.normalized_price IS NULL, listings.normalized_price"
+				if query.SortBy == rpc.SortByPriceDescending {
+					order += " DESC"
+				}
+			}
+		default:
+			order = "score DESC, " + table + ".created_at DESC"
+		}
+		// Break ties by key so pages don't overlap.
+		order += ", " + table + ".peer_id"
+		if !profiles {
+			order += ", listings.slug"
+		}
+		return matches().
+			Select(selectData + ", " + score).
+			Order(order).
+			Limit(pageSize).
+			Offset(query.Page * pageSize).
+			Scan(&hits).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hit := range hits {
+		result := &rpc.SearchResult{
+			Score:          hit.Score,
+			ExpirationDate: hit.Expiration,
+		}
+		if profiles {
+			profile := new(models.Profile)
+			if err := json.Unmarshal(hit.Data, profile); err != nil {
+				return nil, err
+			}
+			result.Data = profile
+		} else {
+			sl := new(obpb.SignedListing)
+			if err := proto.Unmarshal(hit.Data, sl); err != nil {
+				return nil, err
+			}
+			result.Data = sl
+			result.Price = hit.NormalizedPrice
+		}
+		ret.Results = append(ret.Results, result)
+	}
+	return ret, nil
+}
+
+// filterListings adds the facet filters and price range of the query to
+// the listings query. A listing must have one of the accepted values of
+// every filter. Values are compared case insensitively.
+func filterListings(db, tx *gorm.DB, query *rpc.SearchQuery) *gorm.DB {
+	for name, accepted := range query.Filters {
+		if len(accepted) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(accepted))
+		for _, v := range accepted {
+			values = append(values, strings.ToLower(v))
+		}
+		tx = tx.Where("EXISTS (?)", db.Model(&repo.ListingFacet{}).
+			Select("1").
+			Where("listing_facets.peer_id = listings.peer_id AND listing_facets.slug = listings.slug").
+			Where("listing_facets.facet = ?", name).
+			Where("LOWER(listing_facets.value) IN ?", values))
+	}
+	if query.MinPrice > 0 || query.MaxPrice > 0 {
+		tx = tx.Where("listings.normalized_price IS NOT NULL")
+	}
+	if query.MinPrice > 0 {
+		tx = tx.Where("listings.normalized_price >= ?", query.MinPrice)
+	}
+	if query.MaxPrice > 0 {
+		tx = tx.Where("listings.normalized_price <= ?", query.MaxPrice)
+	}
+	return tx
+}
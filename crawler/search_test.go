@@ -0,0 +1,218 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/pricing"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCrawler_Search(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{db: db}
+
+	newListing := func(slug, title, currency string, contractType pb.Listing_Metadata_ContractType, regions ...pb.CountryCode) *pb.SignedListing {
+		return &pb.SignedListing{
+			Cid: slug,
+			Listing: &pb.Listing{
+				Slug: slug,
+				Metadata: &pb.Listing_Metadata{
+					ContractType:    contractType,
+					PricingCurrency: &pb.Currency{Code: currency, Divisibility: 2},
+				},
+				Item: &pb.Listing_Item{
+					Title:       title,
+					Description: "A fine item",
+					Tags:        []string{"handmade"},
+					Categories:  []string{"Crafts"},
+					Condition:   "New",
+				},
+				ShippingOptions: []*pb.Listing_ShippingOption{
+					{Regions: regions},
+				},
+			},
+		}
+	}
+
+	expiration := time.Now().Add(time.Hour)
+	listings := []*pb.SignedListing{
+		newListing("red-mug", "Red coffee mug", "USD", pb.Listing_Metadata_PHYSICAL_GOOD, pb.CountryCode_UNITED_STATES),
+		newListing("blue-mug", "Blue coffee mug", "BTC", pb.Listing_Metadata_PHYSICAL_GOOD, pb.CountryCode_UNITED_STATES, pb.CountryCode_CANADA),
+		newListing("mug-ebook", "Ebook about mugs", "USD", pb.Listing_Metadata_DIGITAL_GOOD),
+	}
+	for _, l := range listings {
+		if err := c.indexListing("QmVendor", l, expiration); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.indexListing("QmExpired", newListing("old-mug", "Old coffee mug", "USD", pb.Listing_Metadata_PHYSICAL_GOOD), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.indexProfile("QmVendor", &models.Profile{Name: "Mug Shop", Handle: "@mugs"}, expiration); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.Search(&rpc.SearchQuery{Query: "coffee mug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 2 {
+		t.Fatalf("Expected 2 results, got %d", res.Total)
+	}
+	if res.Facets[rpc.FacetCurrency]["USD"] != 1 || res.Facets[rpc.FacetCurrency]["BTC"] != 1 {
+		t.Errorf("Incorrect currency facet: %v", res.Facets[rpc.FacetCurrency])
+	}
+	if res.Facets[rpc.FacetShipsTo]["UNITED_STATES"] != 2 || res.Facets[rpc.FacetShipsTo]["CANADA"] != 1 {
+		t.Errorf("Incorrect ships to facet: %v", res.Facets[rpc.FacetShipsTo])
+	}
+	if _, ok := res.Results[0].Data.(*pb.SignedListing); !ok {
+		t.Fatal("Expected listing result")
+	}
+
+	res, err = c.Search(&rpc.SearchQuery{
+		Query:   "mug",
+		Filters: map[string][]string{rpc.FacetShipsTo: {"canada"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || res.Results[0].Data.(*pb.SignedListing).Listing.Slug != "blue-mug" {
+		t.Errorf("Expected only the blue mug to ship to Canada")
+	}
+
+	// Facet values may contain commas.
+	garden := newListing("garden-mug", "Garden coffee mug", "USD", pb.Listing_Metadata_PHYSICAL_GOOD)
+	garden.Listing.Item.Categories = []string{"Home, Garden", "Crafts"}
+	if err := c.indexListing("QmGardener", garden, expiration); err != nil {
+		t.Fatal(err)
+	}
+	res, err = c.Search(&rpc.SearchQuery{
+		Query:   "mug",
+		Filters: map[string][]string{rpc.FacetCategory: {"home, garden"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || res.Results[0].Data.(*pb.SignedListing).Listing.Slug != "garden-mug" {
+		t.Errorf("Expected only the garden mug in the home, garden category")
+	}
+	if res.Facets[rpc.FacetCategory]["Home, Garden"] != 1 || res.Facets[rpc.FacetCategory]["Crafts"] != 1 {
+		t.Errorf("Incorrect category facet: %v", res.Facets[rpc.FacetCategory])
+	}
+	res, err = c.Search(&rpc.SearchQuery{
+		Query:   "mug",
+		Filters: map[string][]string{rpc.FacetCategory: {"garden"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 0 {
+		t.Errorf("Expected part of a category not to match, got %d results", res.Total)
+	}
+	if err := c.removeFromIndex("QmGardener"); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err = c.Search(&rpc.SearchQuery{SortBy: rpc.SortByTitle, PageSize: 2, Page: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 3 || len(res.Results) != 1 || res.Results[0].Data.(*pb.SignedListing).Listing.Slug != "red-mug" {
+		t.Errorf("Incorrect second page of results sorted by title")
+	}
+
+	res, err = c.Search(&rpc.SearchQuery{Query: "mugs", Type: rpc.SearchProfiles})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || res.Results[0].Data.(*models.Profile).Name != "Mug Shop" {
+		t.Errorf("Expected profile result")
+	}
+
+	if err := c.removeListings("QmVendor", map[string]bool{"blue-mug": true}); err != nil {
+		t.Fatal(err)
+	}
+	res, err = c.Search(&rpc.SearchQuery{Query: "mug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 {
+		t.Errorf("Expected removed listings to be gone, got %d results", res.Total)
+	}
+
+	if err := c.removeFromIndex("QmVendor"); err != nil {
+		t.Fatal(err)
+	}
+	res, err = c.Search(&rpc.SearchQuery{Query: "mug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 0 {
+		t.Errorf("Expected banned peer's listings to be gone, got %d results", res.Total)
+	}
+}
+
+func TestCrawler_RemoveProfile(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{
+		db:     db,
+		prices: pricing.NewNormalizer("USD", nil),
+	}
+
+	profile := &models.Profile{
+		Name:      "Moderator",
+		Moderator: true,
+		ModeratorInfo: &models.ModeratorInfo{
+			Fee: models.ModeratorFee{FeeType: models.PercentageFee, Percentage: 5},
+		},
+	}
+	if err := c.indexProfile("QmModerator", profile, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.indexListing("QmModerator", &pb.SignedListing{
+		Listing: &pb.Listing{Slug: "widget", Metadata: &pb.Listing_Metadata{}, Item: &pb.Listing_Item{Title: "Moderator widget"}},
+	}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.removeProfile("QmModerator"); err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.View(func(db *gorm.DB) error {
+		for _, model := range []interface{}{&repo.Profile{}, &repo.Moderator{}} {
+			var count int64
+			if err := db.Model(model).Where("peer_id=?", "QmModerator").Count(&count).Error; err != nil {
+				return err
+			}
+			if count != 0 {
+				t.Errorf("Expected %T to be removed", model)
+			}
+		}
+		var terms []repo.SearchTerm
+		if err := db.Where("peer_id=?", "QmModerator").Find(&terms).Error; err != nil {
+			return err
+		}
+		if len(terms) == 0 {
+			t.Error("Expected the listing's search terms to be kept")
+		}
+		for _, term := range terms {
+			if term.Slug == "" {
+				t.Errorf("Expected the profile term %s to be removed", term.Term)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,119 @@
+package crawler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"math"
+	"strings"
+)
+
+const (
+	// A claimed count is inconsistent if it differs from the verified count
+	// by more than statsCountSlack and by more than statsCountTolerance of
+	// the verified count.
+	statsCountSlack     = 5
+	statsCountTolerance = 0.2
+
+	// maxRatingDifference is the largest difference between the claimed and
+	// verified average rating which is consistent.
+	maxRatingDifference = 0.5
+)
+
+// verifyStats recomputes the profile's stats from the peer's listing index,
+// its verified ratings and its follower files and records the stats which
+// are inconsistent with the profile. A nil link means the peer has no such
+// file.
+func (c *Crawler) verifyStats(node uint, pid peer.ID, profile *models.Profile, listingCount int, followersLink, followingLink *ipld.Link) (*rpc.VerifiedStats, error) {
+	if listingCount < 0 {
+		return nil, errors.New("listing index unavailable")
+	}
+	followers, err := c.countPeers(node, followersLink)
+	if err != nil {
+		return nil, err
+	}
+	following, err := c.countPeers(node, followingLink)
+	if err != nil {
+		return nil, err
+	}
+	rep, err := c.GetReputation(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &rpc.VerifiedStats{
+		FollowerCount:  followers,
+		FollowingCount: following,
+		ListingCount:   uint32(listingCount),
+		RatingCount:    uint32(rep.RatingCount),
+		AverageRating:  float32(rep.Overall),
+	}
+	stats.Inconsistent = inconsistentStats(profile.Stats, stats)
+	if len(stats.Inconsistent) > 0 {
+		log.Infof("Peer %s misreports its profile stats: %s", pid.Pretty(), strings.Join(stats.Inconsistent, ", "))
+	}
+
+	err = c.db.Update(func(db *gorm.DB) error {
+		return db.Model(&repo.Profile{}).
+			Where("peer_id=?", pid.Pretty()).
+			Update("inconsistent_stats", strings.Join(stats.Inconsistent, ",")).Error
+	})
+	return stats, err
+}
+
+// countPeers returns the number of distinct valid peer IDs in the
+// follower or following file.
+func (c *Crawler) countPeers(node uint, link *ipld.Link) (uint32, error) {
+	if link == nil {
+		return 0, nil
+	}
+	b, err := c.cat(c.ctx, c.nodes[node].IPFSNode(), path.IpfsPath(link.Cid))
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	if err := json.Unmarshal(b, &ids); err != nil {
+		return 0, err
+	}
+	seen := make(map[peer.ID]bool)
+	for _, id := range ids {
+		if pid, err := peer.Decode(id); err == nil {
+			seen[pid] = true
+		}
+	}
+	return uint32(len(seen)), nil
+}
+
+// inconsistentStats returns the names of the claimed stats which differ
+// significantly from the verified stats. The average rating is only
+// compared if there are verified ratings.
+func inconsistentStats(claimed *models.ProfileStats, verified *rpc.VerifiedStats) []string {
+	if claimed == nil {
+		return nil
+	}
+	var ret []string
+	for _, stat := range []struct {
+		name              string
+		claimed, verified uint32
+	}{
+		{rpc.StatFollowerCount, claimed.FollowerCount, verified.FollowerCount},
+		{rpc.StatFollowingCount, claimed.FollowingCount, verified.FollowingCount},
+		{rpc.StatListingCount, claimed.ListingCount, verified.ListingCount},
+		{rpc.StatRatingCount, claimed.RatingCount, verified.RatingCount},
+	} {
+		diff := math.Abs(float64(stat.claimed) - float64(stat.verified))
+		if diff > statsCountSlack && diff > statsCountTolerance*float64(stat.verified) {
+			ret = append(ret, stat.name)
+		}
+	}
+	if verified.RatingCount > 0 && math.Abs(float64(claimed.AverageRating-verified.AverageRating)) > maxRatingDifference {
+		ret = append(ret, rpc.StatAverageRating)
+	}
+	return ret
+}
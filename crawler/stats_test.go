@@ -0,0 +1,55 @@
+package crawler
+
+import (
+	"github.com/cpacia/obcrawler/rpc"
+	"github.com/cpacia/openbazaar3.0/models"
+	"reflect"
+	"testing"
+)
+
+func TestInconsistentStats(t *testing.T) {
+	verified := &rpc.VerifiedStats{
+		FollowerCount:  100,
+		FollowingCount: 3,
+		ListingCount:   10,
+		RatingCount:    20,
+		AverageRating:  4.5,
+	}
+	tests := []struct {
+		claimed  *models.ProfileStats
+		expected []string
+	}{
+		{
+			claimed:  nil,
+			expected: nil,
+		},
+		{
+			claimed:  &models.ProfileStats{FollowerCount: 110, FollowingCount: 7, ListingCount: 15, RatingCount: 24, AverageRating: 4.8},
+			expected: nil,
+		},
+		{
+			claimed:  &models.ProfileStats{FollowerCount: 5000, FollowingCount: 3, ListingCount: 10, RatingCount: 20, AverageRating: 4.5},
+			expected: []string{rpc.StatFollowerCount},
+		},
+		{
+			claimed:  &models.ProfileStats{FollowerCount: 100, FollowingCount: 3, ListingCount: 0, RatingCount: 500, AverageRating: 5},
+			expected: []string{rpc.StatListingCount, rpc.StatRatingCount},
+		},
+		{
+			claimed:  &models.ProfileStats{FollowerCount: 100, FollowingCount: 3, ListingCount: 10, RatingCount: 20, AverageRating: 2},
+			expected: []string{rpc.StatAverageRating},
+		},
+	}
+	for i, test := range tests {
+		got := inconsistentStats(test.claimed, verified)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i, test.expected, got)
+		}
+	}
+
+	// The average can't be checked without verified ratings.
+	unrated := &rpc.VerifiedStats{}
+	if got := inconsistentStats(&models.ProfileStats{AverageRating: 5}, unrated); got != nil {
+		t.Errorf("Expected no inconsistent stats, got %v", got)
+	}
+}
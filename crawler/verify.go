@@ -0,0 +1,58 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/cpacia/obcrawler/repo"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+)
+
+// verifyListing returns an error if the listing wasn't created by the
+// peer. The vendor ID and identity key must be the peer's and the listing
+// must be signed by that key. This stops peers from republishing other
+// vendors' listings in their own store.
+func verifyListing(pid peer.ID, sl *obpb.SignedListing) error {
+	l := sl.GetListing()
+	if l.GetVendorID() == nil || l.VendorID.Pubkeys == nil {
+		return errors.New("listing has no vendor ID")
+	}
+	if l.VendorID.PeerID != pid.Pretty() {
+		return fmt.Errorf("listing vendor %s does not match peer", l.VendorID.PeerID)
+	}
+	pubkey, err := crypto.UnmarshalPublicKey(l.VendorID.Pubkeys.Identity)
+	if err != nil {
+		return fmt.Errorf("invalid vendor identity key: %s", err)
+	}
+	if !pid.MatchesPublicKey(pubkey) {
+		return errors.New("vendor identity key does not match peer")
+	}
+	ser, err := proto.Marshal(l)
+	if err != nil {
+		return err
+	}
+	valid, err := pubkey.Verify(ser, sl.Signature)
+	if err != nil || !valid {
+		return errors.New("invalid listing signature")
+	}
+	return nil
+}
+
+// setRejectedListings replaces the listings recorded as rejected during
+// the peer's previous crawl. The map is keyed by CID.
+func (c *Crawler) setRejectedListings(peerID string, rejected map[string]*repo.RejectedListing) error {
+	return c.db.Update(func(db *gorm.DB) error {
+		if err := db.Where("peer_id=?", peerID).Delete(&repo.RejectedListing{}).Error; err != nil {
+			return err
+		}
+		for _, r := range rejected {
+			if err := db.Clauses(upsert).Create(r).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,125 @@
+package crawler
+
+import (
+	"crypto/rand"
+	"github.com/cpacia/obcrawler/repo"
+	"github.com/cpacia/openbazaar3.0/orders/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// newSignedListing returns a listing by the vendor signed with the key.
+func newSignedListing(t *testing.T, vendor crypto.PrivKey, signer crypto.PrivKey) *pb.SignedListing {
+	vendorID, err := peer.IDFromPrivateKey(vendor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := crypto.MarshalPublicKey(vendor.GetPublic())
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &pb.Listing{
+		Slug: "widget",
+		VendorID: &pb.ID{
+			PeerID:  vendorID.Pretty(),
+			Pubkeys: &pb.ID_Pubkeys{Identity: identity},
+		},
+		Item: &pb.Listing_Item{Title: "Widget"},
+	}
+	ser, err := proto.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := signer.Sign(ser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.SignedListing{Listing: l, Signature: sig}
+}
+
+func TestVerifyListing(t *testing.T) {
+	vendorKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendorID, err := peer.IDFromPrivateKey(vendorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherID, err := peer.IDFromPrivateKey(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyListing(vendorID, newSignedListing(t, vendorKey, vendorKey)); err != nil {
+		t.Errorf("Expected valid listing, got %s", err)
+	}
+
+	// Republished by another peer.
+	if err := verifyListing(otherID, newSignedListing(t, vendorKey, vendorKey)); err == nil {
+		t.Error("Expected listing republished by another peer to be rejected")
+	}
+
+	// Signed by a key other than the vendor's.
+	if err := verifyListing(vendorID, newSignedListing(t, vendorKey, otherKey)); err == nil {
+		t.Error("Expected listing with an invalid signature to be rejected")
+	}
+
+	// Claims the vendor's ID but carries another identity key.
+	spoofed := newSignedListing(t, otherKey, otherKey)
+	spoofed.Listing.VendorID.PeerID = vendorID.Pretty()
+	if err := verifyListing(vendorID, spoofed); err == nil {
+		t.Error("Expected listing with a mismatched identity key to be rejected")
+	}
+
+	// Modified after signing.
+	modified := newSignedListing(t, vendorKey, vendorKey)
+	modified.Listing.Item.Title = "Cheap widget"
+	if err := verifyListing(vendorID, modified); err == nil {
+		t.Error("Expected modified listing to be rejected")
+	}
+
+	if err := verifyListing(vendorID, &pb.SignedListing{Listing: &pb.Listing{}}); err == nil {
+		t.Error("Expected listing without a vendor ID to be rejected")
+	}
+}
+
+func TestCrawler_SetRejectedListings(t *testing.T) {
+	db, err := repo.NewDatabase("", repo.Dialect("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Crawler{db: db}
+
+	err = c.setRejectedListings("QmVendor", map[string]*repo.RejectedListing{
+		"QmA": {PeerID: "QmVendor", CID: "QmA", Slug: "a", Reason: "invalid listing signature"},
+		"QmB": {PeerID: "QmVendor", CID: "QmB", Slug: "b", Reason: "invalid listing signature"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.setRejectedListings("QmVendor", map[string]*repo.RejectedListing{
+		"QmC": {PeerID: "QmVendor", CID: "QmC", Slug: "c", Reason: "listing vendor QmOther does not match peer"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rejected []repo.RejectedListing
+	err = db.View(func(db *gorm.DB) error {
+		return db.Where("peer_id=?", "QmVendor").Find(&rejected).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rejected) != 1 || rejected[0].CID != "QmC" {
+		t.Errorf("Expected only the latest crawl's rejections, got %+v", rejected)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/cpacia/obcrawler/classifier"
 	"github.com/cpacia/obcrawler/repo"
 	"github.com/cpacia/obcrawler/rpc"
 	"github.com/cpacia/openbazaar3.0/core/coreiface"
@@ -19,18 +20,23 @@ import (
 	"github.com/ipfs/go-ipns"
 	ipnspb "github.com/ipfs/go-ipns/pb"
 	"github.com/ipfs/go-merkledag"
-	"github.com/ipfs/go-namesys"
 	"github.com/ipfs/interface-go-ipfs-core/path"
 	"github.com/libp2p/go-libp2p-core/peer"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"gorm.io/gorm"
 	"io/ioutil"
-	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const catTimeout = time.Second * 30
 
+// errQuotaExceeded is returned by fetchGraph when the size of
+// the graph exceeds the peer quota.
+var errQuotaExceeded = errors.New("peer quota exceeded")
+
 type job struct {
 	Peer           peer.ID
 	IPNSRecord     *ipnspb.IpnsEntry
@@ -45,7 +51,9 @@ func (c *Crawler) worker() {
 		case <-c.shutdown:
 			return
 		case job := <-c.workChan:
+			atomic.AddInt32(&c.activeWorkers, 1)
 			c.processJob(job)
+			atomic.AddInt32(&c.activeWorkers, -1)
 		}
 	}
 }
@@ -54,34 +62,38 @@ func (c *Crawler) processJob(job *job) {
 	log.Debugf("Starting crawl of peer %s", job.Peer.Pretty())
 	start := time.Now()
 
-	// We'll pick one random node to use for our crawls.
-	r := rand.Intn(len(c.nodes))
+	// The peer's data is fetched by the node which owns the peer on the hash
+	// ring and pinned by it and any replicas.
+	owners := c.ring.owners(job.Peer)
+	r := owners[0]
 
 	// We are going to defer update the LastCrawled time regardless of whether the crawl
 	// succeeds or not so that we don't get stuck in a loop perpetually crawling nodes
-	// which errored.
+	// which errored. Peers which aren't OpenBazaar stores are not tracked.
 	defer func() {
-		// Pin IPNS record if requested.
-		if job.PinRecord && job.IPNSRecord != nil {
-			if err := namesys.PutRecordToRouting(c.ctx, c.nodes[r].IPFSNode().Routing, nil, job.IPNSRecord); err != nil {
-				log.Errorf("Error pinning IPNS record for peer %s: %s", job.Peer.Pretty(), err)
-			}
-		}
 		err := c.db.Update(func(db *gorm.DB) error {
 			var peer repo.Peer
 			err := db.Where("peer_id=?", job.Peer.Pretty()).First(&peer).Error
-			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			} else if err != nil {
 				return err
 			}
-			peer.LastCrawled = time.Now()
-			if job.PinRecord {
-				peer.LastPinned = time.Now()
+			var root string
+			if job.IPNSRecord != nil {
+				root = string(job.IPNSRecord.GetValue())
 			}
+			peer.LastCrawled = time.Now()
+			scheduleNextCrawl(&peer, root, peer.LastCrawled, c.recrawlAge, c.recrawlMinAge, c.recrawlMaxAge)
 			return db.Save(&peer).Error
 		})
 		if err != nil {
 			log.Errorf("Error saving last crawled time for peer %s: %s", job.Peer.Pretty(), err)
 		}
+		// Pin IPNS record if requested.
+		if job.PinRecord && job.IPNSRecord != nil {
+			c.pinRecord(c.nodes[r], job.Peer, job.IPNSRecord)
+		}
 		log.Debugf("Crawl of %s finished in %s", job.Peer.Pretty(), time.Since(start))
 	}()
 
@@ -97,6 +109,12 @@ func (c *Crawler) processJob(job *job) {
 
 		if job.IPNSRecord != nil && bytes.Equal(rec.GetValue(), job.IPNSRecord.Value) {
 			log.Debugf("IPNS record for peer %s is unchanged", job.Peer.Pretty())
+			// The data is unchanged but the record may have been renewed.
+			if eol, err := ipns.GetEOL(rec); err == nil {
+				if err := c.setIndexExpiration(job.Peer.Pretty(), eol); err != nil {
+					log.Errorf("Error updating search index expiration for peer %s: %s", job.Peer.Pretty(), err)
+				}
+			}
 			return
 		}
 
@@ -107,6 +125,7 @@ func (c *Crawler) processJob(job *job) {
 			return
 		}
 		job.Expiration = eol
+		go c.joinPeerTopic(job.Peer)
 	}
 
 	// Next we want to load the IPLD node for the record's root CID. We can use this
@@ -135,27 +154,140 @@ func (c *Crawler) processJob(job *job) {
 		return
 	}
 
-	// If the profile link exists, crawl the profile.
+	ratingsLink, _, err := nd.ResolveLink([]string{"ratings.json"})
+	if err != nil && err != merkledag.ErrLinkNotFound {
+		log.Warningf("Error resolving ratings link for peer %s: %s", job.Peer.Pretty(), err)
+		return
+	}
+	followersLink, _, err := nd.ResolveLink([]string{"followers.json"})
+	if err != nil && err != merkledag.ErrLinkNotFound {
+		log.Warningf("Error resolving followers link for peer %s: %s", job.Peer.Pretty(), err)
+		return
+	}
+	followingLink, _, err := nd.ResolveLink([]string{"following.json"})
+	if err != nil && err != merkledag.ErrLinkNotFound {
+		log.Warningf("Error resolving following link for peer %s: %s", job.Peer.Pretty(), err)
+		return
+	}
+
+	// Only roots linking to a profile or listing index are OpenBazaar stores.
+	if profileLink == nil && listingsLink == nil {
+		log.Debugf("Root of peer %s is not an OpenBazaar store", job.Peer.Pretty())
+		return
+	}
+	if err := c.promotePeer(job); err != nil {
+		log.Errorf("Error saving store for peer %s: %s", job.Peer.Pretty(), err)
+		return
+	}
+
+	// If the rating index link exists, crawl the ratings. This is done before the
+	// profile so the reputation sent with it is up to date.
+	if ratingsLink != nil {
+		if err := c.crawlRatings(r, job.Peer, ratingsLink.Cid); err != nil {
+			log.Warningf("Error crawling ratings for peer %s: %s", job.Peer.Pretty(), err)
+		}
+	}
+
+	// Load the moderators' decisions on the peer's content. If the peer is
+	// quarantined none of its content is indexed, pinned or streamed to public
+	// subscribers until a moderator approves it.
+	decisions, peerQuarantined, err := c.reviewState(job.Peer.Pretty())
+	if err != nil {
+		log.Errorf("Error loading review state for peer %s: %s", job.Peer.Pretty(), err)
+		return
+	}
+
+	// If the profile link exists, crawl the profile, otherwise remove the peer's
+	// profile from the search index. The images referenced by the profile and
+	// listings are collected to be hashed. The files of blocked and quarantined
+	// content are collected so they can be excluded from pinning and the
+	// quarantined content is queued for review.
+	var (
+		profile    models.Profile
+		profileObj *rpc.Object
+		images     = make(map[string]bool)
+		allowed    = make(map[string]bool)
+		blocked    = make(map[string]bool)
+		held       = make(map[string]bool)
+		pending    = make(map[string]*repo.Review)
+	)
 	if profileLink != nil {
 		profileBytes, err := c.cat(c.ctx, c.nodes[r].IPFSNode(), path.IpfsPath(profileLink.Cid))
 		if err == nil {
-			var profile models.Profile
 			err := json.Unmarshal(profileBytes, &profile)
 			if err == nil {
 				log.Debugf("Crawled profile for peer %s", job.Peer.Pretty())
+				content := &classifier.Content{PeerID: job.Peer.Pretty(), Profile: &profile}
+				res := c.reviewContent(content, profileLink.Cid.String(), decisions)
+				if res.Verdict == classifier.Quarantine {
+					review, err := newReview(content, profileLink.Cid.String(), res)
+					if err != nil {
+						log.Errorf("Error queueing profile for review for peer %s: %s", job.Peer.Pretty(), err)
+					} else {
+						pending[profileLink.Cid.String()] = review
+					}
+				}
+				hold := res.Verdict == classifier.Quarantine || peerQuarantined
+				if res.Verdict == classifier.Block {
+					log.Infof("Blocked profile for peer %s: %s", job.Peer.Pretty(), strings.Join(res.Labels, ", "))
+					blocked[profileLink.Cid.String()] = true
+					profileFiles(blocked, &profile)
+					if err := c.removeProfile(job.Peer.Pretty()); err != nil {
+						log.Errorf("Error removing profile for peer %s: %s", job.Peer.Pretty(), err)
+					}
+				} else {
+					if hold {
+						held[profileLink.Cid.String()] = true
+						profileFiles(held, &profile)
+						if err := c.removeProfile(job.Peer.Pretty()); err != nil {
+							log.Errorf("Error removing profile for peer %s: %s", job.Peer.Pretty(), err)
+						}
+					} else {
+						allowed[profileLink.Cid.String()] = true
+						profileFiles(allowed, &profile)
+						profileImages(images, &profile)
+						if err := c.indexProfile(job.Peer.Pretty(), &profile, job.Expiration); err != nil {
+							log.Errorf("Error indexing profile for peer %s: %s", job.Peer.Pretty(), err)
+						}
+					}
+
+					profileObj = &rpc.Object{
+						ExpirationDate: job.Expiration,
+						Data:           &profile,
+						Labels:         res.Labels,
+						Quarantined:    hold,
+					}
+					if profile.Vendor {
+						profileObj.Reputation, err = c.GetReputation(job.Peer)
+						if err != nil {
+							log.Errorf("Error loading reputation for peer %s: %s", job.Peer.Pretty(), err)
+						}
+					}
 
-				// Send the found profile to subscribers.
-				defer c.notifySubscribers(&rpc.Object{
-					ExpirationDate: job.Expiration,
-					Data:           &profile,
-				})
+					// Send the found profile to subscribers.
+					defer c.notifySubscribers(profileObj)
+				}
 			}
 		}
+	} else if err := c.removeProfile(job.Peer.Pretty()); err != nil {
+		log.Errorf("Error removing profile for peer %s: %s", job.Peer.Pretty(), err)
 	}
 
-	// If the listing index link exists, crawl the listings.
-	var newListings []string
-	if listingsLink != nil {
+	// If the listing index link exists, crawl the listings. Listings no longer in
+	// the index are removed from the search index.
+	var (
+		newListings  []string
+		listingCount = -1
+	)
+	if listingsLink == nil {
+		listingCount = 0
+		if err := c.removeListings(job.Peer.Pretty(), nil); err != nil {
+			log.Errorf("Error removing listings for peer %s: %s", job.Peer.Pretty(), err)
+		}
+		if err := c.setRejectedListings(job.Peer.Pretty(), nil); err != nil {
+			log.Errorf("Error recording rejected listings for peer %s: %s", job.Peer.Pretty(), err)
+		}
+	} else {
 		listingBytes, err := c.cat(c.ctx, c.nodes[r].IPFSNode(), path.IpfsPath(listingsLink.Cid))
 		if err == nil {
 			var listingIndex models.ListingIndex
@@ -163,68 +295,235 @@ func (c *Crawler) processJob(job *job) {
 			if err == nil {
 				log.Debugf("Crawled listing index for peer %s", job.Peer.Pretty())
 				// Now that we have the index, range over each listing and try to download it.
+				// Listings which fail to load or verify, or are blocked by the classifiers,
+				// are recorded as rejected. Those which aren't the peer's own are removed
+				// from the search index, as are those blocked or quarantined.
+				slugs := make(map[string]bool)
 				for _, listing := range listingIndex {
-					id, err := cid.Decode(listing.CID)
+					slugs[listing.Slug] = true
+				}
+				var (
+					rejected = make(map[string]*repo.RejectedListing)
+					withheld = make(map[string]bool)
+				)
+				for _, entry := range listingIndex {
+					id, err := cid.Decode(entry.CID)
 					if err != nil {
 						log.Errorf("Error decoding CID for peer %s: %s", job.Peer.Pretty(), err)
 						continue
 					}
-					newListings = append(newListings, listing.CID)
 					listing, err := c.nodes[r].GetListingByCID(c.ctx, id)
 					if err != nil {
 						log.Errorf("Unable to load listing %s for peer %s: %s", id.String(), job.Peer.Pretty(), err)
+						rejected[entry.CID] = &repo.RejectedListing{PeerID: job.Peer.Pretty(), CID: entry.CID, Slug: entry.Slug, Reason: err.Error()}
+						continue
+					}
+					if err := verifyListing(job.Peer, listing); err != nil {
+						log.Warningf("Rejected listing %s for peer %s: %s", id.String(), job.Peer.Pretty(), err)
+						rejected[entry.CID] = &repo.RejectedListing{PeerID: job.Peer.Pretty(), CID: entry.CID, Slug: entry.Slug, Reason: err.Error()}
+						delete(slugs, entry.Slug)
+						continue
+					}
+					content := &classifier.Content{PeerID: job.Peer.Pretty(), Listing: listing}
+					res := c.reviewContent(content, entry.CID, decisions)
+					if res.Verdict == classifier.Block {
+						log.Infof("Blocked listing %s for peer %s: %s", id.String(), job.Peer.Pretty(), strings.Join(res.Labels, ", "))
+						rejected[entry.CID] = &repo.RejectedListing{PeerID: job.Peer.Pretty(), CID: entry.CID, Slug: entry.Slug, Reason: "blocked: " + strings.Join(res.Labels, ", ")}
+						withheld[entry.Slug] = true
+						blocked[entry.CID] = true
+						listingFiles(blocked, listing.GetListing())
 						continue
 					}
+					if res.Verdict == classifier.Quarantine {
+						review, err := newReview(content, entry.CID, res)
+						if err != nil {
+							log.Errorf("Error queueing listing %s for review for peer %s: %s", id.String(), job.Peer.Pretty(), err)
+						} else {
+							pending[entry.CID] = review
+						}
+					}
 					log.Debugf("Crawled listing %s for peer %s", listing.Cid, job.Peer.Pretty())
 
-					// Send the found listing to the subscribers.
-					defer c.notifySubscribers(&rpc.Object{
+					obj := &rpc.Object{
 						ExpirationDate: job.Expiration,
 						Data:           listing,
-					})
+						Labels:         res.Labels,
+						Quarantined:    res.Verdict == classifier.Quarantine || peerQuarantined,
+					}
+					if obj.Quarantined {
+						withheld[entry.Slug] = true
+						held[entry.CID] = true
+						listingFiles(held, listing.GetListing())
+					} else {
+						allowed[entry.CID] = true
+						listingFiles(allowed, listing.GetListing())
+						newListings = append(newListings, entry.CID)
+						listingImages(images, listing.GetListing())
+						if err := c.indexListing(job.Peer.Pretty(), listing, job.Expiration); err != nil {
+							log.Errorf("Error indexing listing %s for peer %s: %s", listing.Cid, job.Peer.Pretty(), err)
+						}
+						// The copies are only looked up for the stream. Clusters
+						// across the whole index are computed by FindDuplicates
+						// on demand.
+						if c.hasSubscribers() {
+							clusters, err := c.FindDuplicates(&rpc.DuplicateQuery{PeerID: job.Peer.Pretty(), Slug: listing.GetListing().GetSlug()})
+							if err != nil {
+								log.Errorf("Error finding duplicates of listing %s for peer %s: %s", listing.Cid, job.Peer.Pretty(), err)
+							} else if len(clusters) > 0 {
+								obj.Duplicates = clusters[0]
+							}
+						}
+					}
+
+					// Send the found listing to the subscribers.
+					defer c.notifySubscribers(obj)
+				}
+				listingCount = len(slugs)
+				for slug := range withheld {
+					delete(slugs, slug)
+				}
+				if err := c.removeListings(job.Peer.Pretty(), slugs); err != nil {
+					log.Errorf("Error removing listings for peer %s: %s", job.Peer.Pretty(), err)
+				}
+				if err := c.setRejectedListings(job.Peer.Pretty(), rejected); err != nil {
+					log.Errorf("Error recording rejected listings for peer %s: %s", job.Peer.Pretty(), err)
 				}
 			}
 		}
 	}
 
-	// If cacheData is set then we will traverse the full graph for this node and
-	// download all cids under the root so that we can pin them.
-	var graph []cid.Cid
-	if c.cacheData {
-		graph, err = c.fetchGraph(c.nodes[r].IPFSNode(), &rootCID)
+	// Recompute the profile's stats from the crawled data so subscribers can
+	// tell which of the claimed stats are misreported.
+	if profileObj != nil {
+		stats, err := c.verifyStats(r, job.Peer, &profile, listingCount, followersLink, followingLink)
 		if err != nil {
-			log.Errorf("Error fetching graph for peer %s: %s", job.Peer.Pretty(), err)
+			log.Warningf("Error verifying profile stats for peer %s: %s", job.Peer.Pretty(), err)
+		} else {
+			profileObj.Stats = stats
 		}
 	}
-	graph = append(graph, rootCID)
-	if profileLink != nil {
-		graph = append(graph, profileLink.Cid)
+
+	// The profile and listing files are always cached, even if the peer is over quota.
+	var partial []cid.Cid
+	if profileLink != nil && !blocked[profileLink.Cid.String()] && !held[profileLink.Cid.String()] {
+		partial = append(partial, profileLink.Cid)
 	}
 	if listingsLink != nil {
-		graph = append(graph, listingsLink.Cid)
+		partial = append(partial, listingsLink.Cid)
 	}
 	for _, l := range newListings {
 		id, err := cid.Decode(l)
 		if err != nil {
 			continue
 		}
+		partial = append(partial, id)
+	}
+
+	// Check the size of the peer's data against the quota. The root node reports the
+	// cumulative size of the graph so we can skip peers which are clearly over quota
+	// without downloading anything. The reported size can't be trusted though, so
+	// the quota is enforced on the bytes actually fetched below.
+	graphSize, err := nd.Size()
+	if err != nil {
+		log.Warningf("Error reading graph size for peer %s: %s", job.Peer.Pretty(), err)
+	}
+	overQuota := c.peerQuota > 0 && graphSize > c.peerQuota
+
+	// If cacheData is set then we will traverse the full graph for this node and
+	// download all cids under the root so that we can pin them. Pinning the root
+	// recursively downloads the full graph too so if pinFiles is set the graph is
+	// fetched first, capped at the quota, and the root is only pinned recursively
+	// if the whole graph was fetched.
+	var (
+		graph    []cid.Cid
+		complete bool
+	)
+	if (c.cacheData || c.pinFiles) && !overQuota {
+		graph, graphSize, err = c.fetchGraph(c.nodes[r].IPFSNode(), &rootCID, c.peerQuota)
+		if errors.Is(err, errQuotaExceeded) {
+			overQuota = true
+			graph = nil
+		} else if err != nil {
+			log.Errorf("Error fetching graph for peer %s: %s", job.Peer.Pretty(), err)
+		} else {
+			complete = true
+		}
+	}
+	if overQuota {
+		log.Infof("Peer %s is over quota (%d bytes). Caching profile and listings only.", job.Peer.Pretty(), graphSize)
+	}
+
+	// Record the images the peer references and, if they were just cached, compute
+	// their perceptual hashes. If the listing index couldn't be loaded the images
+	// found are incomplete so the previous references are kept.
+	if listingCount >= 0 {
+		if err := c.setImageRefs(job.Peer.Pretty(), images); err != nil {
+			log.Errorf("Error saving image references for peer %s: %s", job.Peer.Pretty(), err)
+		}
+		if err := c.setPendingReviews(job.Peer.Pretty(), pending); err != nil {
+			log.Errorf("Error queueing content for review for peer %s: %s", job.Peer.Pretty(), err)
+		}
+	}
+	if len(graph) > 0 {
+		if err := c.hashImages(r, images); err != nil {
+			log.Errorf("Error hashing images for peer %s: %s", job.Peer.Pretty(), err)
+		}
+	}
+
+	// The files of blocked content, and the nodes under them if they were
+	// cached, are neither recorded nor pinned. Those of quarantined content
+	// are recorded as quarantined but not pinned.
+	var excluded, quarantined map[cid.Cid]bool
+	if len(blocked) > 0 {
+		excluded = c.withheldGraph(r, blocked, allowed, len(graph) > 0)
+	}
+	if len(held) > 0 {
+		quarantined = c.withheldGraph(r, held, allowed, len(graph) > 0)
+	}
+	graph = append(graph, rootCID)
+	graph = append(graph, partial...)
+	for id := range quarantined {
 		graph = append(graph, id)
 	}
+	if len(excluded) > 0 {
+		var kept []cid.Cid
+		for _, id := range graph {
+			if !excluded[id] || quarantined[id] {
+				kept = append(kept, id)
+			}
+		}
+		graph = kept
+	}
 
 	// Finally we want to:
 	// 1) Load all existing CIDs for this peer.
 	// 2) Find the diff between the existing CIDs and new CIDs.
-	// 3) For each new CID, pin it (this will download from IPFS if necessary).
-	// 4) Unpin any CIDs that are not carrying forward. This will make them available
-	// to be garbage collected.
-	// 5) Delete CIDs not carrying forward from the db.
+	// 3) Delete CIDs not carrying forward from the db.
+	// 4) Hand the peer's pins to the pin manager. It will pin new files (this will
+	// download from IPFS if necessary) and unpin files no peer references anymore,
+	// making them available to be garbage collected.
 	var (
 		oldCIDs []repo.CIDRecord
 		newCIDs = make(map[string]bool)
-		toUnpin []string
+		evicted bool
 	)
 	err = c.db.Update(func(db *gorm.DB) error {
-		err := db.Where("peer_id=?", job.Peer.Pretty()).Find(&oldCIDs).Error
+		var peer repo.Peer
+		err := db.Where("peer_id=?", job.Peer.Pretty()).First(&peer).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		peer.PeerID = job.Peer.Pretty()
+		peer.GraphSize = graphSize
+		peer.OverQuota = overQuota
+		if err := db.Save(&peer).Error; err != nil {
+			return err
+		}
+		// The peer may have been quarantined during the crawl.
+		peerQuarantined = peerQuarantined || peer.Quarantined
+		evicted = peer.Evicted
+
+		err = db.Where("peer_id=?", job.Peer.Pretty()).Find(&oldCIDs).Error
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Errorf("Error loading current CIDs from DB for peer %s: %s", job.Peer.Pretty(), err)
 		}
@@ -248,18 +547,21 @@ func (c *Crawler) processJob(job *job) {
 				if err != nil {
 					log.Errorf("Error deleting CID record for peer %s: %s", job.Peer.Pretty(), err)
 				}
+			}
+		}
 
-				// While we deleted it for this peer, we only want to unpin it if no other peer has this CID.
-				// So here we will query the db to see if we can find any stored by any other peers. If no, then
-				// we will add it to our unpin list.
-				var recs []repo.CIDRecord
-				err = db.Where("c_id=?", rec.CID).Where("peer_id<>?", job.Peer.Pretty()).Find(&recs).Error
-				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-					return err
-				}
-				if len(recs) == 0 {
-					toUnpin = append(toUnpin, rec.CID)
-				}
+		// Mark the CIDs of quarantined content, or all of the peer's CIDs if
+		// the peer itself is quarantined.
+		if err := db.Model(&repo.CIDRecord{}).Where("peer_id=?", job.Peer.Pretty()).Update("quarantined", peerQuarantined).Error; err != nil {
+			return err
+		}
+		if !peerQuarantined && len(quarantined) > 0 {
+			ids := make([]string, 0, len(quarantined))
+			for id := range quarantined {
+				ids = append(ids, id.String())
+			}
+			if err := db.Model(&repo.CIDRecord{}).Where("peer_id=?", job.Peer.Pretty()).Where("c_id IN ?", ids).Update("quarantined", true).Error; err != nil {
+				return err
 			}
 		}
 		return nil
@@ -269,36 +571,42 @@ func (c *Crawler) processJob(job *job) {
 		return
 	}
 
-	capi, err := coreapi.NewCoreAPI(c.nodes[r].IPFSNode())
-	if err != nil {
-		log.Warningf("Error loading core API during crawl of %s: %s", job.Peer.Pretty(), err)
-		return
+	// If the peer is quarantined, or its data was evicted to free up space on
+	// its nodes, nothing is pinned. If any of the peer's content was blocked or
+	// quarantined the root can't be pinned recursively.
+	pins := make(map[cid.Cid]bool)
+	if c.pinFiles && !peerQuarantined && !evicted {
+		restricted := len(excluded) > 0 || len(quarantined) > 0
+		pins = peerPins(rootCID, partial, graph, quarantined, complete && !overQuota && !restricted, overQuota)
 	}
-
-	// Pin all new files.
-	if c.pinFiles {
-		ctx, cancel := context.WithTimeout(c.ctx, catTimeout)
-		defer cancel()
-
-		// If we already have all the files this should just return immediately.
-		// Otherwise missing files will be downloaded and pinned.
-		if err := capi.Pin().Add(ctx, path.IpfsPath(rootCID)); err != nil {
-			log.Errorf("Error recursively pinning rootCID %s for peer %s: %s", rootCID.String(), job.Peer.Pretty(), err)
-		}
+	if err := c.pins.setPeerPins(job.Peer, owners, pins); err != nil {
+		log.Errorf("Error pinning files for peer %s: %s", job.Peer.Pretty(), err)
 	}
+}
 
-	// If the old CID is not carrying forward, unpin.
-	for _, u := range toUnpin {
-		id, err := cid.Decode(u)
-		if err != nil {
-			log.Errorf("Error decoding CID for unpinning, peer %s: %s", job.Peer.Pretty(), err)
-			continue
-		}
-		err = c.unpinCID(id)
-		if err != nil {
-			log.Errorf("Error unpinning file %s for peer %s: %s", id, job.Peer.Pretty(), err)
+// peerPins returns the pins to hold for a peer, mapped to whether each is
+// pinned recursively. The root is only pinned recursively if its whole graph
+// was fetched within the quota and none of the peer's content is withheld.
+// Otherwise the root node itself and the profile and listing files are
+// pinned and, unless the peer is over quota, so is every other fetched node
+// which isn't quarantined. This keeps the pins within what was fetched.
+func peerPins(root cid.Cid, partial, graph []cid.Cid, quarantined map[cid.Cid]bool, recursive, overQuota bool) map[cid.Cid]bool {
+	pins := map[cid.Cid]bool{root: recursive}
+	if recursive {
+		return pins
+	}
+	for _, id := range partial {
+		pins[id] = true
+	}
+	if overQuota {
+		return pins
+	}
+	for _, id := range graph {
+		if _, ok := pins[id]; !ok && !quarantined[id] {
+			pins[id] = false
 		}
 	}
+	return pins
 }
 
 func fetchIPNSRecord(ctx context.Context, n *core.IpfsNode, pid peer.ID, ipnsQuorum int) (*ipnspb.IpnsEntry, error) {
@@ -393,31 +701,118 @@ func (c *Crawler) dagGet(ctx context.Context, n *core.IpfsNode, cid cid.Cid) (ip
 	return nd, nil
 }
 
-func (c *Crawler) fetchGraph(n *core.IpfsNode, id *cid.Cid) ([]cid.Cid, error) {
+// fetchGraph traverses the DAG under the given root and returns the CIDs
+// of all the nodes that were fetched along with their cumulative size. Up to
+// graphFanout nodes are fetched concurrently and each fetch is retried up to
+// graphRetries times. The traversal does not descend past graphMaxDepth and
+// stops once graphMaxBytes or the quota have been exceeded.
+//
+// If any node could not be fetched or the byte budget was exhausted an error
+// is returned along with the CIDs that were fetched. If the quota was the
+// limit that was hit errQuotaExceeded is returned.
+func (c *Crawler) fetchGraph(n *core.IpfsNode, id *cid.Cid, quota uint64) ([]cid.Cid, uint64, error) {
 	var (
-		ret []cid.Cid
-		m   = make(map[string]bool)
+		ret       []cid.Cid
+		visited   = map[cid.Cid]bool{*id: true}
+		level     = []cid.Cid{*id}
+		fetched   uint64
+		failed    int
+		firstErr  error
+		exhausted bool
+		mtx       sync.Mutex
 	)
-	m[id.String()] = true
-	for {
-		if len(m) == 0 {
-			break
-		}
-		for k := range m {
-			i, err := cid.Decode(k)
-			if err != nil {
-				return ret, err
-			}
-			ret = append(ret, i)
-			nd, err := c.dagGet(c.ctx, n, i)
-			if err != nil {
-				return ret, err
+	limit, limitErr := c.graphMaxBytes, fmt.Errorf("graph exceeds the maximum of %d bytes", c.graphMaxBytes)
+	if quota > 0 && (limit == 0 || quota <= limit) {
+		limit, limitErr = quota, errQuotaExceeded
+	}
+	fanout := int(c.graphFanout)
+	if fanout == 0 {
+		fanout = 1
+	}
+	for depth := uint(0); len(level) > 0 && !exhausted; depth++ {
+		var (
+			next []cid.Cid
+			sem  = make(chan struct{}, fanout)
+			wg   sync.WaitGroup
+		)
+		for _, k := range level {
+			mtx.Lock()
+			stop := exhausted
+			mtx.Unlock()
+			if stop {
+				break
 			}
-			delete(m, k)
-			for _, link := range nd.Links() {
-				m[link.Cid.String()] = true
+
+			select {
+			case sem <- struct{}{}:
+			case <-c.shutdown:
+				wg.Wait()
+				return ret, fetched, errors.New("crawler shutting down")
 			}
+			wg.Add(1)
+			go func(k cid.Cid, depth uint) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				nd, err := c.dagGetWithRetry(n, k)
+
+				mtx.Lock()
+				defer mtx.Unlock()
+
+				if err != nil {
+					failed++
+					if firstErr == nil {
+						firstErr = err
+					}
+					return
+				}
+				ret = append(ret, k)
+				fetched += uint64(len(nd.RawData()))
+				if limit > 0 && fetched > limit {
+					exhausted = true
+					return
+				}
+				if c.graphMaxDepth > 0 && depth >= c.graphMaxDepth {
+					return
+				}
+				for _, link := range nd.Links() {
+					if !visited[link.Cid] {
+						visited[link.Cid] = true
+						next = append(next, link.Cid)
+					}
+				}
+			}(k, depth)
+		}
+		wg.Wait()
+		level = next
+	}
+	if exhausted {
+		return ret, fetched, limitErr
+	}
+	if failed > 0 {
+		return ret, fetched, fmt.Errorf("failed to fetch %d nodes: %w", failed, firstErr)
+	}
+	return ret, fetched, nil
+}
+
+// dagGetWithRetry fetches the node, retrying up to graphRetries times
+// if the fetch fails.
+func (c *Crawler) dagGetWithRetry(n *core.IpfsNode, id cid.Cid) (ipld.Node, error) {
+	var (
+		nd  ipld.Node
+		err error
+	)
+	for i := 0; i <= int(c.graphRetries); i++ {
+		nd, err = c.dagGet(c.ctx, n, id)
+		if err == nil {
+			return nd, nil
+		}
+		select {
+		case <-c.shutdown:
+			return nil, err
+		default:
 		}
 	}
-	return ret, nil
+	return nil, err
 }
@@ -0,0 +1,217 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"github.com/cpacia/openbazaar3.0/core"
+	"github.com/cpacia/openbazaar3.0/core/coreiface"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-merkledag"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPeerPins(t *testing.T) {
+	ids := make([]cid.Cid, 5)
+	for i := range ids {
+		ids[i] = merkledag.NewRawNode([]byte{byte(i)}).Cid()
+	}
+	root, profile, image, held, listing := ids[0], ids[1], ids[2], ids[3], ids[4]
+	partial := []cid.Cid{profile, listing}
+	graph := []cid.Cid{root, image, held, profile, listing}
+	quarantined := map[cid.Cid]bool{held: true}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		overQuota bool
+		expected  map[cid.Cid]bool
+	}{
+		{
+			name:      "complete graph",
+			recursive: true,
+			expected:  map[cid.Cid]bool{root: true},
+		},
+		{
+			name:     "incomplete or withheld graph",
+			expected: map[cid.Cid]bool{root: false, profile: true, listing: true, image: false},
+		},
+		{
+			name:      "over quota",
+			overQuota: true,
+			expected:  map[cid.Cid]bool{root: false, profile: true, listing: true},
+		},
+	}
+	for _, test := range tests {
+		pins := peerPins(root, partial, graph, quarantined, test.recursive, test.overQuota)
+		if !reflect.DeepEqual(pins, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, pins)
+		}
+	}
+}
+
+func TestCrawler_FetchGraph(t *testing.T) {
+	mn, err := core.NewMocknet(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mn.TearDown()
+	n := mn.Nodes()[0].IPFSNode()
+
+	// A graph with a root, 3 children, 6 grandchildren and a leaf under
+	// each grandchild. Every node holds 1KB of data.
+	var (
+		all  []cid.Cid
+		size uint64
+	)
+	add := func(depth, i int, links ...*merkledag.ProtoNode) *merkledag.ProtoNode {
+		nd := merkledag.NodeWithData(append([]byte{byte(depth), byte(i)}, make([]byte, 1024)...))
+		for _, l := range links {
+			if err := nd.AddNodeLink(l.Cid().String(), l); err != nil {
+				t.Fatal(err)
+			}
+		}
+		all = append(all, nd.Cid())
+		size += uint64(len(nd.RawData()))
+		return nd
+	}
+	var children []*merkledag.ProtoNode
+	for i := 0; i < 3; i++ {
+		var grandchildren []*merkledag.ProtoNode
+		for j := 0; j < 2; j++ {
+			leaf := add(3, i*2+j)
+			if err := n.DAG.Add(context.Background(), leaf); err != nil {
+				t.Fatal(err)
+			}
+			grandchild := add(2, i*2+j, leaf)
+			if err := n.DAG.Add(context.Background(), grandchild); err != nil {
+				t.Fatal(err)
+			}
+			grandchildren = append(grandchildren, grandchild)
+		}
+		child := add(1, i, grandchildren...)
+		if err := n.DAG.Add(context.Background(), child); err != nil {
+			t.Fatal(err)
+		}
+		children = append(children, child)
+	}
+	root := add(0, 0, children...)
+	if err := n.DAG.Add(context.Background(), root); err != nil {
+		t.Fatal(err)
+	}
+	rootID := root.Cid()
+
+	tests := []struct {
+		name     string
+		fanout   uint
+		maxDepth uint
+		maxBytes uint64
+		quota    uint64
+		nodes    int
+		err      error
+	}{
+		{
+			name:   "whole graph",
+			fanout: 1,
+			nodes:  len(all),
+		},
+		{
+			name:   "concurrent fetches",
+			fanout: 4,
+			nodes:  len(all),
+		},
+		{
+			name:     "depth limit",
+			fanout:   4,
+			maxDepth: 2,
+			nodes:    10,
+		},
+		{
+			name:   "quota",
+			fanout: 4,
+			quota:  size / 2,
+			err:    errQuotaExceeded,
+		},
+		{
+			name:     "quota below byte budget",
+			fanout:   4,
+			maxBytes: size - 1,
+			quota:    size / 2,
+			err:      errQuotaExceeded,
+		},
+		{
+			name:     "byte budget",
+			fanout:   4,
+			maxBytes: size / 2,
+			quota:    size - 1,
+			err:      errors.New("graph exceeds the maximum"),
+		},
+	}
+	for _, test := range tests {
+		c := &Crawler{
+			ctx:           context.Background(),
+			shutdown:      make(chan struct{}),
+			graphFanout:   test.fanout,
+			graphMaxDepth: test.maxDepth,
+			graphMaxBytes: test.maxBytes,
+		}
+		ids, fetched, err := c.fetchGraph(n, &rootID, test.quota)
+		switch {
+		case test.err == nil:
+			if err != nil {
+				t.Errorf("%s: unexpected error %s", test.name, err)
+			}
+			if len(ids) != test.nodes {
+				t.Errorf("%s: expected %d nodes, got %d", test.name, test.nodes, len(ids))
+			}
+			if test.nodes == len(all) && fetched != size {
+				t.Errorf("%s: expected %d bytes, got %d", test.name, size, fetched)
+			}
+		case test.err == errQuotaExceeded:
+			if !errors.Is(err, errQuotaExceeded) {
+				t.Errorf("%s: expected quota exceeded, got %v", test.name, err)
+			}
+		default:
+			if err == nil || errors.Is(err, errQuotaExceeded) || !strings.Contains(err.Error(), test.err.Error()) {
+				t.Errorf("%s: expected error %q, got %v", test.name, test.err, err)
+			}
+		}
+		if test.err != nil {
+			if fetched <= test.quota && fetched <= test.maxBytes {
+				t.Errorf("%s: expected the limit to be exceeded, fetched %d bytes", test.name, fetched)
+			}
+			if len(ids) >= len(all) {
+				t.Errorf("%s: expected the traversal to stop early, got %d nodes", test.name, len(ids))
+			}
+		}
+	}
+
+	// A node missing from the network is retried and reported along with
+	// the nodes that were fetched.
+	missing := all[0]
+	if err := n.Blockstore.DeleteBlock(missing); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	c := &Crawler{
+		ctx:          ctx,
+		shutdown:     make(chan struct{}),
+		graphFanout:  4,
+		graphRetries: 2,
+	}
+	ids, _, err := c.fetchGraph(n, &rootID, 0)
+	if !errors.Is(err, coreiface.ErrNotFound) {
+		t.Errorf("Expected missing node error, got %v", err)
+	}
+	if len(ids) != len(all)-1 {
+		t.Errorf("Expected %d nodes fetched, got %d", len(all)-1, len(ids))
+	}
+	for _, id := range ids {
+		if id == missing {
+			t.Error("Expected missing node not to be returned")
+		}
+	}
+}
@@ -3,14 +3,18 @@ module github.com/cpacia/obcrawler
 go 1.14
 
 require (
+	github.com/btcsuite/btcd v0.21.0-beta
 	github.com/cpacia/openbazaar3.0 v0.0.0-20210726013347-9434c6896217
 	github.com/gcash/bchutil v0.0.0-20200228172631-5e1930e5d630
 	github.com/gogo/protobuf v1.3.2
 	github.com/golang/protobuf v1.5.2
 	github.com/gorilla/mux v1.7.3
 	github.com/improbable-eng/grpc-web v0.9.1
+	github.com/ipfs/go-blockservice v0.1.4
 	github.com/ipfs/go-cid v0.0.7
 	github.com/ipfs/go-ipfs v0.9.1
+	github.com/ipfs/go-ipfs-blockstore v0.1.6
+	github.com/ipfs/go-ipfs-exchange-offline v0.0.1
 	github.com/ipfs/go-ipfs-files v0.0.8
 	github.com/ipfs/go-ipld-format v0.2.0
 	github.com/ipfs/go-ipns v0.1.0
@@ -20,6 +24,7 @@ require (
 	github.com/jessevdk/go-flags v1.4.0
 	github.com/libp2p/go-libp2p-core v0.8.5
 	github.com/libp2p/go-libp2p-kad-dht v0.12.2
+	github.com/multiformats/go-multiaddr v0.3.3
 	github.com/natefinch/lumberjack v2.0.0+incompatible
 	github.com/op/go-logging v0.0.0-20160315200505-970db520ece7
 	google.golang.org/grpc v1.33.2
@@ -0,0 +1,137 @@
+// Package imagehash computes perceptual hashes of images. Unlike
+// cryptographic hashes, visually similar images have hashes which differ
+// in few bits so near-duplicates can be found even when the files differ.
+package imagehash
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"  // Register the GIF decoder.
+	_ "image/jpeg" // Register the JPEG decoder.
+	_ "image/png"  // Register the PNG decoder.
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// MaxPixels is the largest image Decode accepts. It protects against
+// images which are small to download but huge once decoded.
+const MaxPixels = 40 * 1000 * 1000
+
+// ErrTooLarge is returned by Decode for images larger than MaxPixels.
+var ErrTooLarge = errors.New("image too large")
+
+// Decode decodes a JPEG, PNG or GIF image.
+func Decode(b []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(b))
+	return img, err
+}
+
+// DHash returns the difference hash of the image. Each bit records
+// whether a pixel of the image shrunk to 9x8 is brighter than the
+// pixel to its right.
+func DHash(img image.Image) uint64 {
+	gray := grayscale(img, 9, 8)
+	var h uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			h <<= 1
+			if gray[y][x] > gray[y][x+1] {
+				h |= 1
+			}
+		}
+	}
+	return h
+}
+
+// PHash returns the perceptual hash of the image. Each bit records
+// whether one of the lowest frequencies of the discrete cosine transform
+// of the image shrunk to 32x32 is above their median.
+func PHash(img image.Image) uint64 {
+	const size, low = 32, 8
+	gray := grayscale(img, size, size)
+
+	// Only the lowest frequencies of the 2D DCT-II are needed.
+	var coeffs [low][low]float64
+	for u := 0; u < low; u++ {
+		for v := 0; v < low; v++ {
+			var sum float64
+			for y := 0; y < size; y++ {
+				for x := 0; x < size; x++ {
+					sum += gray[y][x] *
+						math.Cos(float64(2*x+1)*float64(u)*math.Pi/(2*size)) *
+						math.Cos(float64(2*y+1)*float64(v)*math.Pi/(2*size))
+				}
+			}
+			coeffs[v][u] = sum
+		}
+	}
+
+	// The DC term is excluded from the median as it only reflects the
+	// average brightness.
+	values := make([]float64, 0, low*low-1)
+	for v := 0; v < low; v++ {
+		for u := 0; u < low; u++ {
+			if u != 0 || v != 0 {
+				values = append(values, coeffs[v][u])
+			}
+		}
+	}
+	sort.Float64s(values)
+	median := values[len(values)/2]
+
+	var h uint64
+	for v := 0; v < low; v++ {
+		for u := 0; u < low; u++ {
+			h <<= 1
+			if coeffs[v][u] > median {
+				h |= 1
+			}
+		}
+	}
+	return h
+}
+
+// Distance returns the number of bits which differ between two hashes.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// grayscale shrinks the image to the given size by averaging the
+// luminance of the pixels covered by each cell.
+func grayscale(img image.Image, width, height int) [][]float64 {
+	bounds := img.Bounds()
+	sums := make([][]float64, height)
+	counts := make([][]float64, height)
+	for i := range sums {
+		sums[i] = make([]float64, width)
+		counts[i] = make([]float64, width)
+	}
+	w, h := bounds.Dx(), bounds.Dy()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		cy := (y - bounds.Min.Y) * height / h
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			cx := (x - bounds.Min.X) * width / w
+			g := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			sums[cy][cx] += float64(g.Y)
+			counts[cy][cx]++
+		}
+	}
+	for y := range sums {
+		for x := range sums[y] {
+			if counts[y][x] > 0 {
+				sums[y][x] /= counts[y][x]
+			}
+		}
+	}
+	return sums
+}
@@ -0,0 +1,94 @@
+package imagehash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// newImage draws a test pattern of the given size. The pattern is the
+// same at every size.
+func newImage(width, height int, invert bool) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// A gradient with a bright disc off center.
+			fx, fy := float64(x)/float64(width), float64(y)/float64(height)
+			v := uint8(200 * fx * fy)
+			if dx, dy := fx-0.3, fy-0.35; dx*dx+dy*dy < 0.04 {
+				v = 250
+			}
+			if invert {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{R: v, G: v / 2, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func TestHashes(t *testing.T) {
+	original := newImage(320, 240, false)
+	scaled := newImage(160, 120, false)
+	other := newImage(320, 240, true)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, original, &jpeg.Options{Quality: 50}); err != nil {
+		t.Fatal(err)
+	}
+	recompressed, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, hash := range map[string]func(image.Image) uint64{"dHash": DHash, "pHash": PHash} {
+		h := hash(original)
+		if d := Distance(h, hash(scaled)); d > 4 {
+			t.Errorf("%s: expected scaled image to be similar, distance %d", name, d)
+		}
+		if d := Distance(h, hash(recompressed)); d > 4 {
+			t.Errorf("%s: expected recompressed image to be similar, distance %d", name, d)
+		}
+		if d := Distance(h, hash(other)); d < 16 {
+			t.Errorf("%s: expected different image to differ, distance %d", name, d)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, newImage(16, 16, false)); err != nil {
+		t.Fatal(err)
+	}
+	img, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 16 {
+		t.Errorf("Expected width 16, got %d", img.Bounds().Dx())
+	}
+	if _, err := Decode([]byte("not an image")); err == nil {
+		t.Error("Expected error decoding invalid image")
+	}
+
+	// The header of a huge image is rejected before decoding.
+	buf.Reset()
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 8000, 8000))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decode(buf.Bytes()); err != ErrTooLarge {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	if d := Distance(0, ^uint64(0)); d != 64 {
+		t.Errorf("Expected distance 64, got %d", d)
+	}
+	if d := Distance(0xf0, 0x0f); d != 8 {
+		t.Errorf("Expected distance 8, got %d", d)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"github.com/cpacia/obcrawler/crawler"
 	"github.com/cpacia/obcrawler/repo"
 	"github.com/op/go-logging"
@@ -16,11 +17,27 @@ func main() {
 		log.Fatal(err)
 	}
 
+	switch cfg.Args.Command {
+	case "":
+	case "reconcile":
+		// Reconcile is a one-shot maintenance command. It doesn't serve
+		// requests so it must not bind the daemon's gRPC and resolver ports.
+		cfg.GrpcListeners = nil
+		cfg.ResolverListeners = nil
+	default:
+		log.Fatalf("Unknown command: %s", cfg.Args.Command)
+	}
+
 	crawler, err := crawler.NewCrawler(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if cfg.Args.Command == "reconcile" {
+		reconcile(crawler)
+		return
+	}
+
 	if err := crawler.Start(); err != nil {
 		log.Fatal(err)
 	}
@@ -41,3 +58,36 @@ func main() {
 		os.Exit(0)
 	}
 }
+
+// reconcile repairs the pin sets of the crawler's IPFS nodes, prints
+// the differences that were found and exits. The nodes are brought
+// online first so that missing pins can be fetched from the network.
+func reconcile(c *crawler.Crawler) {
+	c.StartNodes()
+
+	report, err := c.Reconcile()
+	if err != nil {
+		c.Stop()
+		log.Fatal(err)
+	}
+
+	printDiffs := func(title string, diffs []crawler.PinDiff) {
+		fmt.Printf("%s: %d\n", title, len(diffs))
+		for _, d := range diffs {
+			if d.PeerID != "" {
+				fmt.Printf("  %s (peer %s)\n", d.CID, d.PeerID)
+			} else {
+				fmt.Printf("  %s (node %d)\n", d.CID, d.Node)
+			}
+		}
+	}
+	printDiffs("Missing pins re-pinned", report.Missing)
+	printDiffs("Orphaned pins removed", report.Orphaned)
+	printDiffs("Duplicate pins removed", report.Duplicate)
+	printDiffs("Stale references released", report.Stale)
+	printDiffs("Untracked pins adopted", report.Adopted)
+
+	if err := c.Stop(); err != nil {
+		log.Fatal(err)
+	}
+}
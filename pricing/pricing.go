@@ -0,0 +1,182 @@
+// Package pricing extracts the prices of listings and normalizes them to a
+// single currency using exchange rates from a pluggable provider.
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateProvider is a source of exchange rates. The rates map a currency
+// code to the value of one unit of that currency in the base currency.
+type RateProvider interface {
+	Rates() (map[string]float64, error)
+}
+
+// FileProvider loads exchange rates from a local JSON file. The file holds
+// an object mapping currency codes to the value of one unit of each in the
+// base currency. For example, with a base of USD:
+//
+//	{"BTC": 35000, "EUR": 1.18}
+type FileProvider struct {
+	Path string
+}
+
+// Rates reads the exchange rates from the file.
+func (p *FileProvider) Rates() (map[string]float64, error) {
+	b, err := ioutil.ReadFile(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	return parseRates(b)
+}
+
+// HTTPProvider fetches exchange rates from a URL serving JSON in the same
+// format as the FileProvider.
+type HTTPProvider struct {
+	URL    string
+	Client *http.Client
+}
+
+// Rates fetches the exchange rates from the URL.
+func (p *HTTPProvider) Rates() (map[string]float64, error) {
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: time.Minute}
+	}
+	resp, err := client.Get(p.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate request returned status %d", resp.StatusCode)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseRates(b)
+}
+
+func parseRates(b []byte) (map[string]float64, error) {
+	var raw map[string]float64
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	rates := make(map[string]float64, len(raw))
+	for code, rate := range raw {
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s", code)
+		}
+		rates[strings.ToUpper(code)] = rate
+	}
+	return rates, nil
+}
+
+// Normalizer converts prices to the base currency using the rates last
+// loaded from its provider. Prices in the base currency are always
+// converted even without a provider.
+type Normalizer struct {
+	base     string
+	provider RateProvider
+
+	mtx   sync.RWMutex
+	rates map[string]float64
+}
+
+// NewNormalizer returns a Normalizer for the base currency. The provider
+// may be nil.
+func NewNormalizer(base string, provider RateProvider) *Normalizer {
+	base = strings.ToUpper(base)
+	return &Normalizer{
+		base:     base,
+		provider: provider,
+		rates:    map[string]float64{base: 1},
+	}
+}
+
+// Base returns the currency prices are normalized to.
+func (n *Normalizer) Base() string {
+	return n.base
+}
+
+// Refresh loads the latest rates from the provider.
+func (n *Normalizer) Refresh() error {
+	if n.provider == nil {
+		return nil
+	}
+	rates, err := n.provider.Rates()
+	if err != nil {
+		return err
+	}
+	rates[n.base] = 1
+
+	n.mtx.Lock()
+	n.rates = rates
+	n.mtx.Unlock()
+	return nil
+}
+
+// Rates returns a copy of the current rates.
+func (n *Normalizer) Rates() map[string]float64 {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+
+	rates := make(map[string]float64, len(n.rates))
+	for code, rate := range n.rates {
+		rates[code] = rate
+	}
+	return rates
+}
+
+// Normalize converts the amount in the currency to the base currency. It
+// returns false if there is no rate for the currency.
+func (n *Normalizer) Normalize(amount float64, currency string) (float64, bool) {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+
+	rate, ok := n.rates[strings.ToUpper(currency)]
+	if !ok {
+		return 0, false
+	}
+	return amount * rate, true
+}
+
+// ListingPrice returns the price of the listing in whole units of its
+// pricing currency along with the currency code. Cryptocurrency listings
+// are priced at the market rate so they have no price.
+func ListingPrice(l *obpb.Listing) (float64, string, error) {
+	if l.GetMetadata().GetContractType() == obpb.Listing_Metadata_CRYPTOCURRENCY {
+		return 0, "", errors.New("cryptocurrency listings have no fixed price")
+	}
+	currency := l.GetMetadata().GetPricingCurrency()
+	if currency.GetCode() == "" {
+		return 0, "", errors.New("listing has no pricing currency")
+	}
+	price, err := Amount(l.GetItem().GetPrice(), uint(currency.Divisibility))
+	if err != nil {
+		return 0, "", err
+	}
+	return price, strings.ToUpper(currency.Code), nil
+}
+
+// Amount converts an amount in the smallest unit of a currency with the
+// given divisibility to whole units.
+func Amount(amount string, divisibility uint) (float64, error) {
+	a, ok := new(big.Int).SetString(amount, 10)
+	if !ok || a.Sign() < 0 {
+		return 0, errors.New("invalid amount")
+	}
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(divisibility)), nil)
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(a), new(big.Float).SetInt(divisor)).Float64()
+	return f, nil
+}
@@ -0,0 +1,107 @@
+package pricing
+
+import (
+	obpb "github.com/cpacia/openbazaar3.0/orders/pb"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestListingPrice(t *testing.T) {
+	tests := []struct {
+		listing  *obpb.Listing
+		price    float64
+		currency string
+		valid    bool
+	}{
+		{
+			listing: &obpb.Listing{
+				Metadata: &obpb.Listing_Metadata{PricingCurrency: &obpb.Currency{Code: "usd", Divisibility: 2}},
+				Item:     &obpb.Listing_Item{Price: "1250"},
+			},
+			price:    12.5,
+			currency: "USD",
+			valid:    true,
+		},
+		{
+			listing: &obpb.Listing{
+				Metadata: &obpb.Listing_Metadata{PricingCurrency: &obpb.Currency{Code: "BTC", Divisibility: 8}},
+				Item:     &obpb.Listing_Item{Price: "50000000"},
+			},
+			price:    0.5,
+			currency: "BTC",
+			valid:    true,
+		},
+		{
+			listing: &obpb.Listing{
+				Metadata: &obpb.Listing_Metadata{PricingCurrency: &obpb.Currency{Code: "USD", Divisibility: 2}},
+				Item:     &obpb.Listing_Item{Price: "abc"},
+			},
+		},
+		{
+			listing: &obpb.Listing{
+				Metadata: &obpb.Listing_Metadata{ContractType: obpb.Listing_Metadata_CRYPTOCURRENCY},
+				Item:     &obpb.Listing_Item{CryptoListingCurrencyCode: "ETH"},
+			},
+		},
+		{
+			listing: &obpb.Listing{},
+		},
+	}
+
+	for i, test := range tests {
+		price, currency, err := ListingPrice(test.listing)
+		if (err == nil) != test.valid {
+			t.Errorf("Test %d: expected valid %t, got error %v", i, test.valid, err)
+			continue
+		}
+		if price != test.price || currency != test.currency {
+			t.Errorf("Test %d: expected %f %s, got %f %s", i, test.price, test.currency, price, currency)
+		}
+	}
+}
+
+func TestNormalizer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pricing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ratesPath := path.Join(dir, "rates.json")
+	if err := ioutil.WriteFile(ratesPath, []byte(`{"btc": 40000, "EUR": 1.2}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	n := NewNormalizer("usd", &FileProvider{Path: ratesPath})
+	if _, ok := n.Normalize(1, "BTC"); ok {
+		t.Error("Expected no rate before refresh")
+	}
+	if price, ok := n.Normalize(10, "USD"); !ok || price != 10 {
+		t.Error("Expected base currency to always normalize")
+	}
+
+	if err := n.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if price, ok := n.Normalize(0.5, "BTC"); !ok || price != 20000 {
+		t.Errorf("Expected 20000, got %f", price)
+	}
+	if price, ok := n.Normalize(10, "eur"); !ok || price != 12 {
+		t.Errorf("Expected 12, got %f", price)
+	}
+	if _, ok := n.Normalize(1, "JPY"); ok {
+		t.Error("Expected no rate for JPY")
+	}
+
+	if err := ioutil.WriteFile(ratesPath, []byte(`{"BTC": -1}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Refresh(); err == nil {
+		t.Error("Expected invalid rate to be rejected")
+	}
+	if _, ok := n.Normalize(1, "BTC"); !ok {
+		t.Error("Expected previous rates to be kept after a failed refresh")
+	}
+}
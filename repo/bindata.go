@@ -68,7 +68,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _sampleObcrawlerConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x56\x5d\x73\xdb\xba\x11\x7d\xd7\xaf\xd8\x87\x64\xa6\x9d\x51\x24\xf9\x23\xcd\xc4\xaa\x3a\xa3\xd8\x49\xaa\xd4\x8d\x35\x96\xf3\xd1\xbc\x2d\x81\x25\x89\x0a\x04\x60\x00\x94\xcc\x76\x6e\x7e\xfb\x9d\x05\x48\x5b\x4e\x7c\xef\x64\xf4\x20\x92\xd8\x3d\xd8\x3d\x7b\xb0\x8b\x39\xdc\xd4\x04\x52\x79\x12\xd1\xfa\x0e\xa2\x85\x10\xad\x27\x90\x18\x11\x42\x2b\x6a\xc0\x00\xb1\x26\xb0\x85\xf0\xb8\xd7\xe4\xd3\x52\x81\x81\xc6\xa0\x5c\x19\xa0\xa1\x88\xfc\x69\x0c\x68\xe4\x68\x0e\xae\x2d\xb4\x12\xc9\x6a\x32\xea\xf1\xa9\xc4\x56\x47\x50\x01\xbe\x4f\x27\x0f\x48\xd6\xc0\xfa\x6a\xb3\xfa\x0a\x57\x1b\x0a\x63\x78\x76\x79\x75\xbe\xbc\x5c\xae\xd7\x17\xcb\x9b\xe5\xf4\xea\xd0\xec\x8b\x32\xd2\xee\xc3\x78\x34\x87\xef\xd3\x4b\x55\x78\xf4\xdd\x74\xe9\x9c\x56\x02\xa3\xb2\x06\x36\xad\x73\xd6\xc7\xc7\x5e\xff\x46\x01\x57\x9b\x14\x18\x3c\xab\x6d\x43\x8f\x96\x47\x73\x58\x6b\x34\xaf\x27\x00\x6f\xcd\x4e\x79\x6b\x1a\x32\x11\x76\xe8\x15\x16\x9a\x02\xa0\x27\xa0\x3b\x87\x46\x92\x84\x60\x99\x86\x0e\x1a\xec\xa0\x20\x68\x03\xc9\x09\xc0\xc7\xab\x9b\xb7\x67\x43\x74\xa3\x39\xd0\x1f\x02\xc5\xce\x29\x81\x5a\x77\xf0\xfc\xf3\xf2\x7a\xb5\x7c\x73\xf9\xf6\xf9\x18\x8a\x36\xf6\xb0\x6d\x88\x8c\x8b\x42\x50\x08\x24\x61\xaf\x62\x3d\x9a\xc3\xb3\xc1\x18\x6a\xf2\x34\x01\x58\xea\x60\xc7\xf0\x9d\xb9\xbc\x8f\x2d\xda\xc7\xdc\x1d\x30\xc6\x25\xe0\x52\x48\xe5\x17\x87\xdc\x8f\x46\x73\xd8\x50\xda\x1c\x4c\xdb\x14\xcc\x48\x09\xab\xf5\xbb\x0d\x18\x2b\x29\x30\x66\x1b\x68\xc2\xf5\x0b\x04\x7b\xa5\x35\x87\x17\x5c\x6b\xa0\x75\xa0\x4c\x50\x92\x92\x77\x50\xa6\xd2\x04\x03\xaf\xca\x84\x88\x46\x10\x6f\x9c\x90\x16\x47\xb3\xa7\x37\xdb\x5b\xbf\x25\x3f\xec\xc4\x7f\x09\x23\x7b\xb1\x7b\x6f\xb0\x38\x3a\x1e\x25\x21\xa9\xc0\x59\x3f\x06\x39\x0c\x96\xff\xb4\x0a\x91\x0c\x13\x50\x5a\xcf\x5a\x0c\x6d\x91\x25\x19\xea\x8c\x9a\xbf\xe5\xd0\x4e\x18\xf8\x13\x7b\x52\x88\x86\x22\xaf\xf7\x8f\x8b\xa3\xb4\x66\xd4\x8e\x7c\x40\x0d\x6b\xdd\x56\x49\x48\x6b\x8d\x1d\xfc\xe5\xd3\xda\xac\xff\x0a\xd8\x46\xdb\x60\xec\x0b\x6b\x1d\x99\x1c\x5f\x1f\x05\x2b\x12\x6c\x11\x51\x19\x16\x07\xaf\xd0\x5d\x24\x6f\x50\xc3\x6a\x0d\x28\xa5\xa7\x10\xa0\xf4\xb6\x81\x90\x05\x4c\x12\x24\xed\x94\xa0\x30\xc9\x19\x5b\x97\xf4\x2d\x55\xc8\x5a\x52\x31\x33\xdb\x3a\xe3\x72\x8c\xe7\x89\x35\x65\x22\xf9\x1d\x6a\x08\x8e\x84\x2a\x15\x05\xa8\xed\x1e\xb4\x35\x15\xf3\xb2\x47\xc5\xfa\x2a\xd3\xd9\xb6\xca\x54\x80\x70\xf1\xcf\x9b\x9e\x72\xe6\x0a\xc1\xa3\x91\xb6\x01\x47\xe4\x57\x17\x1c\xaf\x85\x40\xe8\x45\x0d\xb6\x8d\x60\x68\xdf\x2f\x25\x1a\x93\xe3\xb0\xe9\xe2\x65\xc3\x91\xbc\xb1\x36\x86\x88\x6e\xc8\xac\x97\x3e\x9f\x15\x06\xfb\xaf\x55\x26\x97\x8f\x22\xd7\x76\x02\x57\x06\x42\x44\xdf\x2b\xc3\xca\x5e\x68\x0d\x6e\x69\x34\xe7\x5d\xab\x14\xaa\xb0\xc6\x90\x60\x1e\x52\xa9\xd9\xb8\x48\x5b\x79\x74\x29\xa6\x90\x2a\x93\x24\x50\x53\xc3\x36\x52\x05\x61\x77\xac\x91\x58\x93\xe7\xb2\x27\xb3\x1f\x02\x18\xcd\x1f\x80\x38\xe6\xc5\x54\xb9\xd3\xe9\xdd\x24\xfd\xa6\x51\xb8\xe9\xe9\x6c\x76\x34\x75\xc7\x6e\x7a\x74\x7c\x71\xf2\x2f\x6b\xbf\xac\xbf\x9d\xdc\xbd\xf9\x78\xfd\xfe\xee\xb4\xac\xaf\x8b\xf2\x3f\x4b\xf1\xf5\x53\x2d\xbe\xd5\x37\xdf\x8e\x2f\xcf\xb7\x1f\x5e\x9d\x6e\x3f\x7c\x7d\x5f\xfe\xef\xf5\xcd\xe7\xcb\x9b\x51\xdf\xff\x1e\xe4\xea\x29\x38\x6b\x42\x96\x6c\xaa\x09\x53\xbf\xaf\xc9\x70\xd2\x9c\xeb\x6a\xfd\x71\x03\xb7\x2d\x79\x75\x2f\x01\x15\x00\x21\x7a\x94\x64\xcb\x92\x43\xa6\xb8\x27\xca\x99\xa0\x10\xad\x47\xd1\x31\x38\xbf\xb3\x67\x97\xd8\x48\x67\xd3\x11\x49\xce\x52\x39\x13\x6e\x5b\xeb\xdb\x66\x71\xca\x51\x2d\x9d\x23\x23\x01\x41\xd8\x26\x35\xab\x9e\xd6\x36\x90\x07\xac\xf8\x4b\x4f\xd5\x41\x3b\x7f\x98\x13\x0c\xd9\x62\xef\xbb\xe8\xff\x19\xf7\x82\x8a\xb6\x02\x6d\xab\x8a\x73\xd1\xb4\x23\xcd\xb6\x9f\x51\x2b\x99\x5f\xb3\x24\xfe\x2f\xd9\x70\x0c\xca\x94\x76\x0c\xc6\x46\x25\x68\x0c\x7b\xf4\x46\x99\x6a\x0c\xe4\xbd\xf5\x63\x10\x5e\xa5\xb3\xf5\xdb\x68\xce\x98\xc9\x7f\xc1\x2e\x03\xb1\x4f\x0c\x2e\x6d\x2b\x28\x95\xa6\x90\x7d\x7e\xec\x7b\x53\x6d\xab\xf0\x63\x3b\x91\x05\xb7\x67\x9a\xc0\x2a\x82\x40\x03\xa4\x58\x34\xa9\xdf\xdd\x6a\x15\xe9\x64\x0c\x4d\x17\x6e\xf5\x18\xb8\xa7\xd8\x10\x2b\x56\x77\x6a\xad\x85\x54\xa8\x49\xc4\x45\x32\x18\xe2\xaa\x6d\x88\x03\x38\x3f\x9f\xa5\x4e\xc0\xa5\xe6\x2f\xc9\x14\xfa\x97\x7b\x38\x08\xe4\x77\xe4\x33\x2a\x3b\x2d\x8e\x8e\x5f\x4d\x66\x93\xd9\xe4\xe8\xec\xe4\x64\xf6\xb7\x01\x9b\x4b\x64\xb0\xa1\x9f\xe1\x1e\xa0\x64\x91\x61\xd8\x76\x31\x38\x0c\x00\x0e\x43\xd8\x5b\x2f\x7f\x05\x80\x6d\x17\x83\x43\x3a\xe2\xdd\xfd\x34\x67\xd7\xa1\xeb\xa7\x63\x2b\xed\xde\x68\x8b\x32\xc9\x4f\xa0\xa8\x09\x54\x83\x15\xe5\xd3\xe9\x31\x2a\x53\x05\xa0\x5d\x92\xae\x6d\xab\x3a\x8f\x3e\xd6\x83\xb1\x2c\x38\x49\x77\x24\x01\xb9\x74\x98\xe8\x50\x82\x06\x65\xf6\x47\x36\x4b\xdb\x02\x99\xd0\x0e\xd7\x14\xdc\xa1\xd2\x58\x28\xad\x62\x37\xc9\xed\xbc\x66\x7a\xb4\xb6\x7b\x95\xdb\x5f\xdf\x3e\x21\x72\xd5\xcb\xd6\xa4\x66\x82\xc9\x81\xf3\xcc\xab\x0c\xc6\x61\x2b\x53\xe5\xce\x7a\x90\x2c\x6a\x9d\x65\x75\x9f\x65\x9a\xd1\x79\x2a\x3a\x65\x0c\xe5\xb4\x39\x91\x0a\x7d\x81\x15\x81\xb0\x9a\xa5\xc1\x17\x85\x3f\x0b\x2a\x4d\x86\x27\xc2\x92\xe9\xf6\xc0\x9b\x32\xfe\x7d\x50\x9b\xd4\xdf\xbb\x04\x57\x5d\xaf\xcf\x73\xe7\x2f\x51\x50\x0a\x20\x29\xed\xd1\x20\x54\x25\x74\xb6\x85\x3d\xe6\x63\xde\x77\xc9\xec\xbb\x5c\xaf\x98\x80\xca\x3b\x91\x1d\x16\xb3\x24\xb9\xd9\xd9\xcb\xd9\x2c\x6d\xb7\x34\x3c\xe5\x6a\x32\x71\xb8\x6a\x45\xbb\x25\x73\x2f\x9e\x01\x86\xa1\x0f\x0c\x09\x84\x56\x64\x62\x18\xe0\x79\x2d\x79\x2e\xfe\x6e\xf9\xf9\xf8\x45\x7a\xfb\x07\xef\xf1\x4e\xf1\x05\xc2\x1a\x1e\x94\x89\x1b\x56\x16\xf9\xa8\xca\x0c\xc5\x1c\x8c\xe6\xe0\x9d\xe0\xaf\x8f\xcf\xb3\x77\x62\xc2\x5f\x7f\x05\x67\x4b\x5d\x86\xd9\x52\xf7\x33\x0a\xaf\x0e\x8d\xa1\x9f\xba\xa1\xb6\xad\x96\xc3\x7d\x2f\x35\x99\x03\xf6\x9f\x9a\xe5\xaa\x84\x36\x0c\x7b\xf3\xf5\xe0\x45\x45\x86\x3c\xf2\x60\xdf\x6c\x2e\x0f\xc3\x61\x66\x56\x65\xae\x7d\xbf\x9f\x0a\x49\x40\x69\xb3\x34\x14\x86\x0c\x78\xe5\x01\x48\xc5\x2c\x3d\xad\xb6\xa4\xd3\x75\x34\x7a\x4a\x2b\xc8\x23\x6e\xc7\xdd\x96\xd1\x87\x00\x95\x0b\x0f\xcd\x64\xf4\x7b\x00\x00\x00\xff\xff\x56\xb5\x6e\xbb\xf4\x0b\x00\x00")
+var _sampleObcrawlerConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x5a\x5b\x8f\x1b\x37\x96\x7e\xd7\xaf\x38\x30\x32\xc8\x0c\x20\xab\xd5\x6d\xc7\x76\xac\xd5\x02\xed\xcb\x24\x9d\xf5\xc4\xbd\xee\x76\x92\xcd\x62\x1f\xa8\xaa\x53\x2a\xa6\x59\x64\x99\x64\x49\xad\x04\xc9\x6f\x5f\x7c\x87\xac\x8b\x64\x7b\x2e\x18\xf8\xc1\x2d\x16\x79\x78\x2e\xdf\xb9\x56\xad\xe8\xb6\x66\x2a\xb5\xe7\x22\x3a\x7f\xa0\xe8\x28\x44\xe7\x99\x4a\x15\x15\x85\xae\xa8\x49\x05\x8a\x35\x93\xdb\x14\x5e\xed\x0d\x7b\x79\xb4\x51\x81\xe7\xa4\xdb\x2a\x50\xc3\x51\x61\x69\x4e\xca\x96\xb3\x15\xb5\xdd\xc6\xe8\x42\x76\x2d\x66\x99\x3e\x57\xaa\x33\x91\x74\xa0\x3f\xce\x16\x23\x25\x67\xe9\xfa\xed\xcd\xd5\x4f\xf4\xf6\x86\xc3\x9c\xbe\x78\xf3\xf6\xe5\xe5\x9b\xcb\xeb\xeb\x57\x97\xb7\x97\x67\x6f\xa7\xdb\x7e\xd4\xb6\x74\xfb\x30\x9f\xad\xe8\x8f\xb3\x37\x7a\xe3\x95\x3f\x9c\x5d\xb6\xad\xd1\x85\x8a\xda\x59\xba\xe9\xda\xd6\xf9\x78\x7c\xea\x6f\xaa\xa0\xb7\x37\xc2\x18\x7d\x51\xbb\x86\x8f\x1e\xcf\x56\x74\x6d\x94\xfd\x7a\x41\xf4\xda\xee\xb4\x77\xb6\x61\x1b\x69\xa7\xbc\x56\x1b\xc3\x81\x94\x67\xe2\xfb\x56\xd9\x92\x4b\x0a\x0e\x6a\x38\x50\xa3\x0e\xb4\x61\xea\x02\x97\x0b\xa2\xef\xdf\xde\xbe\x7e\xde\x73\x37\x5b\x11\x7f\x96\x50\x3c\xb4\xba\x50\xc6\x1c\xe8\x4f\x3f\x5c\xbe\xbb\xba\x7c\xf1\xe6\xf5\x9f\xe6\xb4\xe9\x62\x26\xdb\x85\x08\xba\xaa\x28\x38\x04\x2e\x69\xaf\x63\x3d\x5b\xd1\x17\xfd\x66\xaa\xd9\xf3\x82\xe8\xd2\x04\x37\xa7\x3f\xa0\xcb\x81\xb7\xe8\x8e\x75\x37\xd1\x18\x4c\x00\x53\x94\xda\xaf\xa7\xba\x9f\xcd\x56\x74\xc3\x72\x39\xd9\xae\xd9\x40\x23\x15\x5d\x5d\xff\xf5\x86\xac\x2b\x39\x00\x09\x5d\xe0\x05\xec\x17\x98\xf6\xda\x18\xb0\x17\xda\xce\x52\xd7\x92\xb6\x41\x97\x2c\xa7\x83\xb6\x5b\xc3\xd4\xeb\x55\xdb\x10\x95\x2d\x18\x17\x0b\xa5\xf5\xf9\xf2\xd3\x97\xed\x9d\xbf\x63\xdf\xdf\x84\xff\x84\x46\xba\x1f\xc7\xf3\x86\xf5\xf9\x05\x08\xdc\xd6\x3a\x40\xea\x63\x22\x53\x66\x41\xc2\xe8\x10\xd9\x42\x01\x95\xf3\xc0\x62\xe8\x36\xf8\xcf\xe8\x50\x27\xaa\x69\x4d\xce\xad\x1f\xcd\x32\x42\xf3\xc6\xe8\x5a\x5d\xa4\x2b\xf2\x8a\xec\x4b\xe2\x1f\x93\xbe\xba\xfe\xfe\x86\x3c\x17\xce\x97\x61\x41\x2f\x0e\x03\xc8\x63\xad\xc3\x6c\x05\x4e\xcf\x74\x6b\xc3\x99\x32\x66\x41\xef\xc1\x1d\x04\x70\xad\xc0\xb5\x81\x8f\xc5\x5a\x81\xd3\xe2\x84\xf1\xc0\x3b\xf6\xca\x64\x66\x46\x96\xe5\xf7\x7a\x20\x0a\xd6\x8f\xae\x1d\x6d\x20\xec\x2a\x13\x1c\xfd\xe2\xb4\x95\x47\xc2\xee\x54\x4a\x11\x82\x55\x51\xd3\x9d\x75\x7b\x4b\x2d\xb3\x4f\x20\x57\x71\xb6\xca\x92\x51\xd7\x96\x2a\x0a\x82\xbd\xde\x31\x55\x2a\x44\xf6\x89\x71\xcf\x0f\xe5\xbe\xd0\x4b\xc7\x54\x39\x63\xdc\x5e\xdb\x2d\x04\x2a\x75\x80\x1b\x25\xb1\xab\xce\x16\x10\x5c\x19\x1d\x0f\x10\x29\x3f\xc5\xad\x22\x57\x58\x9f\xf7\xb6\x68\xd4\xbd\x6e\xba\x66\x62\xe4\x96\xfd\x43\xec\xfc\x58\x0a\x31\x3d\x84\x5c\xd0\x5b\x5b\x30\x79\x48\x04\x8f\xa8\x39\x8b\xe9\x2a\xf9\x21\xc7\x0d\xab\x90\x85\x63\x1b\xcd\x81\x6a\x56\xbe\xa4\xca\xbb\x06\xf6\x32\x5c\xc5\x9e\x1e\x29\xb2\xbc\x27\x67\x05\xc8\x8d\xba\x9f\x70\xfa\xd5\x72\x29\x90\xbe\x66\xaf\x5d\x99\xbd\xda\x73\x46\x99\x5c\x17\xa2\x36\xe6\xe1\x4e\x19\x5d\x1e\x21\x05\x90\x1d\x6e\x0f\xcc\x49\xef\x22\x45\x32\x0e\x1c\x4e\x07\xba\x63\x6e\x81\x22\x09\xc6\x81\x4a\x1d\x0a\x07\x54\x40\xa1\xfb\x5a\x8b\x5a\x59\x7b\x72\x7b\x8b\xe3\x88\x54\xae\xaa\x8c\xb6\xbc\xa0\xcb\xde\x78\x80\x9b\x9d\xb2\xc6\x65\x82\x9b\x75\x10\x8e\xfd\x68\x67\x80\x61\x50\x53\xa1\x2c\x7c\xbd\x72\x9d\x2d\x29\xe3\xe7\xd5\xb7\xb7\x0b\x7a\x97\x85\xc0\x75\x53\x92\x23\xd2\x25\xd4\x3a\x4b\x30\x03\x80\xcb\xec\xbf\x0c\xe0\x32\x69\x41\x14\x08\x85\x62\xff\xc0\xd6\xc4\xf4\xda\x8a\xe9\x55\xe3\x3a\x1b\xa1\xac\xa8\x1b\x58\x92\xf6\x4a\x23\x3e\xc6\x3d\x74\x36\x0a\x14\xb0\x47\xf5\x31\x28\x5f\x37\x51\x38\xee\x1a\x76\x6b\x1b\xd9\xef\x94\x59\x3f\xae\x3f\x8f\xb5\x23\x6b\x45\x37\x9e\xa6\x96\x3d\x35\xda\x76\x91\x8f\xa8\x7a\x15\x79\xfd\x48\x00\x21\x7e\xc0\x21\x5a\x8e\xd8\x92\xff\x4c\xe2\xbd\xb7\x7a\xc7\x3e\x28\x43\xd7\xa6\xdb\x8a\x9e\xae\x8d\x3a\xd0\x9f\xdf\x5f\xdb\xeb\xbf\x90\xea\xa2\x6b\x54\xcc\x60\x72\x2d\xdb\x14\x86\x72\x58\x40\x6e\x23\xb7\x89\x4a\x5b\xc0\x02\x4f\xf8\x3e\xb2\xb7\xca\xd0\xd5\x35\xa9\xb2\xf4\x1c\x42\x42\x72\x48\xa9\x90\x4b\x2a\x79\xa7\x0b\x0e\x19\x53\x39\xf4\x64\xcf\x0b\xa4\x85\x49\xeb\xba\xd6\xb6\x89\xc7\x97\xf0\x67\xea\xd5\x44\xa1\xe5\x42\x57\x9a\x03\xd5\x6e\x4f\xc6\xd9\xed\xc4\x12\x15\x22\x58\xe9\xe0\xec\x8a\x5e\x7d\x7b\x9b\x83\x37\x80\xa4\xc8\x2b\x5b\xba\x46\xcc\x7f\xf5\x0a\xfc\x3a\x0a\xac\x7c\x51\x93\xeb\x22\xb0\x97\x1f\x49\x40\x96\x83\x83\x6d\xbe\x6a\xc0\xc9\x0b\xe7\x62\x88\xaa\xed\x25\xcb\x49\x14\x59\x77\xf0\x50\x28\xc1\x72\x44\x96\x80\xf7\x53\x88\xca\xe7\x1c\xe3\xca\x9c\xb2\x1a\x75\xc7\xb3\x15\x6e\xdd\x0a\xab\x85\xb3\x96\x25\x12\x89\xcf\x61\xf3\x46\xae\xf2\xaa\xcd\xae\x08\xcb\x74\x30\x64\xcd\x4d\x8e\x63\xe2\x7b\xe4\x62\x2d\x2e\x93\xb6\x9d\x30\x30\x5b\x8d\x84\xc0\x33\xe2\xf4\xe3\xb3\xfb\x85\xfc\x3b\x8b\x45\x7b\xf6\x78\xb9\x3c\x3f\x6b\x2f\xda\xb3\xf3\x8b\x57\x8f\xfe\xcb\xb9\x1f\xaf\x7f\x7e\x74\xff\xe2\xfb\x77\xdf\xdc\x3f\xae\xea\x77\x9b\xea\x7f\x2e\x8b\x9f\xde\xd7\xc5\xcf\xf5\xed\xcf\x17\x6f\x5e\xde\x7d\xf7\xf4\xf1\xdd\x77\x3f\x7d\x53\xfd\xfa\xf5\xed\x0f\x6f\x6e\x7b\xbc\x8e\x38\xf5\x1c\x5a\x67\x43\xca\xd4\x62\x13\xa8\x7e\x5f\xb3\xa5\x46\xdd\x41\x56\x41\xf2\x87\x8e\xbd\x1e\x20\xa0\x03\x29\x8a\x5e\x95\xec\xaa\x6a\xb6\x1a\x1c\x0a\x7a\x50\x45\xd1\x79\x55\x1c\xfa\x88\x89\x93\x07\xc1\x29\x7e\x85\x96\xb9\xec\x3d\xf7\x43\xe7\x7c\xd7\xac\x1f\x83\xab\xcb\xb6\x65\x5b\x92\xa2\xc2\x35\x52\xf6\x64\xb5\x76\x81\x3d\xa9\x2d\x56\xb2\xaa\x26\x85\xe1\x58\x71\x82\x64\xa7\xf2\xd9\x75\xfe\x1f\x74\x5f\xf1\xa6\xdb\x92\x71\xdb\x2d\x64\x31\xbc\x63\x83\xbd\x3f\x48\x48\x95\x9f\x09\x12\xbf\x95\xd8\x38\x27\x6d\x2b\x37\x27\xeb\xa2\x2e\x78\x4e\x7b\xe5\xad\xb6\xdb\x39\xb1\xf7\xce\xcf\xa9\xf0\x5a\x7c\xeb\xf7\xd9\x0a\x34\xe5\xfc\x1a\x47\x66\xb3\xcf\x96\xc0\xc6\x6d\xa9\xd2\x86\xe1\x70\xc6\x6d\x4f\x2b\xa8\x33\xe3\xb6\xe1\xb4\x30\x29\x37\x14\x0f\x2d\x2f\xe8\x2a\x4a\x18\x65\x0d\xd0\x20\x9a\x86\x0f\x46\x47\x7e\x34\xa7\xe6\x10\x3e\x98\x39\xa1\x3a\x71\x21\x6e\x81\x6e\x08\x56\x6e\x4a\xad\x0c\x17\x71\x2d\x1b\x7a\xbe\x6a\x17\x62\x4f\x1c\x7f\x3f\x87\x6b\x0f\xe1\x5a\xb6\x52\x1f\xbb\x7b\x72\x14\xd8\xef\xd8\x27\xaa\x38\xb4\x3e\xbf\x78\xba\x58\x2e\x96\x8b\xf3\xe7\x8f\x1e\x2d\x9f\xf4\xb4\x61\x22\xab\x1a\xfe\x98\xdc\xc0\x19\x95\x9b\x44\x06\x7b\xd7\xfd\x81\x9e\x40\xab\x42\xd8\x4f\xd3\xc7\xdf\x21\x80\xbd\xeb\xfe\xc0\x3f\xaa\x5d\x4a\xb7\xb7\xc6\xa9\x52\xe0\x57\xa8\xa2\x66\xd2\x8d\xda\x22\x0a\xd8\x92\xbc\x8a\xda\x6e\x03\xf1\x4e\xa0\xeb\xba\x6d\x0d\x12\x07\xc1\x83\x75\x00\x5c\xc9\xf7\x5c\x92\x82\xe9\x94\xa8\x43\xa7\x2a\x6b\xe2\xb2\x42\x2a\x3a\x62\x1b\xba\xbe\xe1\x51\x3b\xa5\x8d\xda\x68\xa9\x52\xfe\x8d\xb2\x06\x15\x37\xd8\xd6\x76\x9b\x22\xeb\x8f\xf0\x4b\xac\x52\x5e\x86\x4d\xd9\xa2\x40\x4a\xc5\x4a\xd5\x19\x43\x5b\xaf\xda\x9a\x3a\x5b\x72\x2e\xcd\x72\x42\xf3\x0e\x42\x85\x41\x2d\x5c\x8e\xfe\x1c\x6b\x04\xb8\x31\x2e\xbc\xba\xfc\x66\x2c\x8a\x2b\x8e\x45\x4d\x85\xb3\x45\xe7\xbd\x94\x3c\x60\x52\xae\xa9\x94\x75\x5d\x5c\x3f\xfb\x38\xb2\x20\xe5\xe6\xd4\x17\xfd\x21\xd1\xc8\x61\x3e\xd3\xee\xc3\xff\x56\xef\xf0\xa0\x6b\x51\x14\xa7\x74\x22\xb4\x3d\x47\x04\x9d\xf5\xc5\x69\x9a\x2d\xb9\x8d\x35\x48\x47\xaf\x90\x0d\x99\x54\x5f\x23\xc8\xc1\x05\xfd\xcc\xde\x51\xc3\xca\x06\xea\xac\xd1\x8d\x8e\x29\xec\xc8\xe3\x46\xdd\x0b\x85\xf5\x93\xc7\xa7\x94\x47\xf6\x37\x87\x98\xd8\x1f\x40\x24\x41\x31\xdf\x08\x7e\xff\xd5\x3b\x85\xe2\xfa\x7c\xf9\xf4\xd1\xd3\xc7\xe7\xcf\x2e\xfe\xe1\xdd\xae\x1a\xaf\x10\x9b\xa3\xb5\x11\x10\x03\x72\x2d\x8a\xd5\x6b\xc9\x21\xfb\xda\x85\x8c\x3c\xbe\x2f\x98\x51\x71\x48\xe4\x75\x51\x21\x6b\xa1\x78\xab\xd5\xae\x2f\xf6\x5a\xef\x10\x8f\xe6\xd2\x2b\x40\x10\xc1\xb9\xe0\x38\xaf\x84\x74\x4f\xf2\x9b\x56\x5b\x0b\xa4\x5c\x8d\x9e\x23\x29\x8c\x8c\xf2\x5b\x1e\x42\x1b\x9c\x26\xdc\xe9\xb6\xe5\xf2\xf3\xaa\x80\xc2\x84\xad\xf5\x57\x8f\x9e\x3c\x7b\xba\xfc\x3a\xf5\x64\xdf\xba\x3d\xb9\x0a\x7d\x8b\xc0\x05\x40\xcb\xb5\x29\xb5\x88\xfa\xc0\x37\xa9\x2d\xaa\x96\xd8\xaf\x06\x52\x45\xec\xa4\xcc\xa9\xd9\x94\xb4\x39\xe4\x06\xa5\x6f\x3d\x17\xf4\x37\x1d\x50\xd1\x81\x46\xcf\xa1\xe7\x87\x49\x1e\x11\xcd\xf9\xb6\x56\x96\xcb\x4c\x4f\x9e\x37\x6e\x37\x48\x90\xdd\x30\x50\x40\x37\xd0\xc1\xc9\x7a\xee\xb4\xcc\x0b\x52\x2d\x3b\xfe\xc6\x2d\x66\xaf\x0e\x81\x7c\x67\x51\x36\xa7\x6a\xa2\x6b\x73\x04\x92\x82\xd8\x77\xd2\x9f\xe9\x98\x2a\x5a\x74\xe8\xc2\xfa\x28\x38\xf2\x97\xb2\x02\xd8\x61\x71\xa8\x6c\x2e\x52\xd9\x99\x4c\x0f\x99\x54\x08\x7a\x0b\x29\xa2\x9b\x76\xde\x9b\x03\x3c\x36\x48\xe1\x17\xa9\x56\xa1\xd6\x76\xbb\xa0\xd7\x93\x80\x20\x90\x41\xfc\xe1\x78\x62\xee\xac\xce\xdc\x7c\xa3\x53\x8b\x60\x16\xd5\x1e\xb5\xa6\x03\xc0\x74\xa0\x46\x59\x69\x0b\x30\x3e\xe9\x95\x7e\x3d\xaa\x72\xa3\x0c\x5a\xf8\x72\xaa\x87\xe4\x44\xd3\x40\xd1\x37\xf8\xc8\x3f\x99\x56\xa0\xa2\x56\x76\x9b\x1a\xed\x7e\x6d\xbd\xfc\x08\x2a\x1f\x3a\xee\xe0\xfb\x1b\x85\xf8\xe4\x2a\xdc\x92\xeb\x77\x71\xdd\x0d\x0f\xed\x25\x6c\x2a\xa2\xa7\xbd\xb5\x33\x68\x40\x22\x35\x48\x8c\x9e\x65\x8f\x3c\x0a\xfa\x57\x1e\xaa\x33\x58\x2d\xd4\x5e\xdb\xbb\xa1\xce\x1b\xbd\x54\x97\x86\x87\xe9\x43\x6e\x79\x65\x0b\x2a\xb9\xcc\x5b\xe9\x38\xd8\x2f\x23\x6d\x54\x71\x47\x5d\x9b\x2d\x7a\x54\xa9\x9e\x37\xb3\xd5\x47\x1c\xe4\x69\xc7\x60\x2c\x84\xe8\x51\x14\x30\x1e\xf7\x92\x8b\x80\x1b\x15\x59\xd0\x24\xe6\xac\x55\xa0\x0d\xba\x1b\xb7\x41\xc2\x4a\xb0\x48\xea\x9c\x0b\x1b\xf0\x08\x57\x55\xc9\x12\x1a\x7d\x6c\x88\xae\xcd\x2a\x97\x62\x07\x88\xcc\xc5\x56\x83\x16\xd6\x96\x88\x51\xa8\xc2\xc2\x10\x72\x74\xac\x51\x83\x2b\xaa\x35\x9a\x4b\x29\xf7\xb2\xd1\xb2\xf5\x47\x66\xab\xd8\xf7\x88\x58\x51\x5b\x5e\x1c\xfd\x5a\x9f\x3f\x79\x56\x8f\x2b\x8d\xb6\x58\xbc\x78\x3c\x5d\x53\xf7\x58\x7b\x7a\xb1\x9c\x60\x7f\x5f\xeb\xa2\x4e\x81\x0d\xd9\x5a\x84\x96\x5e\x38\x95\x11\xe8\xc4\xd1\x6b\x80\x1b\xeb\xe4\x6f\xf6\x47\x7c\x21\x71\x4b\x44\xec\xfd\xa0\xb3\x7d\xb0\x7b\xbd\x63\x7f\x40\x4d\x89\x95\xde\x54\x63\xe8\x71\x15\xa6\x57\x18\x17\xe1\xf9\x60\xb5\x34\xe9\x14\xe8\xa4\xca\x6c\x12\x9e\x27\x0d\x21\xa6\x81\xda\x73\x39\xcf\x9a\xc2\x3c\x61\x8c\x8f\xf2\xf0\xb0\xbe\x38\x7f\xb2\xac\x4f\x39\x58\x9f\x0f\x4b\x1f\x41\x05\x1c\xcb\x8d\x27\x71\xb0\x6f\x56\x80\x03\x41\x11\x9a\x6f\x88\x0f\x78\x8f\x28\xc1\x49\xf4\x3f\xe6\xf0\x59\xb6\x3d\x07\x67\x76\x92\x15\x11\xe8\x2c\xbd\x6d\xd9\xbe\x50\xbf\x2a\xe5\x73\xad\x0b\x79\xee\xb8\x8d\xa8\x2b\x38\x75\x3a\x29\x18\x54\xce\x6f\x5d\x44\x84\x97\xc9\x81\x94\x59\xb0\x9c\xfd\xf2\xb3\x86\x83\x3e\x7a\xee\x26\x7a\x79\x7a\x21\x10\xb8\x2c\xa2\xde\xb1\x39\x10\xdb\xae\x61\x34\xcf\xfd\x7c\x01\xf5\x9c\x3f\x50\x59\xc7\xa3\x8e\x10\x11\x6d\xaf\x8c\xb4\x34\xd8\xe9\x5d\x87\xf2\x2f\x25\x17\xb1\x68\x3a\x87\x1e\x54\x2e\xf5\xfd\x0c\xab\x0f\xdb\x28\xa5\x80\x0d\x88\x36\x91\x3c\x29\x39\x4d\x39\x20\xea\xa8\x60\x5b\xa6\x28\x50\xe6\x58\x94\x81\x97\x8b\xae\x4a\xdb\x32\x87\x50\x88\xd7\xf7\xe3\x43\x01\xeb\x3a\x93\x33\xed\x5e\x07\x94\x9b\x68\x1e\x47\x5b\x42\x3d\xbd\x8c\xeb\xf3\xd9\xea\x23\x81\x13\x56\xfa\xd5\x56\x79\x65\x0c\x1b\x1d\x9a\xf5\xf9\xf2\xe3\x50\xba\x55\x7e\xa3\xb6\x48\x3d\x06\xdd\x43\xaa\x1b\x07\x10\x2d\xe8\x7d\x76\x8d\xc1\x57\x4a\x36\x1c\x39\x4f\xbd\x4a\x1d\xee\xc0\xd0\xb6\x38\x4d\x53\xdf\x9c\xd0\x55\x42\x8f\x58\x79\xcc\x27\x80\x05\x44\x2e\xcf\xad\xa3\xad\x77\x7b\xc4\xae\x83\x4b\x8e\x49\xb5\xde\xd6\xb4\x57\x91\x7d\xa3\xfc\x1d\xfd\x59\xdb\x54\x19\xfd\x65\x41\x57\x15\x32\x91\x0e\x69\x42\x06\x34\x6e\xdc\x8e\x3f\x75\x4a\xa2\xcf\xa9\x78\x98\x9d\x42\xd9\x22\x4c\x6e\x54\xe1\x85\xf1\x93\x53\xb5\x49\x64\xc0\x4d\xc8\xe4\x49\x1a\x2e\xa9\xb3\x51\x1b\xea\x02\x88\x97\x1e\x71\x74\xc3\xc6\xed\x13\x45\xb7\x1f\x19\x39\x2d\x29\xb0\x61\x78\x28\x89\x6e\x5b\x40\xe0\x61\x6d\xbd\x94\x35\xe3\xf6\xd3\xa5\xd9\x2a\xf9\xe8\x94\xfb\xc4\x25\x62\xe0\x27\xc2\x9f\x46\x83\xa3\x85\x57\x98\x99\x07\x13\x7c\x96\xcf\xd7\x69\xfb\x98\x04\x73\x3b\x94\x01\x20\x75\x58\x96\x7b\x98\x92\x20\xeb\x78\xe7\x9a\x7c\x39\x37\x49\x20\xb9\x59\x2c\x90\x82\x3c\x06\xf8\xad\x67\x55\x7e\xca\x20\xae\x3a\x8d\x5c\xe8\xcc\xcc\x81\x54\xe1\x5d\x48\x1a\xdb\x16\xe3\x5c\x09\xd5\x20\x48\xb9\x6a\xa0\x02\x7f\xc6\xbc\x45\x19\x03\x4b\x45\x41\x58\x62\x25\x44\xb5\xdd\xb2\x4f\x7d\xd4\x8d\x44\x2b\x10\xdc\x18\x57\xdc\x89\xfb\x2b\x63\x4e\xef\xd7\x76\x1c\x0a\x96\x5c\x76\x52\x88\x40\x93\xe9\x94\x10\x49\x6d\xd6\x60\x8e\x61\x00\x30\x5b\x4d\x19\x74\xb6\xbf\x4a\x0e\x61\x06\x88\x2b\x72\x4d\x82\x3f\xd3\xac\x76\xa8\x0a\x75\xc9\x36\xea\x78\x98\x4b\x48\xcb\x15\xb0\xed\x2b\x55\x3b\x28\x70\xb6\x1a\x84\x77\x36\xd3\x40\x55\x26\x97\x4d\x8a\x39\xac\xe1\x9a\x05\xbd\xc0\x93\x40\xca\xc0\x0e\x87\x14\xb8\x87\xf2\x19\x5b\x46\x8b\x4b\x2d\x8c\x37\x28\x28\x15\x62\xee\x14\x53\x96\x97\x74\x15\x6a\xe5\xb9\x1c\xe5\x5a\x9f\x9f\x74\xe4\xd0\xa9\x34\x08\x93\x9e\x73\x78\x09\xd4\xe3\xc9\x96\x02\xaf\x13\x44\x70\xf9\xf7\x3b\xe7\xd9\xea\xd3\xbd\x73\x29\x2f\xcb\x70\x29\xe8\x0f\x9d\xf3\x09\x53\xd6\xd9\x87\x39\x0b\x1f\x8f\x6a\x07\xe6\xba\x5c\xb4\xe5\xb2\x0f\x56\x70\x02\x87\xdc\x0a\x0c\x6f\xf2\x3c\x37\x4a\x5b\xa9\x5f\x90\x1e\x71\xfb\xbf\xf3\x2a\x03\x83\xb1\x23\xce\x8f\xc2\x74\xdb\x0d\xa1\x79\xcc\xcb\x27\x7c\x2e\x04\x32\xc9\x92\x08\x49\x83\x6c\xe2\x88\xf4\xe8\x09\xd5\xae\x93\x92\xb5\xd7\x61\xff\xd6\xd0\x60\x54\x23\x6f\x65\x90\xfa\xfa\x31\xdd\x51\xfd\x71\xf1\x2f\xcc\xbc\xc1\xec\x44\x7d\x93\xc1\xb7\x94\x07\xd2\x54\x23\x84\xa7\x88\x90\xbc\x1c\x6e\x6b\xcc\xc4\x09\x27\x5c\x4c\x4b\x1e\x89\x84\x6f\x72\x03\xdb\x7a\x5d\x0c\xb0\xf5\x8d\x32\xfa\xd7\x14\xee\x04\xb3\x69\x68\x51\x1c\x06\x8b\xe5\x84\x5e\x69\x13\xd9\x67\x04\x86\x34\xea\x76\x76\x41\xaf\xef\x13\xc4\xa5\xb0\x96\xb4\x2c\xe7\xd2\x2d\x23\x35\x38\x49\x46\xb4\xe4\x40\x45\xc6\x15\x2a\xe1\x1d\xc5\x9f\xa2\xf7\xef\xde\xe4\x62\x84\x33\x49\x50\xec\x75\xb9\xa0\x17\x2e\xd6\x32\x55\x62\x42\x9f\xfe\xdd\xcd\xdb\xef\xc9\x6d\x7e\x41\xfa\x6d\x54\xdb\x02\x35\x62\xeb\xe1\xca\x02\x71\x22\x6b\x74\xa7\x4c\xc7\x7d\x68\xe9\xac\x1e\xe7\xa3\x47\x6c\xce\x25\x22\xf3\xbd\x6a\x5a\xf1\x99\xdf\x1e\xbc\xb8\x7d\xf9\xe0\x39\x3d\xc2\xdb\xa7\x39\x3d\x78\xfd\xfe\xdd\x83\xe7\x74\xbe\x38\x7f\xf6\xfb\xa2\xd7\x67\x48\xa2\xca\x4b\x1b\x35\x0a\x3c\xd6\xfc\x10\x63\x08\x12\xa3\xc6\x61\x2a\x39\xd9\x1f\x59\xbf\xbf\xc1\xd8\x7e\x2a\x3d\xb4\x73\x3c\xff\xc4\x6a\x58\xfc\x12\x9c\x3d\xd9\xda\x79\xb3\xae\x63\x6c\xc3\xf3\xb3\xb3\x2c\xc0\xa2\x70\xcd\xe7\x0f\x8c\x28\xad\x87\xd7\x10\x68\xee\xd3\xbc\x23\x9c\x4e\x38\x04\x04\x85\x41\xfb\x5c\x69\x04\xf5\x34\x8d\x1a\x46\x80\x39\x3e\x39\x14\xb7\x9e\x55\x83\x88\xf4\xae\x03\xa1\x89\xd9\x67\xab\x91\x84\xf7\xf2\xb4\x01\x4c\xe9\x8e\x0f\x18\x51\x86\x39\x79\xde\x76\x46\x79\x54\xfd\x18\x65\xca\x4b\x83\x09\x2b\x54\xa8\xc8\x5b\x87\x49\xd7\x82\x5e\x3a\x2b\xed\x3a\x3e\x0f\x90\x37\xaf\x1b\xc6\xb7\x0d\xb7\x8c\x4f\x22\x54\x48\x18\x89\x6e\x00\x5b\x3f\x93\x54\x71\xc2\x46\xe7\x4d\x6e\x8e\xd2\x74\x5f\xc2\x5a\xac\xe9\xb7\x07\x3b\xf6\xa5\x2e\xe2\x83\xe7\xf4\x60\xb1\x58\x3c\x98\xd3\x03\xa3\x36\x6c\xc2\x83\xe7\xf4\xbf\x8b\xc5\xe2\xff\x7e\xef\x3f\xb5\xc8\x1b\x11\xef\x91\xba\x52\x7a\x74\xfb\x39\x7d\xe8\x94\x57\x36\x6a\x2c\xfa\x94\xd2\x72\x4a\x61\x94\x45\x89\x7b\x1d\xc8\xe6\x91\x75\x56\xa2\x9d\x6a\x71\xb6\xa2\xff\x1e\xc8\x1c\x9d\x92\x49\x0e\x00\xeb\x79\xa7\x79\x8f\xac\xa4\xa8\x71\x25\xf0\xe0\x7c\x6f\x21\x60\x3d\xf4\xb9\x0d\x6d\x96\xbc\x11\x30\x87\xa3\x1b\x4e\xac\x72\x82\xb9\xce\x8c\x10\x3a\x52\x9c\x20\xee\xf9\xd9\xd9\x38\xea\x7e\xb6\xfc\x7a\x79\x96\xf7\x1c\x80\xab\x1b\x79\x9f\x95\x4a\xb0\xed\xbb\xeb\x97\xa9\x22\xa9\x54\x91\x33\x34\x26\xeb\x47\x6f\xe2\x75\x45\x07\xd7\xd1\x5e\xa5\xd7\x1a\xf9\xad\x50\x3a\x7b\x79\x7d\x05\x9d\x6f\x7d\x5b\x00\x0f\x6c\xd7\xb8\x75\xb9\x58\x3e\xff\x6a\xb9\x94\xf8\x7f\x69\xf1\x56\xaf\x46\x39\x90\x3f\x52\x89\xee\x6e\x28\xf3\x32\x0b\x97\xd7\x57\x20\x3d\xd9\xc8\x54\x18\xcd\x36\x86\x9e\x3c\x9e\xc9\xc9\xf5\x7f\x38\xfc\x7d\xf1\x50\x7e\xfd\xa7\xdc\x41\xf2\xf7\xa8\xea\x9c\x13\x04\x82\xad\x77\x3b\xf9\x4a\xc3\xf5\x66\xf9\xf0\x09\xeb\xe5\x31\x78\xe8\x36\xa1\xf0\x7a\x23\xdb\x75\x94\x4a\xbd\xb3\x81\x63\x6e\x6f\xf2\x0d\xa8\xf7\x1a\x8e\xb5\x43\x5a\xb2\xe5\x78\x71\x4f\x40\x5e\x32\xa6\x4a\x24\x27\x46\xb1\xea\xb0\x2f\x4b\x32\xfc\x9e\x08\xf3\xd7\x34\x42\xb3\x78\xcb\xd9\x77\x7b\x05\xfb\xa8\x2b\xa9\xde\x24\x40\x23\x5d\xb7\x05\x56\x4f\x80\xd1\x16\x0b\xac\xfe\x33\x74\xee\x18\x13\x02\xdf\x16\x77\x7c\xf8\x98\x0a\x9e\xce\x56\x47\xaf\x4c\x43\x2d\x3d\x5d\xfe\xec\x07\x0a\x0a\x13\x28\x7d\xea\x45\xac\xae\xa8\x0b\xfd\xdd\x78\xb7\xfb\x70\xcb\x16\x8a\xe2\x92\x6e\x6e\xde\x4c\xd9\x81\x76\xae\xaa\xa3\xaf\x43\xe0\x86\x2e\xa6\xcb\x86\xb9\x1b\x8e\xc0\x7d\x46\x42\x3a\xf6\x1f\xa6\xdc\xa1\xa1\x86\xe9\x3c\xcb\x15\x0a\x25\xb0\x7c\x7d\x00\xea\x3d\x83\xba\x0d\xeb\xf3\x8b\xa7\x8b\xe5\x62\xb9\x38\x9f\xfd\xff\x00\xab\xc9\x67\x8c\xfb\x25\x00\x00")
 
 func sampleObcrawlerConfBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +83,7 @@ func sampleObcrawlerConf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sample-obcrawler.conf", size: 3060, mode: os.FileMode(420), modTime: time.Unix(1605028319, 0)}
+	info := bindataFileInfo{name: "sample-obcrawler.conf", size: 9723, mode: os.FileMode(420), modTime: time.Unix(1792374590, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
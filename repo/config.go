@@ -23,6 +23,10 @@ const (
 	defaultConfigFilename = "obcrawler.conf"
 	defaultLogFilename    = "crawler.log"
 	defaultGrpcPort       = "5001"
+
+	// dhtRecordTTL is the maximum amount of time a DHT node will
+	// hold onto a record before it must be put again.
+	dhtRecordTTL = time.Hour * 36
 )
 
 var log = logging.MustGetLogger("REPO")
@@ -55,29 +59,71 @@ var (
 //
 // See LoadConfig for details on the configuration load process.
 type Config struct {
-	ShowVersion        bool          `short:"v" long:"version" description:"Display version information and exit"`
-	NumNodes           uint          `short:"n" long:"nodes" description:"Number of IPFS nodes to spin up." default:"10"`
-	NumWorkers         uint          `short:"w" long:"workers" description:"Number of workers to use when crawling nodes" default:"12"`
-	PubsubNodes        uint          `short:"p" long:"pubsubnodes" description:"Number of pubsub nodes to listen on." default:"3"`
-	ConfigFile         string        `short:"C" long:"configfile" description:"Path to configuration file"`
-	DataDir            string        `short:"d" long:"datadir" description:"Directory to store data"`
-	CrawlInterval      time.Duration `long:"crawlinterval" description:"The amount of time to wait between network crawls" default:"5m"`
-	LogDir             string        `long:"logdir" description:"Directory to log output."`
-	LogLevel           string        `short:"l" long:"loglevel" description:"Set the logging level [debug, info, notice, warning, error, critical]." default:"info"`
-	BoostrapAddrs      []string      `long:"bootstrapaddr" description:"Override the default bootstrap addresses with the provided values"`
-	Testnet            bool          `short:"t" long:"testnet" description:"Use the test network"`
-	DisableNATPortMap  bool          `long:"noupnp" description:"Disable use of upnp"`
-	IPNSQuorum         uint          `long:"ipnsquorum" description:"The size of the IPNS quorum to use. Smaller is faster but less up-to-date." default:"2"`
-	UserAgentComment   string        `long:"uacomment" description:"Comment to add to the user agent"`
-	DisableDataCaching bool          `long:"disabledatacaching" description:"By default the crawler will download, cache, and seed node data including images and ratings. This functionality can be disabled with this flag."`
-	DisableFilePinning bool          `long:"diablefilepinning" description:"By default the crawler will pin all files it downloads until the file is replaced by another one."`
-	DisableIPNSPinning bool          `long:"disableipnspinning" description:"By default the crawler will pin non-expired IPNS records to ensure availability."`
+	ShowVersion         bool          `short:"v" long:"version" description:"Display version information and exit"`
+	NumNodes            uint          `short:"n" long:"nodes" description:"Number of IPFS nodes to spin up." default:"10"`
+	NumWorkers          uint          `short:"w" long:"workers" description:"Number of workers to use when crawling nodes" default:"12"`
+	PubsubNodes         uint          `short:"p" long:"pubsubnodes" description:"Number of pubsub nodes to listen on." default:"3"`
+	ConfigFile          string        `short:"C" long:"configfile" description:"Path to configuration file"`
+	DataDir             string        `short:"d" long:"datadir" description:"Directory to store data"`
+	CrawlInterval       time.Duration `long:"crawlinterval" description:"The amount of time to wait between network crawls" default:"5m"`
+	LogDir              string        `long:"logdir" description:"Directory to log output."`
+	LogLevel            string        `short:"l" long:"loglevel" description:"Set the logging level [debug, info, notice, warning, error, critical]." default:"info"`
+	BoostrapAddrs       []string      `long:"bootstrapaddr" description:"Override the default bootstrap addresses with the provided values"`
+	Testnet             bool          `short:"t" long:"testnet" description:"Use the test network"`
+	DisableNATPortMap   bool          `long:"noupnp" description:"Disable use of upnp"`
+	IPNSQuorum          uint          `long:"ipnsquorum" description:"The size of the IPNS quorum to use. Smaller is faster but less up-to-date." default:"2"`
+	UserAgentComment    string        `long:"uacomment" description:"Comment to add to the user agent"`
+	DisableDataCaching  bool          `long:"disabledatacaching" description:"By default the crawler will download, cache, and seed node data including images and ratings. This functionality can be disabled with this flag."`
+	DisableFilePinning  bool          `long:"diablefilepinning" description:"By default the crawler will pin all files it downloads until the file is replaced by another one."`
+	DisableIPNSPinning  bool          `long:"disableipnspinning" description:"By default the crawler will pin non-expired IPNS records to ensure availability."`
+	PubsubTopics        []string      `long:"pubsubtopic" description:"Override the default IPNS pubsub topic (/ipns/all) with the provided values. Useful for private test networks."`
+	DisablePeerTopics   bool          `long:"disablepeertopics" description:"By default the crawler will join the IPNS pubsub topic of each known peer to receive record updates faster. This functionality can be disabled with this flag."`
+	MaxPeerTopics       uint          `long:"maxpeertopics" description:"The maximum number of per-peer IPNS pubsub topics to join. Once reached the topic of the peer least recently heard from is left to join a new one." default:"5000"`
+	IPNSRepublish       bool          `long:"ipnsrepublish" description:"Periodically republish the valid IPNS records of recently seen peers on /ipns/all and their own IPNS pubsub topics so their stores remain discoverable while offline."`
+	RepublishInterval   time.Duration `long:"republishinterval" description:"The minimum amount of time to wait between republishes of a peer's IPNS record" default:"4h"`
+	RepublishRate       uint          `long:"republishrate" description:"The maximum number of IPNS records to republish per minute." default:"30"`
+	IPNSPinInterval     time.Duration `long:"ipnspininterval" description:"How often to put each non-expired IPNS record back to the DHT. Must be less than the DHT record TTL (36h)." default:"12h"`
+	IPNSPinBatchSize    uint          `long:"ipnspinbatchsize" description:"The maximum number of IPNS records to put to the DHT per minute." default:"100"`
+	GraphFanout         uint          `long:"graphfanout" description:"The number of DAG nodes to fetch concurrently when traversing a peer's graph." default:"8"`
+	GraphRetries        uint          `long:"graphretries" description:"The number of times to retry fetching a DAG node before giving up on it." default:"2"`
+	GraphMaxDepth       uint          `long:"graphmaxdepth" description:"The maximum depth to traverse a peer's graph. Zero means unlimited." default:"64"`
+	GraphMaxBytes       uint64        `long:"graphmaxbytes" description:"The maximum number of bytes to download when traversing a peer's graph. Zero means unlimited."`
+	PeerQuota           uint64        `long:"peerquota" description:"The maximum number of bytes of a peer's data to cache and pin. Peers over the quota only have their profile and listings cached. Zero means unlimited." default:"536870912"`
+	ReconcileInterval   time.Duration `long:"reconcileinterval" description:"How often to reconcile the pin table against the pins held by the IPFS nodes. Zero disables scheduled reconciliation." default:"24h"`
+	RecrawlInterval     time.Duration `long:"recrawlinterval" description:"How often to queue a batch of stale peers to be re-crawled." default:"1m"`
+	RecrawlAge          time.Duration `long:"recrawlage" description:"How long after its last crawl a peer without a history of changes is re-crawled. Other peers are re-crawled based on how often their data changes." default:"168h"`
+	RecrawlMinAge       time.Duration `long:"recrawlminage" description:"The minimum time between re-crawls of a peer." default:"24h"`
+	RecrawlMaxAge       time.Duration `long:"recrawlmaxage" description:"The maximum time between re-crawls of a peer." default:"720h"`
+	RecrawlBatchSize    uint          `long:"recrawlbatchsize" description:"The maximum number of peers to queue for re-crawl at each interval. The batch shrinks to the number of idle workers so the work queue doesn't back up." default:"10"`
+	PeerExpiry          time.Duration `long:"peerexpiry" description:"How long after a peer was last seen it is no longer re-crawled and its data is unpinned." default:"2160h"`
+	ObservedPeerExpiry  time.Duration `long:"observedpeerexpiry" description:"How long after a peer was last seen it is forgotten if it was never found to be an OpenBazaar store." default:"72h"`
+	UnpinInterval       time.Duration `long:"unpininterval" description:"How often to release the pins of expired peers." default:"1h"`
+	UnpinBatchSize      uint          `long:"unpinbatchsize" description:"The maximum number of expired peers to release the pins of at each interval." default:"10"`
+	DHTCrawl            bool          `long:"dhtcrawl" description:"Actively enumerate the DHT by walking the routing tables of the peers found and record the OpenBazaar nodes among them."`
+	DHTCrawlInterval    time.Duration `long:"dhtcrawlinterval" description:"How often to enumerate the DHT when dhtcrawl is enabled." default:"1h"`
+	DHTCrawlParallelism uint          `long:"dhtcrawlparallelism" description:"The number of peers to query concurrently when enumerating the DHT." default:"100"`
+	GCInterval          time.Duration `long:"gcinterval" description:"How often to garbage collect each IPFS node." default:"24h"`
+	GCHighWatermark     uint64        `long:"gchighwatermark" description:"Garbage collect a node early once its repo grows beyond this many bytes. If it is still above the watermark afterwards the data of the least recently seen peers is unpinned until it drops below the low watermark. Zero disables." default:"0"`
+	GCLowWatermark      uint64        `long:"gclowwatermark" description:"The number of bytes to evict data down to when a node stays above the high watermark. Zero means the high watermark." default:"0"`
+	GCEvictAfter        time.Duration `long:"gcevictafter" description:"Only the data of peers not seen for this long is evicted when a node stays above the high watermark." default:"168h"`
+	GCStagger           bool          `long:"gcstagger" description:"Spread garbage collection of the IPFS nodes evenly across the gc interval instead of collecting them all at once."`
+	SharedBlockstore    bool          `long:"sharedblockstore" description:"Store the blocks of all the IPFS nodes in a single deduplicated blockstore. Each node keeps its own identity, DHT table and pins."`
+	Replicas            uint          `long:"replicas" description:"The number of IPFS nodes in addition to the owner node that pin each peer's data." default:"0"`
+
+	PriceCurrency        string        `long:"pricecurrency" description:"The currency listing prices are normalized to for filtering and sorting." default:"USD"`
+	ExchangeRateFile     string        `long:"exchangeratefile" description:"A path to a JSON file mapping currency codes to the value of one unit of each in the price currency."`
+	ExchangeRateURL      string        `long:"exchangerateurl" description:"A URL to fetch exchange rates from in the same format as the exchange rate file."`
+	ExchangeRateInterval time.Duration `long:"exchangerateinterval" description:"How often to reload the exchange rates." default:"1h"`
+
+	ClassifierRules string `long:"classifierrules" description:"A path to a JSON file of rules used to label crawled profiles and listings and to quarantine or block them."`
+	ClassifierURL   string `long:"classifierurl" description:"A URL of an external service to classify crawled profiles and listings with."`
 
 	RPCCert           string   `long:"rpccert" description:"A path to the SSL certificate to use with gRPC"`
 	RPCKey            string   `long:"rpckey" description:"A path to the SSL key to use with gRPC"`
 	ExternalIPs       []string `long:"externalips" description:"This option should be used to specify the external IP address if using the auto-generated SSL certificate"`
 	GrpcListeners     []string `long:"grpclisten" description:"Add an interface/port to listen for experimental gRPC connections (default port:5001)"`
 	GrpcAuthToken     string   `long:"grpcauthtoken" description:"Set a token here if you want to enable client authentication with gRPC"`
+	ModeratorToken    string   `long:"moderatortoken" description:"Set a token here to enable the gRPC moderation methods and moderator subscriptions. Clients must provide it to use them"`
 	ResolverListeners []string `long:"resolverlisten" description:"Run a resolver HTTP server for IPNS records."`
 	NoResolverTLS     bool     `long:"noresolvertls" description:"Disable TLS when using the resolver."`
 
@@ -85,6 +131,10 @@ type Config struct {
 	DBHost    string `long:"dbhost" description:"The host:post location of the database."`
 	DBUser    string `long:"dbuser" description:"The database username"`
 	DBPass    string `long:"dbpass" description:"The database password"`
+
+	Args struct {
+		Command string `positional-arg-name:"command" description:"Optional command to run instead of the crawler. reconcile brings the IPFS nodes online, repairs their pin sets against the pin table and exits. [reconcile]"`
+	} `positional-args:"yes"`
 }
 
 // LoadConfig initializes and parses the config using a config file and command
@@ -169,6 +219,61 @@ func LoadConfig() (*Config, error) {
 		return nil, errors.New("pubsub nodes must not exceeds the number of IPFS nodes")
 	}
 
+	if cfg.RecrawlInterval == 0 || cfg.UnpinInterval == 0 {
+		return nil, errors.New("recrawl and unpin intervals must not be zero")
+	}
+
+	if cfg.RecrawlMinAge > cfg.RecrawlMaxAge {
+		return nil, errors.New("recrawl min age must not exceed the max age")
+	}
+
+	if cfg.RecrawlBatchSize == 0 || cfg.UnpinBatchSize == 0 {
+		return nil, errors.New("recrawl and unpin batch sizes must not be zero")
+	}
+
+	if cfg.ExchangeRateFile != "" && cfg.ExchangeRateURL != "" {
+		return nil, errors.New("only one of exchangeratefile and exchangerateurl may be set")
+	}
+	if cfg.ExchangeRateInterval == 0 {
+		return nil, errors.New("exchange rate interval must not be zero")
+	}
+
+	if cfg.DHTCrawl && (cfg.DHTCrawlInterval == 0 || cfg.DHTCrawlParallelism == 0) {
+		return nil, errors.New("dht crawl interval and parallelism must not be zero")
+	}
+
+	if cfg.IPNSRepublish && (cfg.RepublishInterval == 0 || cfg.RepublishRate == 0) {
+		return nil, errors.New("republish interval and rate must not be zero")
+	}
+
+	if cfg.GCInterval == 0 {
+		return nil, errors.New("gc interval must not be zero")
+	}
+
+	if cfg.GCHighWatermark > 0 && cfg.GCEvictAfter == 0 {
+		return nil, errors.New("gc evict after must not be zero")
+	}
+
+	if cfg.GCLowWatermark > cfg.GCHighWatermark {
+		return nil, errors.New("gc low watermark must not exceed the high watermark")
+	}
+
+	if cfg.Replicas >= cfg.NumNodes {
+		return nil, errors.New("replicas must be less than the number of IPFS nodes")
+	}
+
+	if cfg.IPNSPinInterval == 0 || cfg.IPNSPinInterval >= dhtRecordTTL {
+		return nil, fmt.Errorf("ipns pin interval must be greater than zero and less than %s", dhtRecordTTL)
+	}
+
+	if cfg.IPNSPinBatchSize == 0 {
+		return nil, errors.New("ipns pin batch size must not be zero")
+	}
+
+	if cfg.GraphFanout == 0 {
+		return nil, errors.New("graph fanout must not be zero")
+	}
+
 	_, ok := LogLevelMap[strings.ToLower(cfg.LogLevel)]
 	if !ok {
 		return nil, errors.New("invalid log level")
@@ -211,6 +316,12 @@ func LoadConfig() (*Config, error) {
 	if cfg.LogDir == "" {
 		cfg.LogDir = cleanAndExpandPath(path.Join(cfg.DataDir, "logs"))
 	}
+	if cfg.ExchangeRateFile != "" {
+		cfg.ExchangeRateFile = cleanAndExpandPath(cfg.ExchangeRateFile)
+	}
+	if cfg.ClassifierRules != "" {
+		cfg.ClassifierRules = cleanAndExpandPath(cfg.ClassifierRules)
+	}
 
 	return &cfg, nil
 }
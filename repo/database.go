@@ -72,7 +72,7 @@ func NewDatabase(dataDir string, opts ...Option) (*Database, error) {
 		return nil, err
 	}
 
-	if err := db.AutoMigrate(&Peer{}, &CIDRecord{}); err != nil {
+	if err := db.AutoMigrate(&ObservedPeer{}, &Peer{}, &CIDRecord{}, &Pin{}, &PinRef{}, &Listing{}, &ListingFacet{}, &Profile{}, &SearchTerm{}, &ListingFeature{}, &Moderator{}, &Rating{}, &RejectedRating{}, &RejectedListing{}, &ClassifierVerdict{}, &ImageHash{}, &RejectedImage{}, &ImageRef{}, &Review{}); err != nil {
 		return nil, err
 	}
 
@@ -5,22 +5,275 @@ import (
 	"time"
 )
 
-// Peer is the database model holding information about the peer
-// and its IPNS record.
+// ObservedPeer is the database model holding information about a peer
+// the crawler's IPFS nodes have seen on the network. Most of these are
+// plain IPFS nodes. A peer is promoted to a Peer once its IPNS record
+// resolves to a valid OpenBazaar root.
+type ObservedPeer struct {
+	PeerID    string `gorm:"primary_key"`
+	FirstSeen time.Time
+	LastSeen  time.Time `gorm:"index"`
+
+	// LastCrawlAttempt is when the peer was last queued for a crawl to
+	// find out whether it is a store.
+	LastCrawlAttempt time.Time
+
+	// Metadata reported by the peer to the libp2p identify protocol.
+	// Protocols and ListenAddrs are comma separated.
+	AgentVersion   string `gorm:"index"`
+	Protocols      string
+	ListenAddrs    string
+	PublicKeyType  string
+	OpenBazaar     bool `gorm:"index"`
+	PublicAddr     bool `gorm:"index"`
+	Relayed        bool `gorm:"index"`
+	LastIdentified time.Time
+}
+
+// Peer is the database model holding information about an OpenBazaar
+// store and its IPNS record. The data of a quarantined peer is crawled
+// but not pinned, indexed or streamed to public subscribers until a
+// moderator approves it.
 type Peer struct {
-	PeerID         string `gorm:"primary_key"`
-	FirstSeen      time.Time
-	LastSeen       time.Time `gorm:"index"`
-	LastCrawled    time.Time `gorm:"index"`
-	LastPinned     time.Time `gorm:"index"`
-	IPNSExpiration time.Time `gorm:"index"`
-	IPNSRecord     []byte
-	Banned         bool `gorm:"index"`
+	PeerID          string `gorm:"primary_key"`
+	FirstSeen       time.Time
+	LastSeen        time.Time `gorm:"index"`
+	LastCrawled     time.Time `gorm:"index"`
+	NextCrawlAt     time.Time `gorm:"index"`
+	RootCID         string
+	RootChangedAt   time.Time
+	ChangeInterval  time.Duration
+	LastPinned      time.Time `gorm:"index"`
+	LastPinAttempt  time.Time `gorm:"index"`
+	PinFailures     uint
+	LastRepublished time.Time `gorm:"index"`
+	IPNSExpiration  time.Time `gorm:"index"`
+	IPNSRecord      []byte
+	GraphSize       uint64
+	OverQuota       bool `gorm:"index"`
+	Evicted         bool `gorm:"index"`
+	Banned          bool `gorm:"index"`
+	Quarantined     bool `gorm:"index"`
 }
 
-// CIDRecord is a database model that maps a CID to a peer ID.
+// CIDRecord is a database model that maps a CID to a peer ID. Quarantined
+// CIDs belong to content pending review and are not pinned.
 type CIDRecord struct {
 	gorm.Model
-	CID    string `gorm:"index"`
-	PeerID string `gorm:"index"`
+	CID         string `gorm:"index"`
+	PeerID      string `gorm:"index"`
+	Quarantined bool   `gorm:"index"`
+}
+
+// Pin is a database model that tracks a CID pinned by one of the
+// crawler's IPFS nodes. RefCount is the number of peers referencing
+// the pin on that node. The CID is unpinned from the node when it
+// drops to zero.
+type Pin struct {
+	CID       string `gorm:"primary_key"`
+	Node      uint   `gorm:"primary_key"`
+	Recursive bool
+	RefCount  uint
+}
+
+// PinRef is a database model that records a peer's reference to a pin
+// held by one of the nodes assigned to the peer.
+type PinRef struct {
+	CID       string `gorm:"primary_key"`
+	PeerID    string `gorm:"primary_key"`
+	Node      uint   `gorm:"primary_key"`
+	Recursive bool
+}
+
+// Listing is a database model holding the latest crawled version of a
+// listing along with the fields it can be searched and sorted on. The
+// values it can be filtered on are ListingFacets. Price is in whole
+// units of Currency and NormalizedPrice in the crawler's price currency.
+// They are nil if unknown. Fingerprint is the MinHash signature of the
+// listing's text used to detect copies.
+type Listing struct {
+	PeerID          string `gorm:"primary_key"`
+	Slug            string `gorm:"primary_key"`
+	CID             string `gorm:"index"`
+	Title           string
+	Description     string
+	Condition       string
+	ContractType    string
+	Currency        string
+	Nsfw            bool
+	Price           *float64
+	NormalizedPrice *float64 `gorm:"index"`
+	Fingerprint     []byte
+	SignedListing   []byte
+	Expiration      time.Time `gorm:"index"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ListingFacet is a database model holding a value of a listing's search
+// facet. Facets with several values, such as the categories, have a row
+// for each value.
+type ListingFacet struct {
+	PeerID string `gorm:"primary_key"`
+	Slug   string `gorm:"primary_key"`
+	Facet  string `gorm:"primary_key"`
+	Value  string `gorm:"primary_key"`
+}
+
+// Profile is a database model holding the latest crawled version of a
+// peer's profile. The profile is serialized as JSON. InconsistentStats
+// is a comma separated list of the profile stats whose claimed values
+// differ from the values verified from the crawled data.
+type Profile struct {
+	PeerID            string `gorm:"primary_key"`
+	Name              string
+	Handle            string
+	Vendor            bool
+	Moderator         bool
+	Profile           []byte
+	InconsistentStats string
+	Expiration        time.Time `gorm:"index"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// SearchTerm is a database model for the full-text search index. It maps
+// a term to a listing or profile containing it. Slug is empty for profiles.
+// Weight is the sum of the weights of the fields the term appears in.
+type SearchTerm struct {
+	Term   string `gorm:"primary_key"`
+	PeerID string `gorm:"primary_key"`
+	Slug   string `gorm:"primary_key"`
+	Weight uint
+}
+
+// ListingFeature is a database model mapping a feature shared by copies
+// of a listing to the listings which have it. Feature is either a band of
+// the listing's fingerprint or the hash of an image it references.
+type ListingFeature struct {
+	Feature string `gorm:"primary_key"`
+	PeerID  string `gorm:"primary_key"`
+	Slug    string `gorm:"primary_key"`
+}
+
+// Moderator is a database model holding the terms of a peer whose profile
+// offers moderation. Multi-valued fields are comma separated. FixedFee is
+// in whole units of FixedFeeCurrency and NormalizedFixedFee in the
+// crawler's price currency. They are nil if there is no fixed fee or it
+// is unknown.
+type Moderator struct {
+	PeerID             string `gorm:"primary_key"`
+	Languages          string
+	AcceptedCurrencies string
+	FeeType            string
+	Percentage         float64
+	FixedFee           *float64
+	FixedFeeCurrency   string
+	NormalizedFixedFee *float64
+	Expiration         time.Time `gorm:"index"`
+	UpdatedAt          time.Time
+}
+
+// Rating is a database model holding a verified rating of a vendor. The
+// vendor signs a unique rating key for each order so ratings are keyed by
+// it to count only one rating per order.
+type Rating struct {
+	PeerID          string `gorm:"primary_key"`
+	RatingKey       string `gorm:"primary_key"`
+	CID             string `gorm:"index"`
+	Slug            string
+	BuyerID         string
+	Overall         uint32
+	Quality         uint32
+	Description     uint32
+	DeliverySpeed   uint32
+	CustomerService uint32
+	Timestamp       time.Time
+	CreatedAt       time.Time
+}
+
+// RejectedListing is a database model recording a listing in a peer's
+// listing index which was rejected during the peer's latest crawl.
+type RejectedListing struct {
+	PeerID    string `gorm:"primary_key"`
+	CID       string `gorm:"primary_key"`
+	Slug      string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// RejectedRating is a database model recording a rating in a peer's
+// rating index which is invalid or superseded by a newer rating of the
+// same order. Rejected ratings are not fetched again.
+type RejectedRating struct {
+	PeerID    string `gorm:"primary_key"`
+	CID       string `gorm:"primary_key"`
+	Reason    string
+	CreatedAt time.Time
+}
+
+// ClassifierVerdict is a database model caching the classifiers' verdict
+// on a profile or listing. Content is immutable so it is only classified
+// again if the classifiers change. Classifier identifies the rules and
+// endpoint which gave the verdict.
+type ClassifierVerdict struct {
+	CID        string `gorm:"primary_key"`
+	Classifier string `gorm:"primary_key"`
+	Verdict    string
+	Labels     string
+	CreatedAt  time.Time
+}
+
+// ImageHash is a database model holding the perceptual hashes of a
+// crawled image. The hashes are stored as signed integers as not every
+// database supports unsigned 64 bit integers. Each hash is also split
+// into four indexed 16 bit chunks so similar images can be looked up.
+type ImageHash struct {
+	CID       string `gorm:"primary_key"`
+	DHash     int64  `gorm:"index"`
+	PHash     int64  `gorm:"index"`
+	DChunk0   int    `gorm:"index"`
+	DChunk1   int    `gorm:"index"`
+	DChunk2   int    `gorm:"index"`
+	DChunk3   int    `gorm:"index"`
+	PChunk0   int    `gorm:"index"`
+	PChunk1   int    `gorm:"index"`
+	PChunk2   int    `gorm:"index"`
+	PChunk3   int    `gorm:"index"`
+	Width     int
+	Height    int
+	CreatedAt time.Time
+}
+
+// RejectedImage is a database model recording an image which couldn't
+// be decoded. Rejected images are not fetched again.
+type RejectedImage struct {
+	CID       string `gorm:"primary_key"`
+	Reason    string
+	CreatedAt time.Time
+}
+
+// ImageRef is a database model recording that a peer's profile or
+// listings reference an image.
+type ImageRef struct {
+	CID    string `gorm:"primary_key"`
+	PeerID string `gorm:"primary_key"`
+}
+
+// Review is a database model for the moderation review queue. It records
+// a peer's profile or listing quarantined pending review, or the peer
+// itself if CID is empty. The content is kept so it can be reviewed
+// without being pinned. Status is pending until a moderator approves or
+// rejects it. Decisions are kept so the same content isn't queued again.
+type Review struct {
+	PeerID        string `gorm:"primary_key"`
+	CID           string `gorm:"primary_key"`
+	Slug          string
+	Labels        string
+	Reason        string
+	Status        string `gorm:"index"`
+	Profile       []byte
+	SignedListing []byte
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
@@ -1,14 +1,233 @@
 package rpc
 
 import (
+	"github.com/cpacia/openbazaar3.0/models"
 	peer "github.com/libp2p/go-libp2p-core/peer"
+	"time"
 )
 
 // Crawler is an interface to the Crawler package used to
 // avoid circular imports.
 type Crawler interface {
-	Subscribe() (*Subscription, error)
+	Subscribe(moderator bool) (*Subscription, error)
 	CrawlNode(pid peer.ID) error
 	BanNode(pid peer.ID) error
 	UnbanNode(pid peer.ID) error
+	QuarantineNode(pid peer.ID, reason string) error
+	GetQuota(pid peer.ID) (*QuotaStatus, error)
+	Search(query *SearchQuery) (*SearchResults, error)
+	ListModerators(query *ModeratorQuery) (*ModeratorResults, error)
+	GetReputation(pid peer.ID) (*Reputation, error)
+	FindDuplicates(query *DuplicateQuery) ([]*DuplicateCluster, error)
+	FindSimilarImages(query *ImageQuery) ([]*SimilarImage, error)
+	ListReviews(query *ReviewQuery) ([]*Review, error)
+	ApproveReview(pid peer.ID, cid string) error
+	RejectReview(pid peer.ID, cid string) error
+}
+
+// QuotaStatus holds the storage quota status of a node.
+type QuotaStatus struct {
+	GraphSize uint64
+	Quota     uint64
+	OverQuota bool
+}
+
+// SearchType selects whether listings or profiles are searched.
+type SearchType int
+
+const (
+	SearchListings SearchType = iota
+	SearchProfiles
+)
+
+// SortBy is the order of search results.
+type SortBy int
+
+const (
+	SortByRelevance SortBy = iota
+	SortByNewest
+	SortByTitle
+	SortByPriceAscending
+	SortByPriceDescending
+)
+
+// Facet names used in SearchQuery.Filters and SearchResults.Facets.
+const (
+	FacetCategory     = "category"
+	FacetCurrency     = "currency"
+	FacetCondition    = "condition"
+	FacetContractType = "contractType"
+	FacetShipsTo      = "shipsTo"
+)
+
+// SearchQuery is a full-text search of the crawled listings or profiles.
+// An empty query matches everything. Filters map a facet name to the
+// values to accept. A result must match one of the values of every
+// filter. MinPrice and MaxPrice are in the price currency and are
+// ignored if zero. Filters only apply to listings.
+type SearchQuery struct {
+	Query    string
+	Type     SearchType
+	Filters  map[string][]string
+	MinPrice float64
+	MaxPrice float64
+	SortBy   SortBy
+	Page     int
+	PageSize int
+}
+
+// SearchResults holds a page of search results. Total is the number of
+// results across all pages. Facets map a facet name to the number of
+// results with each value. PriceCurrency is the currency the prices of
+// the results are normalized to.
+type SearchResults struct {
+	Total         int
+	Results       []*SearchResult
+	Facets        map[string]map[string]int
+	PriceCurrency string
+}
+
+// SearchResult is a single search result. Data holds a *models.Profile
+// or a *pb.SignedListing. Price is the normalized price of a listing and
+// is nil if it is unknown.
+type SearchResult struct {
+	Data           interface{}
+	Score          uint
+	Price          *float64
+	ExpirationDate time.Time
+}
+
+// ModeratorQuery filters the moderator directory. Currency and Language
+// must be accepted by the moderator and are compared case insensitively.
+// MaxPercentage is the highest percentage fee and MaxFixedFee the highest
+// fixed fee in the price currency. SeenWithin excludes moderators not seen
+// online within the duration. Zero values are ignored.
+type ModeratorQuery struct {
+	Currency      string
+	Language      string
+	MaxPercentage float64
+	MaxFixedFee   float64
+	SeenWithin    time.Duration
+}
+
+// ModeratorResults holds the moderators matching a query. PriceCurrency
+// is the currency fixed fees are normalized to.
+type ModeratorResults struct {
+	Moderators    []*Moderator
+	PriceCurrency string
+}
+
+// Moderator is an entry in the moderator directory. LastSeen is when the moderator was last
+// seen online. NormalizedFixedFee is the fixed fee in the price currency
+// and is nil if there is none or it is unknown.
+type Moderator struct {
+	Profile            *models.Profile
+	LastSeen           time.Time
+	NormalizedFixedFee *float64
+	ExpirationDate     time.Time
+}
+
+// Reputation aggregates the verified ratings of a vendor. The averages
+// are of each rating category. Score is the overall average weighted
+// towards a neutral rating for vendors with few ratings.
+type Reputation struct {
+	RatingCount     int
+	Overall         float64
+	Quality         float64
+	Description     float64
+	DeliverySpeed   float64
+	CustomerService float64
+	Score           float64
+	LastRated       time.Time
+}
+
+// Profile stat names used in VerifiedStats.Inconsistent.
+const (
+	StatFollowerCount  = "followerCount"
+	StatFollowingCount = "followingCount"
+	StatListingCount   = "listingCount"
+	StatRatingCount    = "ratingCount"
+	StatAverageRating  = "averageRating"
+)
+
+// VerifiedStats holds a profile's stats recomputed from the crawled
+// listing index, ratings and follower data. Inconsistent lists the stats
+// whose claimed value differs significantly from the verified value.
+type VerifiedStats struct {
+	FollowerCount  uint32
+	FollowingCount uint32
+	ListingCount   uint32
+	RatingCount    uint32
+	AverageRating  float32
+	Inconsistent   []string
+}
+
+// DuplicateQuery selects the clusters of copied listings to return. If
+// PeerID and Slug are set only the cluster of that listing is returned.
+type DuplicateQuery struct {
+	PeerID string
+	Slug   string
+}
+
+// DuplicateCluster is a set of listings from different stores which are
+// copies of each other. The listings are ordered by when they were first
+// crawled so the first is most likely the original.
+type DuplicateCluster struct {
+	Listings []*DuplicateListing
+}
+
+// DuplicateListing is a listing in a DuplicateCluster. Similarity is the
+// estimated similarity of its text to the first listing in the cluster
+// and SharedImages the number of images they share.
+type DuplicateListing struct {
+	PeerID       string
+	Slug         string
+	CID          string
+	Title        string
+	FirstCrawled time.Time
+	Similarity   float64
+	SharedImages int
+}
+
+// ImageQuery looks up crawled images similar to the crawled image with
+// the given CID or to the given perceptual hashes. If CID is set the
+// hashes are ignored. MaxDistance is the most bits each hash may differ
+// by, up to 16. A default is used if it is zero.
+type ImageQuery struct {
+	CID         string
+	DHash       *uint64
+	PHash       *uint64
+	MaxDistance int
+}
+
+// SimilarImage is a crawled image similar to the one queried. The
+// distances are the number of bits its hashes differ from those queried
+// by, or -1 if the hash wasn't compared. PeerIDs are the peers whose
+// profile or listings reference the image.
+type SimilarImage struct {
+	CID           string
+	Width         int
+	Height        int
+	DHashDistance int
+	PHashDistance int
+	PeerIDs       []string
+}
+
+// ReviewQuery selects the items pending review. If PeerID is set only
+// the peer's items are returned.
+type ReviewQuery struct {
+	PeerID string
+}
+
+// Review is an item in the moderation review queue. CID is empty if the
+// peer itself is quarantined. Otherwise Data is the quarantined profile
+// or listing and Labels are the labels the classifiers gave it.
+type Review struct {
+	PeerID    string
+	CID       string
+	Slug      string
+	Labels    []string
+	Reason    string
+	CreatedAt time.Time
+	Data      interface{}
 }
@@ -26,6 +26,65 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type SearchRequest_SearchType int32
+
+const (
+	SearchRequest_LISTINGS SearchRequest_SearchType = 0
+	SearchRequest_PROFILES SearchRequest_SearchType = 1
+)
+
+var SearchRequest_SearchType_name = map[int32]string{
+	0: "LISTINGS",
+	1: "PROFILES",
+}
+
+var SearchRequest_SearchType_value = map[string]int32{
+	"LISTINGS": 0,
+	"PROFILES": 1,
+}
+
+func (x SearchRequest_SearchType) String() string {
+	return proto.EnumName(SearchRequest_SearchType_name, int32(x))
+}
+
+func (SearchRequest_SearchType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{12, 0}
+}
+
+type SearchRequest_SortBy int32
+
+const (
+	SearchRequest_RELEVANCE  SearchRequest_SortBy = 0
+	SearchRequest_NEWEST     SearchRequest_SortBy = 1
+	SearchRequest_TITLE      SearchRequest_SortBy = 2
+	SearchRequest_PRICE_ASC  SearchRequest_SortBy = 3
+	SearchRequest_PRICE_DESC SearchRequest_SortBy = 4
+)
+
+var SearchRequest_SortBy_name = map[int32]string{
+	0: "RELEVANCE",
+	1: "NEWEST",
+	2: "TITLE",
+	3: "PRICE_ASC",
+	4: "PRICE_DESC",
+}
+
+var SearchRequest_SortBy_value = map[string]int32{
+	"RELEVANCE":  0,
+	"NEWEST":     1,
+	"TITLE":      2,
+	"PRICE_ASC":  3,
+	"PRICE_DESC": 4,
+}
+
+func (x SearchRequest_SortBy) String() string {
+	return proto.EnumName(SearchRequest_SortBy_name, int32(x))
+}
+
+func (SearchRequest_SortBy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{12, 1}
+}
+
 type Profile_ModeratorInfo_ModeratorFee_FeeType int32
 
 const (
@@ -51,11 +110,12 @@ func (x Profile_ModeratorInfo_ModeratorFee_FeeType) String() string {
 }
 
 func (Profile_ModeratorInfo_ModeratorFee_FeeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 4, 0, 0}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 4, 0, 0}
 }
 
 // RPC MESSAGES
 type SubscribeRequest struct {
+	Moderator            bool     `protobuf:"varint,1,opt,name=moderator,proto3" json:"moderator,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -86,12 +146,25 @@ func (m *SubscribeRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
 
+func (m *SubscribeRequest) GetModerator() bool {
+	if m != nil {
+		return m.Moderator
+	}
+	return false
+}
+
 type UserData struct {
 	// Types that are valid to be assigned to Data:
 	//	*UserData_Profile
 	//	*UserData_Listing
 	Data                 isUserData_Data      `protobuf_oneof:"data"`
 	Expiration           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Reputation           *Reputation          `protobuf:"bytes,4,opt,name=reputation,proto3" json:"reputation,omitempty"`
+	VerifiedStats        *VerifiedStats       `protobuf:"bytes,5,opt,name=verifiedStats,proto3" json:"verifiedStats,omitempty"`
+	Duplicates           *DuplicateCluster    `protobuf:"bytes,6,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Copycat              bool                 `protobuf:"varint,7,opt,name=copycat,proto3" json:"copycat,omitempty"`
+	Labels               []string             `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty"`
+	Quarantined          bool                 `protobuf:"varint,9,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -166,6 +239,48 @@ func (m *UserData) GetExpiration() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *UserData) GetReputation() *Reputation {
+	if m != nil {
+		return m.Reputation
+	}
+	return nil
+}
+
+func (m *UserData) GetVerifiedStats() *VerifiedStats {
+	if m != nil {
+		return m.VerifiedStats
+	}
+	return nil
+}
+
+func (m *UserData) GetDuplicates() *DuplicateCluster {
+	if m != nil {
+		return m.Duplicates
+	}
+	return nil
+}
+
+func (m *UserData) GetCopycat() bool {
+	if m != nil {
+		return m.Copycat
+	}
+	return false
+}
+
+func (m *UserData) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *UserData) GetQuarantined() bool {
+	if m != nil {
+		return m.Quarantined
+	}
+	return false
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*UserData) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -331,58 +446,1722 @@ func (*UnbanNodeRequest) Descriptor() ([]byte, []int) {
 func (m *UnbanNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnbanNodeRequest.Unmarshal(m, b)
 }
-func (m *UnbanNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnbanNodeRequest.Marshal(b, m, deterministic)
+func (m *UnbanNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanNodeRequest.Marshal(b, m, deterministic)
+}
+func (m *UnbanNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanNodeRequest.Merge(m, src)
+}
+func (m *UnbanNodeRequest) XXX_Size() int {
+	return xxx_messageInfo_UnbanNodeRequest.Size(m)
+}
+func (m *UnbanNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanNodeRequest proto.InternalMessageInfo
+
+func (m *UnbanNodeRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+type UnbanNodeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnbanNodeResponse) Reset()         { *m = UnbanNodeResponse{} }
+func (m *UnbanNodeResponse) String() string { return proto.CompactTextString(m) }
+func (*UnbanNodeResponse) ProtoMessage()    {}
+func (*UnbanNodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{7}
+}
+
+func (m *UnbanNodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanNodeResponse.Unmarshal(m, b)
+}
+func (m *UnbanNodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanNodeResponse.Marshal(b, m, deterministic)
+}
+func (m *UnbanNodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanNodeResponse.Merge(m, src)
+}
+func (m *UnbanNodeResponse) XXX_Size() int {
+	return xxx_messageInfo_UnbanNodeResponse.Size(m)
+}
+func (m *UnbanNodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanNodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanNodeResponse proto.InternalMessageInfo
+
+type QuarantineNodeRequest struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuarantineNodeRequest) Reset()         { *m = QuarantineNodeRequest{} }
+func (m *QuarantineNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*QuarantineNodeRequest) ProtoMessage()    {}
+func (*QuarantineNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{8}
+}
+
+func (m *QuarantineNodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantineNodeRequest.Unmarshal(m, b)
+}
+func (m *QuarantineNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantineNodeRequest.Marshal(b, m, deterministic)
+}
+func (m *QuarantineNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantineNodeRequest.Merge(m, src)
+}
+func (m *QuarantineNodeRequest) XXX_Size() int {
+	return xxx_messageInfo_QuarantineNodeRequest.Size(m)
+}
+func (m *QuarantineNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantineNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantineNodeRequest proto.InternalMessageInfo
+
+func (m *QuarantineNodeRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *QuarantineNodeRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type QuarantineNodeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuarantineNodeResponse) Reset()         { *m = QuarantineNodeResponse{} }
+func (m *QuarantineNodeResponse) String() string { return proto.CompactTextString(m) }
+func (*QuarantineNodeResponse) ProtoMessage()    {}
+func (*QuarantineNodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{9}
+}
+
+func (m *QuarantineNodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantineNodeResponse.Unmarshal(m, b)
+}
+func (m *QuarantineNodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantineNodeResponse.Marshal(b, m, deterministic)
+}
+func (m *QuarantineNodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantineNodeResponse.Merge(m, src)
+}
+func (m *QuarantineNodeResponse) XXX_Size() int {
+	return xxx_messageInfo_QuarantineNodeResponse.Size(m)
+}
+func (m *QuarantineNodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantineNodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantineNodeResponse proto.InternalMessageInfo
+
+type GetQuotaRequest struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetQuotaRequest) Reset()         { *m = GetQuotaRequest{} }
+func (m *GetQuotaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetQuotaRequest) ProtoMessage()    {}
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{10}
+}
+
+func (m *GetQuotaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuotaRequest.Unmarshal(m, b)
+}
+func (m *GetQuotaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuotaRequest.Marshal(b, m, deterministic)
+}
+func (m *GetQuotaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuotaRequest.Merge(m, src)
+}
+func (m *GetQuotaRequest) XXX_Size() int {
+	return xxx_messageInfo_GetQuotaRequest.Size(m)
+}
+func (m *GetQuotaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuotaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuotaRequest proto.InternalMessageInfo
+
+func (m *GetQuotaRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+type GetQuotaResponse struct {
+	GraphSize            uint64   `protobuf:"varint,1,opt,name=graphSize,proto3" json:"graphSize,omitempty"`
+	Quota                uint64   `protobuf:"varint,2,opt,name=quota,proto3" json:"quota,omitempty"`
+	OverQuota            bool     `protobuf:"varint,3,opt,name=overQuota,proto3" json:"overQuota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetQuotaResponse) Reset()         { *m = GetQuotaResponse{} }
+func (m *GetQuotaResponse) String() string { return proto.CompactTextString(m) }
+func (*GetQuotaResponse) ProtoMessage()    {}
+func (*GetQuotaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{11}
+}
+
+func (m *GetQuotaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuotaResponse.Unmarshal(m, b)
+}
+func (m *GetQuotaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuotaResponse.Marshal(b, m, deterministic)
+}
+func (m *GetQuotaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuotaResponse.Merge(m, src)
+}
+func (m *GetQuotaResponse) XXX_Size() int {
+	return xxx_messageInfo_GetQuotaResponse.Size(m)
+}
+func (m *GetQuotaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuotaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuotaResponse proto.InternalMessageInfo
+
+func (m *GetQuotaResponse) GetGraphSize() uint64 {
+	if m != nil {
+		return m.GraphSize
+	}
+	return 0
+}
+
+func (m *GetQuotaResponse) GetQuota() uint64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+func (m *GetQuotaResponse) GetOverQuota() bool {
+	if m != nil {
+		return m.OverQuota
+	}
+	return false
+}
+
+type SearchRequest struct {
+	Query                string                   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Type                 SearchRequest_SearchType `protobuf:"varint,2,opt,name=type,proto3,enum=pb.SearchRequest_SearchType" json:"type,omitempty"`
+	Categories           []string                 `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	Currencies           []string                 `protobuf:"bytes,4,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	Conditions           []string                 `protobuf:"bytes,5,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	ContractTypes        []string                 `protobuf:"bytes,6,rep,name=contractTypes,proto3" json:"contractTypes,omitempty"`
+	ShipsTo              []string                 `protobuf:"bytes,7,rep,name=shipsTo,proto3" json:"shipsTo,omitempty"`
+	SortBy               SearchRequest_SortBy     `protobuf:"varint,8,opt,name=sortBy,proto3,enum=pb.SearchRequest_SortBy" json:"sortBy,omitempty"`
+	Page                 uint32                   `protobuf:"varint,9,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize             uint32                   `protobuf:"varint,10,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	MinPrice             float64                  `protobuf:"fixed64,11,opt,name=minPrice,proto3" json:"minPrice,omitempty"`
+	MaxPrice             float64                  `protobuf:"fixed64,12,opt,name=maxPrice,proto3" json:"maxPrice,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{12}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetType() SearchRequest_SearchType {
+	if m != nil {
+		return m.Type
+	}
+	return SearchRequest_LISTINGS
+}
+
+func (m *SearchRequest) GetCategories() []string {
+	if m != nil {
+		return m.Categories
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetCurrencies() []string {
+	if m != nil {
+		return m.Currencies
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetConditions() []string {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetContractTypes() []string {
+	if m != nil {
+		return m.ContractTypes
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetShipsTo() []string {
+	if m != nil {
+		return m.ShipsTo
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetSortBy() SearchRequest_SortBy {
+	if m != nil {
+		return m.SortBy
+	}
+	return SearchRequest_RELEVANCE
+}
+
+func (m *SearchRequest) GetPage() uint32 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetMinPrice() float64 {
+	if m != nil {
+		return m.MinPrice
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetMaxPrice() float64 {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	Total                uint32                   `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Results              []*SearchResponse_Result `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Facets               []*SearchResponse_Facet  `protobuf:"bytes,3,rep,name=facets,proto3" json:"facets,omitempty"`
+	PriceCurrency        string                   `protobuf:"bytes,4,opt,name=priceCurrency,proto3" json:"priceCurrency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *SearchResponse) Reset()         { *m = SearchResponse{} }
+func (m *SearchResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse) ProtoMessage()    {}
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{13}
+}
+
+func (m *SearchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse.Unmarshal(m, b)
+}
+func (m *SearchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse.Merge(m, src)
+}
+func (m *SearchResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse.Size(m)
+}
+func (m *SearchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse proto.InternalMessageInfo
+
+func (m *SearchResponse) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *SearchResponse) GetResults() []*SearchResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetFacets() []*SearchResponse_Facet {
+	if m != nil {
+		return m.Facets
+	}
+	return nil
+}
+
+func (m *SearchResponse) GetPriceCurrency() string {
+	if m != nil {
+		return m.PriceCurrency
+	}
+	return ""
+}
+
+type SearchResponse_Result struct {
+	Data                 *UserData             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Score                uint32                `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Price                *SearchResponse_Price `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *SearchResponse_Result) Reset()         { *m = SearchResponse_Result{} }
+func (m *SearchResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse_Result) ProtoMessage()    {}
+func (*SearchResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{13, 0}
+}
+
+func (m *SearchResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse_Result.Unmarshal(m, b)
+}
+func (m *SearchResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse_Result.Merge(m, src)
+}
+func (m *SearchResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse_Result.Size(m)
+}
+func (m *SearchResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse_Result proto.InternalMessageInfo
+
+func (m *SearchResponse_Result) GetData() *UserData {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SearchResponse_Result) GetScore() uint32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SearchResponse_Result) GetPrice() *SearchResponse_Price {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+type SearchResponse_Price struct {
+	Amount               float64  `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse_Price) Reset()         { *m = SearchResponse_Price{} }
+func (m *SearchResponse_Price) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse_Price) ProtoMessage()    {}
+func (*SearchResponse_Price) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{13, 1}
+}
+
+func (m *SearchResponse_Price) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse_Price.Unmarshal(m, b)
+}
+func (m *SearchResponse_Price) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse_Price.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse_Price) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse_Price.Merge(m, src)
+}
+func (m *SearchResponse_Price) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse_Price.Size(m)
+}
+func (m *SearchResponse_Price) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse_Price.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse_Price proto.InternalMessageInfo
+
+func (m *SearchResponse_Price) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *SearchResponse_Price) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+type SearchResponse_Facet struct {
+	Name                 string                             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values               []*SearchResponse_Facet_FacetValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *SearchResponse_Facet) Reset()         { *m = SearchResponse_Facet{} }
+func (m *SearchResponse_Facet) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse_Facet) ProtoMessage()    {}
+func (*SearchResponse_Facet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{13, 2}
+}
+
+func (m *SearchResponse_Facet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse_Facet.Unmarshal(m, b)
+}
+func (m *SearchResponse_Facet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse_Facet.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse_Facet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse_Facet.Merge(m, src)
+}
+func (m *SearchResponse_Facet) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse_Facet.Size(m)
+}
+func (m *SearchResponse_Facet) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse_Facet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse_Facet proto.InternalMessageInfo
+
+func (m *SearchResponse_Facet) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SearchResponse_Facet) GetValues() []*SearchResponse_Facet_FacetValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type SearchResponse_Facet_FacetValue struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count                uint32   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchResponse_Facet_FacetValue) Reset()         { *m = SearchResponse_Facet_FacetValue{} }
+func (m *SearchResponse_Facet_FacetValue) String() string { return proto.CompactTextString(m) }
+func (*SearchResponse_Facet_FacetValue) ProtoMessage()    {}
+func (*SearchResponse_Facet_FacetValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{13, 2, 0}
+}
+
+func (m *SearchResponse_Facet_FacetValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchResponse_Facet_FacetValue.Unmarshal(m, b)
+}
+func (m *SearchResponse_Facet_FacetValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchResponse_Facet_FacetValue.Marshal(b, m, deterministic)
+}
+func (m *SearchResponse_Facet_FacetValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchResponse_Facet_FacetValue.Merge(m, src)
+}
+func (m *SearchResponse_Facet_FacetValue) XXX_Size() int {
+	return xxx_messageInfo_SearchResponse_Facet_FacetValue.Size(m)
+}
+func (m *SearchResponse_Facet_FacetValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchResponse_Facet_FacetValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchResponse_Facet_FacetValue proto.InternalMessageInfo
+
+func (m *SearchResponse_Facet_FacetValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *SearchResponse_Facet_FacetValue) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type ListModeratorsRequest struct {
+	Currency             string   `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Language             string   `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	MaxPercentage        float64  `protobuf:"fixed64,3,opt,name=maxPercentage,proto3" json:"maxPercentage,omitempty"`
+	MaxFixedFee          float64  `protobuf:"fixed64,4,opt,name=maxFixedFee,proto3" json:"maxFixedFee,omitempty"`
+	SeenWithin           uint32   `protobuf:"varint,5,opt,name=seenWithin,proto3" json:"seenWithin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListModeratorsRequest) Reset()         { *m = ListModeratorsRequest{} }
+func (m *ListModeratorsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListModeratorsRequest) ProtoMessage()    {}
+func (*ListModeratorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{14}
+}
+
+func (m *ListModeratorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListModeratorsRequest.Unmarshal(m, b)
+}
+func (m *ListModeratorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListModeratorsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListModeratorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListModeratorsRequest.Merge(m, src)
+}
+func (m *ListModeratorsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListModeratorsRequest.Size(m)
+}
+func (m *ListModeratorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListModeratorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListModeratorsRequest proto.InternalMessageInfo
+
+func (m *ListModeratorsRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *ListModeratorsRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *ListModeratorsRequest) GetMaxPercentage() float64 {
+	if m != nil {
+		return m.MaxPercentage
+	}
+	return 0
+}
+
+func (m *ListModeratorsRequest) GetMaxFixedFee() float64 {
+	if m != nil {
+		return m.MaxFixedFee
+	}
+	return 0
+}
+
+func (m *ListModeratorsRequest) GetSeenWithin() uint32 {
+	if m != nil {
+		return m.SeenWithin
+	}
+	return 0
+}
+
+type ListModeratorsResponse struct {
+	Moderators           []*ListModeratorsResponse_Moderator `protobuf:"bytes,1,rep,name=moderators,proto3" json:"moderators,omitempty"`
+	PriceCurrency        string                              `protobuf:"bytes,2,opt,name=priceCurrency,proto3" json:"priceCurrency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *ListModeratorsResponse) Reset()         { *m = ListModeratorsResponse{} }
+func (m *ListModeratorsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListModeratorsResponse) ProtoMessage()    {}
+func (*ListModeratorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{15}
+}
+
+func (m *ListModeratorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListModeratorsResponse.Unmarshal(m, b)
+}
+func (m *ListModeratorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListModeratorsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListModeratorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListModeratorsResponse.Merge(m, src)
+}
+func (m *ListModeratorsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListModeratorsResponse.Size(m)
+}
+func (m *ListModeratorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListModeratorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListModeratorsResponse proto.InternalMessageInfo
+
+func (m *ListModeratorsResponse) GetModerators() []*ListModeratorsResponse_Moderator {
+	if m != nil {
+		return m.Moderators
+	}
+	return nil
+}
+
+func (m *ListModeratorsResponse) GetPriceCurrency() string {
+	if m != nil {
+		return m.PriceCurrency
+	}
+	return ""
+}
+
+type ListModeratorsResponse_Moderator struct {
+	Data                 *UserData             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	LastSeen             *timestamp.Timestamp  `protobuf:"bytes,2,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	FixedFee             *SearchResponse_Price `protobuf:"bytes,3,opt,name=fixedFee,proto3" json:"fixedFee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListModeratorsResponse_Moderator) Reset()         { *m = ListModeratorsResponse_Moderator{} }
+func (m *ListModeratorsResponse_Moderator) String() string { return proto.CompactTextString(m) }
+func (*ListModeratorsResponse_Moderator) ProtoMessage()    {}
+func (*ListModeratorsResponse_Moderator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{15, 0}
+}
+
+func (m *ListModeratorsResponse_Moderator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListModeratorsResponse_Moderator.Unmarshal(m, b)
+}
+func (m *ListModeratorsResponse_Moderator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListModeratorsResponse_Moderator.Marshal(b, m, deterministic)
+}
+func (m *ListModeratorsResponse_Moderator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListModeratorsResponse_Moderator.Merge(m, src)
+}
+func (m *ListModeratorsResponse_Moderator) XXX_Size() int {
+	return xxx_messageInfo_ListModeratorsResponse_Moderator.Size(m)
+}
+func (m *ListModeratorsResponse_Moderator) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListModeratorsResponse_Moderator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListModeratorsResponse_Moderator proto.InternalMessageInfo
+
+func (m *ListModeratorsResponse_Moderator) GetData() *UserData {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ListModeratorsResponse_Moderator) GetLastSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeen
+	}
+	return nil
+}
+
+func (m *ListModeratorsResponse_Moderator) GetFixedFee() *SearchResponse_Price {
+	if m != nil {
+		return m.FixedFee
+	}
+	return nil
+}
+
+type GetReputationRequest struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetReputationRequest) Reset()         { *m = GetReputationRequest{} }
+func (m *GetReputationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetReputationRequest) ProtoMessage()    {}
+func (*GetReputationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{16}
+}
+
+func (m *GetReputationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReputationRequest.Unmarshal(m, b)
+}
+func (m *GetReputationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReputationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetReputationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReputationRequest.Merge(m, src)
+}
+func (m *GetReputationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetReputationRequest.Size(m)
+}
+func (m *GetReputationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReputationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReputationRequest proto.InternalMessageInfo
+
+func (m *GetReputationRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+type Reputation struct {
+	RatingCount          uint32               `protobuf:"varint,1,opt,name=ratingCount,proto3" json:"ratingCount,omitempty"`
+	Overall              float64              `protobuf:"fixed64,2,opt,name=overall,proto3" json:"overall,omitempty"`
+	Quality              float64              `protobuf:"fixed64,3,opt,name=quality,proto3" json:"quality,omitempty"`
+	Description          float64              `protobuf:"fixed64,4,opt,name=description,proto3" json:"description,omitempty"`
+	DeliverySpeed        float64              `protobuf:"fixed64,5,opt,name=deliverySpeed,proto3" json:"deliverySpeed,omitempty"`
+	CustomerService      float64              `protobuf:"fixed64,6,opt,name=customerService,proto3" json:"customerService,omitempty"`
+	Score                float64              `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"`
+	LastRated            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=lastRated,proto3" json:"lastRated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Reputation) Reset()         { *m = Reputation{} }
+func (m *Reputation) String() string { return proto.CompactTextString(m) }
+func (*Reputation) ProtoMessage()    {}
+func (*Reputation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{17}
+}
+
+func (m *Reputation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Reputation.Unmarshal(m, b)
+}
+func (m *Reputation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Reputation.Marshal(b, m, deterministic)
+}
+func (m *Reputation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reputation.Merge(m, src)
+}
+func (m *Reputation) XXX_Size() int {
+	return xxx_messageInfo_Reputation.Size(m)
+}
+func (m *Reputation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reputation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Reputation proto.InternalMessageInfo
+
+func (m *Reputation) GetRatingCount() uint32 {
+	if m != nil {
+		return m.RatingCount
+	}
+	return 0
+}
+
+func (m *Reputation) GetOverall() float64 {
+	if m != nil {
+		return m.Overall
+	}
+	return 0
+}
+
+func (m *Reputation) GetQuality() float64 {
+	if m != nil {
+		return m.Quality
+	}
+	return 0
+}
+
+func (m *Reputation) GetDescription() float64 {
+	if m != nil {
+		return m.Description
+	}
+	return 0
+}
+
+func (m *Reputation) GetDeliverySpeed() float64 {
+	if m != nil {
+		return m.DeliverySpeed
+	}
+	return 0
+}
+
+func (m *Reputation) GetCustomerService() float64 {
+	if m != nil {
+		return m.CustomerService
+	}
+	return 0
+}
+
+func (m *Reputation) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Reputation) GetLastRated() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastRated
+	}
+	return nil
+}
+
+// VerifiedStats holds the profile stats recomputed from the crawled data.
+// The stats claimed by the vendor are in Profile.stats. Inconsistent
+// names the claimed stats which differ significantly from these.
+type VerifiedStats struct {
+	FollowerCount        uint32   `protobuf:"varint,1,opt,name=followerCount,proto3" json:"followerCount,omitempty"`
+	FollowingCount       uint32   `protobuf:"varint,2,opt,name=followingCount,proto3" json:"followingCount,omitempty"`
+	ListingCount         uint32   `protobuf:"varint,3,opt,name=listingCount,proto3" json:"listingCount,omitempty"`
+	RatingCount          uint32   `protobuf:"varint,4,opt,name=ratingCount,proto3" json:"ratingCount,omitempty"`
+	AverageRating        float32  `protobuf:"fixed32,5,opt,name=averageRating,proto3" json:"averageRating,omitempty"`
+	Inconsistent         []string `protobuf:"bytes,6,rep,name=inconsistent,proto3" json:"inconsistent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifiedStats) Reset()         { *m = VerifiedStats{} }
+func (m *VerifiedStats) String() string { return proto.CompactTextString(m) }
+func (*VerifiedStats) ProtoMessage()    {}
+func (*VerifiedStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{18}
+}
+
+func (m *VerifiedStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifiedStats.Unmarshal(m, b)
+}
+func (m *VerifiedStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifiedStats.Marshal(b, m, deterministic)
+}
+func (m *VerifiedStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifiedStats.Merge(m, src)
+}
+func (m *VerifiedStats) XXX_Size() int {
+	return xxx_messageInfo_VerifiedStats.Size(m)
+}
+func (m *VerifiedStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifiedStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifiedStats proto.InternalMessageInfo
+
+func (m *VerifiedStats) GetFollowerCount() uint32 {
+	if m != nil {
+		return m.FollowerCount
+	}
+	return 0
+}
+
+func (m *VerifiedStats) GetFollowingCount() uint32 {
+	if m != nil {
+		return m.FollowingCount
+	}
+	return 0
+}
+
+func (m *VerifiedStats) GetListingCount() uint32 {
+	if m != nil {
+		return m.ListingCount
+	}
+	return 0
+}
+
+func (m *VerifiedStats) GetRatingCount() uint32 {
+	if m != nil {
+		return m.RatingCount
+	}
+	return 0
+}
+
+func (m *VerifiedStats) GetAverageRating() float32 {
+	if m != nil {
+		return m.AverageRating
+	}
+	return 0
+}
+
+func (m *VerifiedStats) GetInconsistent() []string {
+	if m != nil {
+		return m.Inconsistent
+	}
+	return nil
+}
+
+type FindDuplicatesRequest struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Slug                 string   `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindDuplicatesRequest) Reset()         { *m = FindDuplicatesRequest{} }
+func (m *FindDuplicatesRequest) String() string { return proto.CompactTextString(m) }
+func (*FindDuplicatesRequest) ProtoMessage()    {}
+func (*FindDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{19}
+}
+
+func (m *FindDuplicatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindDuplicatesRequest.Unmarshal(m, b)
+}
+func (m *FindDuplicatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindDuplicatesRequest.Marshal(b, m, deterministic)
+}
+func (m *FindDuplicatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindDuplicatesRequest.Merge(m, src)
+}
+func (m *FindDuplicatesRequest) XXX_Size() int {
+	return xxx_messageInfo_FindDuplicatesRequest.Size(m)
+}
+func (m *FindDuplicatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindDuplicatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindDuplicatesRequest proto.InternalMessageInfo
+
+func (m *FindDuplicatesRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *FindDuplicatesRequest) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+type FindDuplicatesResponse struct {
+	Clusters             []*DuplicateCluster `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *FindDuplicatesResponse) Reset()         { *m = FindDuplicatesResponse{} }
+func (m *FindDuplicatesResponse) String() string { return proto.CompactTextString(m) }
+func (*FindDuplicatesResponse) ProtoMessage()    {}
+func (*FindDuplicatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{20}
+}
+
+func (m *FindDuplicatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindDuplicatesResponse.Unmarshal(m, b)
+}
+func (m *FindDuplicatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindDuplicatesResponse.Marshal(b, m, deterministic)
+}
+func (m *FindDuplicatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindDuplicatesResponse.Merge(m, src)
+}
+func (m *FindDuplicatesResponse) XXX_Size() int {
+	return xxx_messageInfo_FindDuplicatesResponse.Size(m)
+}
+func (m *FindDuplicatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindDuplicatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindDuplicatesResponse proto.InternalMessageInfo
+
+func (m *FindDuplicatesResponse) GetClusters() []*DuplicateCluster {
+	if m != nil {
+		return m.Clusters
+	}
+	return nil
+}
+
+// DuplicateCluster holds listings from different stores which are copies
+// of each other, ordered by when they were first crawled. The first is
+// most likely the original and the others copycats.
+type DuplicateCluster struct {
+	Listings             []*DuplicateCluster_Listing `protobuf:"bytes,1,rep,name=listings,proto3" json:"listings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *DuplicateCluster) Reset()         { *m = DuplicateCluster{} }
+func (m *DuplicateCluster) String() string { return proto.CompactTextString(m) }
+func (*DuplicateCluster) ProtoMessage()    {}
+func (*DuplicateCluster) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{21}
+}
+
+func (m *DuplicateCluster) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateCluster.Unmarshal(m, b)
+}
+func (m *DuplicateCluster) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateCluster.Marshal(b, m, deterministic)
+}
+func (m *DuplicateCluster) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateCluster.Merge(m, src)
+}
+func (m *DuplicateCluster) XXX_Size() int {
+	return xxx_messageInfo_DuplicateCluster.Size(m)
+}
+func (m *DuplicateCluster) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateCluster.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateCluster proto.InternalMessageInfo
+
+func (m *DuplicateCluster) GetListings() []*DuplicateCluster_Listing {
+	if m != nil {
+		return m.Listings
+	}
+	return nil
+}
+
+type DuplicateCluster_Listing struct {
+	Peer                 string               `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Slug                 string               `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Cid                  string               `protobuf:"bytes,3,opt,name=cid,proto3" json:"cid,omitempty"`
+	Title                string               `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	FirstCrawled         *timestamp.Timestamp `protobuf:"bytes,5,opt,name=firstCrawled,proto3" json:"firstCrawled,omitempty"`
+	Similarity           float64              `protobuf:"fixed64,6,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	SharedImages         uint32               `protobuf:"varint,7,opt,name=sharedImages,proto3" json:"sharedImages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DuplicateCluster_Listing) Reset()         { *m = DuplicateCluster_Listing{} }
+func (m *DuplicateCluster_Listing) String() string { return proto.CompactTextString(m) }
+func (*DuplicateCluster_Listing) ProtoMessage()    {}
+func (*DuplicateCluster_Listing) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{21, 0}
+}
+
+func (m *DuplicateCluster_Listing) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateCluster_Listing.Unmarshal(m, b)
+}
+func (m *DuplicateCluster_Listing) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateCluster_Listing.Marshal(b, m, deterministic)
+}
+func (m *DuplicateCluster_Listing) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateCluster_Listing.Merge(m, src)
+}
+func (m *DuplicateCluster_Listing) XXX_Size() int {
+	return xxx_messageInfo_DuplicateCluster_Listing.Size(m)
+}
+func (m *DuplicateCluster_Listing) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateCluster_Listing.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateCluster_Listing proto.InternalMessageInfo
+
+func (m *DuplicateCluster_Listing) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *DuplicateCluster_Listing) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *DuplicateCluster_Listing) GetCid() string {
+	if m != nil {
+		return m.Cid
+	}
+	return ""
+}
+
+func (m *DuplicateCluster_Listing) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *DuplicateCluster_Listing) GetFirstCrawled() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstCrawled
+	}
+	return nil
+}
+
+func (m *DuplicateCluster_Listing) GetSimilarity() float64 {
+	if m != nil {
+		return m.Similarity
+	}
+	return 0
+}
+
+func (m *DuplicateCluster_Listing) GetSharedImages() uint32 {
+	if m != nil {
+		return m.SharedImages
+	}
+	return 0
+}
+
+type FindSimilarImagesRequest struct {
+	Cid                  string   `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	DHash                uint64   `protobuf:"varint,2,opt,name=dHash,proto3" json:"dHash,omitempty"`
+	PHash                uint64   `protobuf:"varint,3,opt,name=pHash,proto3" json:"pHash,omitempty"`
+	MaxDistance          uint32   `protobuf:"varint,4,opt,name=maxDistance,proto3" json:"maxDistance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindSimilarImagesRequest) Reset()         { *m = FindSimilarImagesRequest{} }
+func (m *FindSimilarImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*FindSimilarImagesRequest) ProtoMessage()    {}
+func (*FindSimilarImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{22}
+}
+
+func (m *FindSimilarImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindSimilarImagesRequest.Unmarshal(m, b)
+}
+func (m *FindSimilarImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindSimilarImagesRequest.Marshal(b, m, deterministic)
+}
+func (m *FindSimilarImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindSimilarImagesRequest.Merge(m, src)
+}
+func (m *FindSimilarImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_FindSimilarImagesRequest.Size(m)
+}
+func (m *FindSimilarImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindSimilarImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindSimilarImagesRequest proto.InternalMessageInfo
+
+func (m *FindSimilarImagesRequest) GetCid() string {
+	if m != nil {
+		return m.Cid
+	}
+	return ""
+}
+
+func (m *FindSimilarImagesRequest) GetDHash() uint64 {
+	if m != nil {
+		return m.DHash
+	}
+	return 0
+}
+
+func (m *FindSimilarImagesRequest) GetPHash() uint64 {
+	if m != nil {
+		return m.PHash
+	}
+	return 0
+}
+
+func (m *FindSimilarImagesRequest) GetMaxDistance() uint32 {
+	if m != nil {
+		return m.MaxDistance
+	}
+	return 0
+}
+
+type FindSimilarImagesResponse struct {
+	Images               []*FindSimilarImagesResponse_Image `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *FindSimilarImagesResponse) Reset()         { *m = FindSimilarImagesResponse{} }
+func (m *FindSimilarImagesResponse) String() string { return proto.CompactTextString(m) }
+func (*FindSimilarImagesResponse) ProtoMessage()    {}
+func (*FindSimilarImagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{23}
+}
+
+func (m *FindSimilarImagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindSimilarImagesResponse.Unmarshal(m, b)
+}
+func (m *FindSimilarImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindSimilarImagesResponse.Marshal(b, m, deterministic)
+}
+func (m *FindSimilarImagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindSimilarImagesResponse.Merge(m, src)
+}
+func (m *FindSimilarImagesResponse) XXX_Size() int {
+	return xxx_messageInfo_FindSimilarImagesResponse.Size(m)
+}
+func (m *FindSimilarImagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindSimilarImagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindSimilarImagesResponse proto.InternalMessageInfo
+
+func (m *FindSimilarImagesResponse) GetImages() []*FindSimilarImagesResponse_Image {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+type FindSimilarImagesResponse_Image struct {
+	Cid                  string   `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Width                uint32   `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32   `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	DHashDistance        int32    `protobuf:"varint,4,opt,name=dHashDistance,proto3" json:"dHashDistance,omitempty"`
+	PHashDistance        int32    `protobuf:"varint,5,opt,name=pHashDistance,proto3" json:"pHashDistance,omitempty"`
+	Peers                []string `protobuf:"bytes,6,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindSimilarImagesResponse_Image) Reset()         { *m = FindSimilarImagesResponse_Image{} }
+func (m *FindSimilarImagesResponse_Image) String() string { return proto.CompactTextString(m) }
+func (*FindSimilarImagesResponse_Image) ProtoMessage()    {}
+func (*FindSimilarImagesResponse_Image) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{23, 0}
+}
+
+func (m *FindSimilarImagesResponse_Image) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindSimilarImagesResponse_Image.Unmarshal(m, b)
+}
+func (m *FindSimilarImagesResponse_Image) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindSimilarImagesResponse_Image.Marshal(b, m, deterministic)
+}
+func (m *FindSimilarImagesResponse_Image) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindSimilarImagesResponse_Image.Merge(m, src)
+}
+func (m *FindSimilarImagesResponse_Image) XXX_Size() int {
+	return xxx_messageInfo_FindSimilarImagesResponse_Image.Size(m)
+}
+func (m *FindSimilarImagesResponse_Image) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindSimilarImagesResponse_Image.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindSimilarImagesResponse_Image proto.InternalMessageInfo
+
+func (m *FindSimilarImagesResponse_Image) GetCid() string {
+	if m != nil {
+		return m.Cid
+	}
+	return ""
+}
+
+func (m *FindSimilarImagesResponse_Image) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *FindSimilarImagesResponse_Image) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *FindSimilarImagesResponse_Image) GetDHashDistance() int32 {
+	if m != nil {
+		return m.DHashDistance
+	}
+	return 0
+}
+
+func (m *FindSimilarImagesResponse_Image) GetPHashDistance() int32 {
+	if m != nil {
+		return m.PHashDistance
+	}
+	return 0
+}
+
+func (m *FindSimilarImagesResponse_Image) GetPeers() []string {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type ListReviewsRequest struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListReviewsRequest) Reset()         { *m = ListReviewsRequest{} }
+func (m *ListReviewsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListReviewsRequest) ProtoMessage()    {}
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{24}
+}
+
+func (m *ListReviewsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListReviewsRequest.Unmarshal(m, b)
+}
+func (m *ListReviewsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListReviewsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListReviewsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReviewsRequest.Merge(m, src)
+}
+func (m *ListReviewsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListReviewsRequest.Size(m)
+}
+func (m *ListReviewsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReviewsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReviewsRequest proto.InternalMessageInfo
+
+func (m *ListReviewsRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+type ListReviewsResponse struct {
+	Reviews              []*ListReviewsResponse_Review `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *ListReviewsResponse) Reset()         { *m = ListReviewsResponse{} }
+func (m *ListReviewsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListReviewsResponse) ProtoMessage()    {}
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{25}
+}
+
+func (m *ListReviewsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListReviewsResponse.Unmarshal(m, b)
+}
+func (m *ListReviewsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListReviewsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListReviewsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReviewsResponse.Merge(m, src)
+}
+func (m *ListReviewsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListReviewsResponse.Size(m)
+}
+func (m *ListReviewsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReviewsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReviewsResponse proto.InternalMessageInfo
+
+func (m *ListReviewsResponse) GetReviews() []*ListReviewsResponse_Review {
+	if m != nil {
+		return m.Reviews
+	}
+	return nil
+}
+
+type ListReviewsResponse_Review struct {
+	Peer                 string               `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Cid                  string               `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	Slug                 string               `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Labels               []string             `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	Reason               string               `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	Data                 *UserData            `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListReviewsResponse_Review) Reset()         { *m = ListReviewsResponse_Review{} }
+func (m *ListReviewsResponse_Review) String() string { return proto.CompactTextString(m) }
+func (*ListReviewsResponse_Review) ProtoMessage()    {}
+func (*ListReviewsResponse_Review) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{25, 0}
+}
+
+func (m *ListReviewsResponse_Review) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListReviewsResponse_Review.Unmarshal(m, b)
+}
+func (m *ListReviewsResponse_Review) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListReviewsResponse_Review.Marshal(b, m, deterministic)
+}
+func (m *ListReviewsResponse_Review) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReviewsResponse_Review.Merge(m, src)
+}
+func (m *ListReviewsResponse_Review) XXX_Size() int {
+	return xxx_messageInfo_ListReviewsResponse_Review.Size(m)
+}
+func (m *ListReviewsResponse_Review) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReviewsResponse_Review.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReviewsResponse_Review proto.InternalMessageInfo
+
+func (m *ListReviewsResponse_Review) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *ListReviewsResponse_Review) GetCid() string {
+	if m != nil {
+		return m.Cid
+	}
+	return ""
+}
+
+func (m *ListReviewsResponse_Review) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *ListReviewsResponse_Review) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *ListReviewsResponse_Review) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ListReviewsResponse_Review) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *ListReviewsResponse_Review) GetData() *UserData {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ApproveReviewRequest struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Cid                  string   `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveReviewRequest) Reset()         { *m = ApproveReviewRequest{} }
+func (m *ApproveReviewRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveReviewRequest) ProtoMessage()    {}
+func (*ApproveReviewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{26}
+}
+
+func (m *ApproveReviewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveReviewRequest.Unmarshal(m, b)
+}
+func (m *ApproveReviewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveReviewRequest.Marshal(b, m, deterministic)
+}
+func (m *ApproveReviewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveReviewRequest.Merge(m, src)
+}
+func (m *ApproveReviewRequest) XXX_Size() int {
+	return xxx_messageInfo_ApproveReviewRequest.Size(m)
+}
+func (m *ApproveReviewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveReviewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveReviewRequest proto.InternalMessageInfo
+
+func (m *ApproveReviewRequest) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *ApproveReviewRequest) GetCid() string {
+	if m != nil {
+		return m.Cid
+	}
+	return ""
+}
+
+type ApproveReviewResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveReviewResponse) Reset()         { *m = ApproveReviewResponse{} }
+func (m *ApproveReviewResponse) String() string { return proto.CompactTextString(m) }
+func (*ApproveReviewResponse) ProtoMessage()    {}
+func (*ApproveReviewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{27}
+}
+
+func (m *ApproveReviewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveReviewResponse.Unmarshal(m, b)
+}
+func (m *ApproveReviewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveReviewResponse.Marshal(b, m, deterministic)
+}
+func (m *ApproveReviewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveReviewResponse.Merge(m, src)
+}
+func (m *ApproveReviewResponse) XXX_Size() int {
+	return xxx_messageInfo_ApproveReviewResponse.Size(m)
+}
+func (m *ApproveReviewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveReviewResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveReviewResponse proto.InternalMessageInfo
+
+type RejectReviewRequest struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Cid                  string   `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectReviewRequest) Reset()         { *m = RejectReviewRequest{} }
+func (m *RejectReviewRequest) String() string { return proto.CompactTextString(m) }
+func (*RejectReviewRequest) ProtoMessage()    {}
+func (*RejectReviewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{28}
+}
+
+func (m *RejectReviewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectReviewRequest.Unmarshal(m, b)
+}
+func (m *RejectReviewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectReviewRequest.Marshal(b, m, deterministic)
 }
-func (m *UnbanNodeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnbanNodeRequest.Merge(m, src)
+func (m *RejectReviewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectReviewRequest.Merge(m, src)
 }
-func (m *UnbanNodeRequest) XXX_Size() int {
-	return xxx_messageInfo_UnbanNodeRequest.Size(m)
+func (m *RejectReviewRequest) XXX_Size() int {
+	return xxx_messageInfo_RejectReviewRequest.Size(m)
 }
-func (m *UnbanNodeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnbanNodeRequest.DiscardUnknown(m)
+func (m *RejectReviewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectReviewRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnbanNodeRequest proto.InternalMessageInfo
+var xxx_messageInfo_RejectReviewRequest proto.InternalMessageInfo
 
-func (m *UnbanNodeRequest) GetPeer() string {
+func (m *RejectReviewRequest) GetPeer() string {
 	if m != nil {
 		return m.Peer
 	}
 	return ""
 }
 
-type UnbanNodeResponse struct {
+func (m *RejectReviewRequest) GetCid() string {
+	if m != nil {
+		return m.Cid
+	}
+	return ""
+}
+
+type RejectReviewResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UnbanNodeResponse) Reset()         { *m = UnbanNodeResponse{} }
-func (m *UnbanNodeResponse) String() string { return proto.CompactTextString(m) }
-func (*UnbanNodeResponse) ProtoMessage()    {}
-func (*UnbanNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{7}
+func (m *RejectReviewResponse) Reset()         { *m = RejectReviewResponse{} }
+func (m *RejectReviewResponse) String() string { return proto.CompactTextString(m) }
+func (*RejectReviewResponse) ProtoMessage()    {}
+func (*RejectReviewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84c7eabcfe7807d1, []int{29}
 }
 
-func (m *UnbanNodeResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnbanNodeResponse.Unmarshal(m, b)
+func (m *RejectReviewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectReviewResponse.Unmarshal(m, b)
 }
-func (m *UnbanNodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnbanNodeResponse.Marshal(b, m, deterministic)
+func (m *RejectReviewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectReviewResponse.Marshal(b, m, deterministic)
 }
-func (m *UnbanNodeResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnbanNodeResponse.Merge(m, src)
+func (m *RejectReviewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectReviewResponse.Merge(m, src)
 }
-func (m *UnbanNodeResponse) XXX_Size() int {
-	return xxx_messageInfo_UnbanNodeResponse.Size(m)
+func (m *RejectReviewResponse) XXX_Size() int {
+	return xxx_messageInfo_RejectReviewResponse.Size(m)
 }
-func (m *UnbanNodeResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnbanNodeResponse.DiscardUnknown(m)
+func (m *RejectReviewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectReviewResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnbanNodeResponse proto.InternalMessageInfo
+var xxx_messageInfo_RejectReviewResponse proto.InternalMessageInfo
 
 // DATA MESSAGES
 type Profile struct {
@@ -413,7 +2192,7 @@ func (m *Profile) Reset()         { *m = Profile{} }
 func (m *Profile) String() string { return proto.CompactTextString(m) }
 func (*Profile) ProtoMessage()    {}
 func (*Profile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30}
 }
 
 func (m *Profile) XXX_Unmarshal(b []byte) error {
@@ -575,7 +2354,7 @@ func (m *Profile_ProfileColors) Reset()         { *m = Profile_ProfileColors{} }
 func (m *Profile_ProfileColors) String() string { return proto.CompactTextString(m) }
 func (*Profile_ProfileColors) ProtoMessage()    {}
 func (*Profile_ProfileColors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 0}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 0}
 }
 
 func (m *Profile_ProfileColors) XXX_Unmarshal(b []byte) error {
@@ -645,7 +2424,7 @@ func (m *Profile_ContactInfo) Reset()         { *m = Profile_ContactInfo{} }
 func (m *Profile_ContactInfo) String() string { return proto.CompactTextString(m) }
 func (*Profile_ContactInfo) ProtoMessage()    {}
 func (*Profile_ContactInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 1}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 1}
 }
 
 func (m *Profile_ContactInfo) XXX_Unmarshal(b []byte) error {
@@ -707,7 +2486,7 @@ func (m *Profile_ContactInfo_SocialAccount) Reset()         { *m = Profile_Conta
 func (m *Profile_ContactInfo_SocialAccount) String() string { return proto.CompactTextString(m) }
 func (*Profile_ContactInfo_SocialAccount) ProtoMessage()    {}
 func (*Profile_ContactInfo_SocialAccount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 1, 0}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 1, 0}
 }
 
 func (m *Profile_ContactInfo_SocialAccount) XXX_Unmarshal(b []byte) error {
@@ -765,7 +2544,7 @@ func (m *Profile_ProfileStats) Reset()         { *m = Profile_ProfileStats{} }
 func (m *Profile_ProfileStats) String() string { return proto.CompactTextString(m) }
 func (*Profile_ProfileStats) ProtoMessage()    {}
 func (*Profile_ProfileStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 2}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 2}
 }
 
 func (m *Profile_ProfileStats) XXX_Unmarshal(b []byte) error {
@@ -845,7 +2624,7 @@ func (m *Profile_ImageHashes) Reset()         { *m = Profile_ImageHashes{} }
 func (m *Profile_ImageHashes) String() string { return proto.CompactTextString(m) }
 func (*Profile_ImageHashes) ProtoMessage()    {}
 func (*Profile_ImageHashes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 3}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 3}
 }
 
 func (m *Profile_ImageHashes) XXX_Unmarshal(b []byte) error {
@@ -923,7 +2702,7 @@ func (m *Profile_ModeratorInfo) Reset()         { *m = Profile_ModeratorInfo{} }
 func (m *Profile_ModeratorInfo) String() string { return proto.CompactTextString(m) }
 func (*Profile_ModeratorInfo) ProtoMessage()    {}
 func (*Profile_ModeratorInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 4}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 4}
 }
 
 func (m *Profile_ModeratorInfo) XXX_Unmarshal(b []byte) error {
@@ -992,7 +2771,7 @@ func (m *Profile_ModeratorInfo_ModeratorFee) Reset()         { *m = Profile_Mode
 func (m *Profile_ModeratorInfo_ModeratorFee) String() string { return proto.CompactTextString(m) }
 func (*Profile_ModeratorInfo_ModeratorFee) ProtoMessage()    {}
 func (*Profile_ModeratorInfo_ModeratorFee) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 4, 0}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 4, 0}
 }
 
 func (m *Profile_ModeratorInfo_ModeratorFee) XXX_Unmarshal(b []byte) error {
@@ -1046,7 +2825,7 @@ func (m *Profile_Currency) Reset()         { *m = Profile_Currency{} }
 func (m *Profile_Currency) String() string { return proto.CompactTextString(m) }
 func (*Profile_Currency) ProtoMessage()    {}
 func (*Profile_Currency) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 5}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 5}
 }
 
 func (m *Profile_Currency) XXX_Unmarshal(b []byte) error {
@@ -1093,7 +2872,7 @@ func (m *Profile_CurrencyValue) Reset()         { *m = Profile_CurrencyValue{} }
 func (m *Profile_CurrencyValue) String() string { return proto.CompactTextString(m) }
 func (*Profile_CurrencyValue) ProtoMessage()    {}
 func (*Profile_CurrencyValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84c7eabcfe7807d1, []int{8, 6}
+	return fileDescriptor_84c7eabcfe7807d1, []int{30, 6}
 }
 
 func (m *Profile_CurrencyValue) XXX_Unmarshal(b []byte) error {
@@ -1129,6 +2908,8 @@ func (m *Profile_CurrencyValue) GetAmount() string {
 }
 
 func init() {
+	proto.RegisterEnum("pb.SearchRequest_SearchType", SearchRequest_SearchType_name, SearchRequest_SearchType_value)
+	proto.RegisterEnum("pb.SearchRequest_SortBy", SearchRequest_SortBy_name, SearchRequest_SortBy_value)
 	proto.RegisterEnum("pb.Profile_ModeratorInfo_ModeratorFee_FeeType", Profile_ModeratorInfo_ModeratorFee_FeeType_name, Profile_ModeratorInfo_ModeratorFee_FeeType_value)
 	proto.RegisterType((*SubscribeRequest)(nil), "pb.SubscribeRequest")
 	proto.RegisterType((*UserData)(nil), "pb.UserData")
@@ -1138,6 +2919,36 @@ func init() {
 	proto.RegisterType((*BanNodeResponse)(nil), "pb.BanNodeResponse")
 	proto.RegisterType((*UnbanNodeRequest)(nil), "pb.UnbanNodeRequest")
 	proto.RegisterType((*UnbanNodeResponse)(nil), "pb.UnbanNodeResponse")
+	proto.RegisterType((*QuarantineNodeRequest)(nil), "pb.QuarantineNodeRequest")
+	proto.RegisterType((*QuarantineNodeResponse)(nil), "pb.QuarantineNodeResponse")
+	proto.RegisterType((*GetQuotaRequest)(nil), "pb.GetQuotaRequest")
+	proto.RegisterType((*GetQuotaResponse)(nil), "pb.GetQuotaResponse")
+	proto.RegisterType((*SearchRequest)(nil), "pb.SearchRequest")
+	proto.RegisterType((*SearchResponse)(nil), "pb.SearchResponse")
+	proto.RegisterType((*SearchResponse_Result)(nil), "pb.SearchResponse.Result")
+	proto.RegisterType((*SearchResponse_Price)(nil), "pb.SearchResponse.Price")
+	proto.RegisterType((*SearchResponse_Facet)(nil), "pb.SearchResponse.Facet")
+	proto.RegisterType((*SearchResponse_Facet_FacetValue)(nil), "pb.SearchResponse.Facet.FacetValue")
+	proto.RegisterType((*ListModeratorsRequest)(nil), "pb.ListModeratorsRequest")
+	proto.RegisterType((*ListModeratorsResponse)(nil), "pb.ListModeratorsResponse")
+	proto.RegisterType((*ListModeratorsResponse_Moderator)(nil), "pb.ListModeratorsResponse.Moderator")
+	proto.RegisterType((*GetReputationRequest)(nil), "pb.GetReputationRequest")
+	proto.RegisterType((*Reputation)(nil), "pb.Reputation")
+	proto.RegisterType((*VerifiedStats)(nil), "pb.VerifiedStats")
+	proto.RegisterType((*FindDuplicatesRequest)(nil), "pb.FindDuplicatesRequest")
+	proto.RegisterType((*FindDuplicatesResponse)(nil), "pb.FindDuplicatesResponse")
+	proto.RegisterType((*DuplicateCluster)(nil), "pb.DuplicateCluster")
+	proto.RegisterType((*DuplicateCluster_Listing)(nil), "pb.DuplicateCluster.Listing")
+	proto.RegisterType((*FindSimilarImagesRequest)(nil), "pb.FindSimilarImagesRequest")
+	proto.RegisterType((*FindSimilarImagesResponse)(nil), "pb.FindSimilarImagesResponse")
+	proto.RegisterType((*FindSimilarImagesResponse_Image)(nil), "pb.FindSimilarImagesResponse.Image")
+	proto.RegisterType((*ListReviewsRequest)(nil), "pb.ListReviewsRequest")
+	proto.RegisterType((*ListReviewsResponse)(nil), "pb.ListReviewsResponse")
+	proto.RegisterType((*ListReviewsResponse_Review)(nil), "pb.ListReviewsResponse.Review")
+	proto.RegisterType((*ApproveReviewRequest)(nil), "pb.ApproveReviewRequest")
+	proto.RegisterType((*ApproveReviewResponse)(nil), "pb.ApproveReviewResponse")
+	proto.RegisterType((*RejectReviewRequest)(nil), "pb.RejectReviewRequest")
+	proto.RegisterType((*RejectReviewResponse)(nil), "pb.RejectReviewResponse")
 	proto.RegisterType((*Profile)(nil), "pb.Profile")
 	proto.RegisterType((*Profile_ProfileColors)(nil), "pb.Profile.ProfileColors")
 	proto.RegisterType((*Profile_ContactInfo)(nil), "pb.Profile.ContactInfo")
@@ -1153,83 +2964,173 @@ func init() {
 func init() { proto.RegisterFile("crawler.proto", fileDescriptor_84c7eabcfe7807d1) }
 
 var fileDescriptor_84c7eabcfe7807d1 = []byte{
-	// 1207 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x5f, 0x6f, 0xdc, 0x44,
-	0x10, 0xcf, 0xe5, 0x92, 0xdc, 0xdd, 0xdc, 0x5d, 0x9a, 0x6c, 0x4b, 0x31, 0x16, 0x82, 0xe8, 0x54,
-	0x42, 0xd4, 0x07, 0xb7, 0xa4, 0x80, 0xa0, 0x08, 0x50, 0x9b, 0xea, 0xd4, 0x0a, 0x5a, 0x55, 0x4e,
-	0x8b, 0xc4, 0xe3, 0xda, 0x9e, 0xf3, 0xad, 0x64, 0x7b, 0xcd, 0xee, 0x3a, 0xe9, 0x7d, 0x07, 0xbe,
-	0x02, 0xe2, 0x81, 0x8f, 0x86, 0xc4, 0x23, 0x6f, 0x88, 0x57, 0xb4, 0x7f, 0xec, 0xb3, 0xf3, 0x47,
-	0xe1, 0xe9, 0x76, 0x7e, 0xf3, 0xfb, 0xed, 0x8e, 0x67, 0x67, 0xe7, 0x06, 0xa6, 0xb1, 0xa0, 0xe7,
-	0x19, 0x8a, 0xa0, 0x14, 0x5c, 0x71, 0xb2, 0x59, 0x46, 0xfe, 0xc7, 0x29, 0xe7, 0x69, 0x86, 0x0f,
-	0x0c, 0x12, 0x55, 0x8b, 0x07, 0x8a, 0xe5, 0x28, 0x15, 0xcd, 0x4b, 0x4b, 0xf2, 0xa7, 0x19, 0x93,
-	0x8a, 0x15, 0xa9, 0x35, 0x67, 0x04, 0xf6, 0x4e, 0xab, 0x48, 0xc6, 0x82, 0x45, 0x18, 0xe2, 0x2f,
-	0x15, 0x4a, 0x35, 0xfb, 0xa3, 0x07, 0xc3, 0xb7, 0x12, 0xc5, 0x33, 0xaa, 0x28, 0xf9, 0x14, 0x06,
-	0xa5, 0xe0, 0x0b, 0x96, 0xa1, 0xd7, 0x3b, 0xe8, 0x1d, 0x8d, 0x8f, 0xc7, 0x41, 0x19, 0x05, 0xaf,
-	0x2d, 0xf4, 0x7c, 0x23, 0xac, 0xbd, 0xe4, 0x3e, 0x0c, 0xdc, 0xd6, 0xde, 0xa6, 0x21, 0xee, 0x06,
-	0xa7, 0x2c, 0x2d, 0x30, 0xf9, 0xd1, 0xa2, 0x9a, 0xeb, 0x08, 0xe4, 0x31, 0x00, 0xbe, 0x2b, 0x99,
-	0xa0, 0x8a, 0xf1, 0xc2, 0xeb, 0x1b, 0xba, 0x1f, 0xd8, 0xd0, 0x83, 0x3a, 0xf4, 0xe0, 0x4d, 0x1d,
-	0x7a, 0xd8, 0x62, 0x3f, 0xdd, 0x81, 0xad, 0x84, 0x2a, 0x3a, 0x3b, 0x84, 0xbd, 0x13, 0xfd, 0xf9,
-	0xaf, 0x78, 0x52, 0x47, 0x4e, 0x08, 0x6c, 0x95, 0x88, 0xc2, 0x44, 0x3a, 0x0a, 0xcd, 0x7a, 0x76,
-	0x1b, 0xf6, 0x5b, 0x3c, 0x59, 0xf2, 0x42, 0xe2, 0xec, 0x1e, 0xec, 0x3e, 0xa5, 0xc5, 0x4d, 0xd2,
-	0x7d, 0xb8, 0xd5, 0xb0, 0x9c, 0xf0, 0x10, 0xf6, 0xde, 0x16, 0xd1, 0xcd, 0xd2, 0xdb, 0xb0, 0xdf,
-	0xe2, 0x39, 0xf1, 0xaf, 0xfb, 0x30, 0x70, 0x99, 0x23, 0x77, 0x61, 0x47, 0x13, 0x5f, 0x3c, 0x73,
-	0x32, 0x67, 0xe9, 0xcd, 0x0a, 0x9a, 0xa3, 0xc9, 0xe1, 0x28, 0x34, 0x6b, 0xcd, 0x5d, 0xd2, 0x22,
-	0xc9, 0xd0, 0xa4, 0x6a, 0x14, 0x3a, 0x8b, 0xf8, 0x30, 0xcc, 0x78, 0x6c, 0x93, 0xb8, 0x65, 0x3c,
-	0x8d, 0x4d, 0xee, 0xc0, 0x36, 0x8d, 0x78, 0xa5, 0xbc, 0x6d, 0xe3, 0xb0, 0x06, 0xb9, 0x0f, 0x7b,
-	0x72, 0xc9, 0x85, 0x7a, 0x86, 0xfa, 0xca, 0x4b, 0xa3, 0xdc, 0x31, 0x84, 0x4b, 0xb8, 0x89, 0x44,
-	0x2e, 0xce, 0xbd, 0xc1, 0x41, 0xef, 0x68, 0x18, 0x9a, 0xb5, 0x8e, 0xe4, 0x0c, 0x8b, 0x84, 0x0b,
-	0x6f, 0x68, 0x50, 0x67, 0x91, 0x0f, 0x61, 0x94, 0xf3, 0x04, 0x05, 0x55, 0x5c, 0x78, 0x23, 0xe3,
-	0x5a, 0x03, 0xe4, 0x7b, 0x98, 0x36, 0xc6, 0x8b, 0x62, 0xc1, 0x3d, 0x30, 0x37, 0xfe, 0x41, 0xab,
-	0x92, 0x82, 0x97, 0x6d, 0x42, 0xd8, 0xe5, 0x93, 0xaf, 0x61, 0x1c, 0xf3, 0x42, 0xd1, 0x58, 0x19,
-	0xf9, 0xd8, 0xc8, 0xdf, 0x6f, 0xcb, 0x4f, 0xd6, 0xee, 0xb0, 0xcd, 0x25, 0x9f, 0xc1, 0x4e, 0xcc,
-	0x33, 0x2e, 0xa4, 0x37, 0xb9, 0x7c, 0xa8, 0xfb, 0x3d, 0x31, 0x84, 0xd0, 0x11, 0xc9, 0x37, 0x30,
-	0xa1, 0x67, 0x54, 0x51, 0xf1, 0x9c, 0xca, 0x25, 0x4a, 0x6f, 0x7a, 0xf9, 0xb8, 0x17, 0x39, 0x4d,
-	0xd1, 0xba, 0xc3, 0x0e, 0x59, 0x8b, 0x97, 0x48, 0x13, 0xac, 0xc5, 0xbb, 0x37, 0x88, 0xdb, 0x64,
-	0x12, 0xc0, 0xb6, 0x54, 0x54, 0x49, 0xef, 0x96, 0x51, 0x79, 0x57, 0xc4, 0x7a, 0xaa, 0xfd, 0xa1,
-	0xa5, 0xe9, 0xb4, 0x97, 0x55, 0x94, 0xb1, 0xf8, 0x07, 0x5c, 0x79, 0x7b, 0xe6, 0x1e, 0xd7, 0x00,
-	0xf9, 0x12, 0xee, 0x4a, 0xc5, 0x05, 0x3e, 0x29, 0x92, 0x39, 0x17, 0xe7, 0x54, 0x24, 0xa7, 0x28,
-	0xce, 0x50, 0x48, 0x6f, 0xff, 0xa0, 0x7f, 0x34, 0x0a, 0xaf, 0xf1, 0x92, 0xef, 0x60, 0x92, 0x51,
-	0xa9, 0x5e, 0xf2, 0x84, 0x2d, 0x18, 0x26, 0x1e, 0xb9, 0xf1, 0x7d, 0x76, 0xf8, 0xfe, 0xef, 0x3d,
-	0x98, 0x76, 0x32, 0x4b, 0x3c, 0xdd, 0x44, 0x58, 0x4e, 0xc5, 0xca, 0x55, 0x7b, 0x6d, 0xea, 0x2f,
-	0x90, 0x18, 0xf3, 0x22, 0xd1, 0x3e, 0x5b, 0xf3, 0x6b, 0x40, 0x97, 0xa0, 0xc2, 0x77, 0xca, 0x95,
-	0xbd, 0x59, 0x6b, 0xc5, 0x92, 0xa5, 0xcb, 0x8c, 0xa5, 0x4b, 0xe5, 0xaa, 0x7e, 0x0d, 0x90, 0x7b,
-	0x30, 0x6d, 0x8c, 0x37, 0x5a, 0x6a, 0xcb, 0xbf, 0x0b, 0xfa, 0xff, 0xf4, 0x60, 0xdc, 0xaa, 0x18,
-	0x1d, 0xdf, 0x39, 0x46, 0x92, 0x29, 0xac, 0xe3, 0x73, 0xa6, 0x7e, 0x46, 0x98, 0x53, 0x96, 0xb9,
-	0xd8, 0xac, 0x41, 0x0e, 0x60, 0x5c, 0x2e, 0x79, 0x81, 0xaf, 0xaa, 0x3c, 0x42, 0xe1, 0xc2, 0x6b,
-	0x43, 0xe4, 0x5b, 0xd8, 0x91, 0x3c, 0x66, 0x34, 0xf3, 0xb6, 0x0e, 0xfa, 0x47, 0xe3, 0xe3, 0x4f,
-	0xae, 0x29, 0xd6, 0xe0, 0xd4, 0xb0, 0x9e, 0xc4, 0x31, 0xaf, 0x0a, 0x15, 0x3a, 0x91, 0xff, 0x16,
-	0xa6, 0x1d, 0x87, 0xc9, 0xc4, 0xaa, 0xac, 0xc3, 0x33, 0x6b, 0xfd, 0xfc, 0x2b, 0x89, 0xa2, 0xd5,
-	0x2e, 0x1a, 0x5b, 0xc7, 0x5d, 0x0a, 0xce, 0x17, 0x2e, 0x36, 0x6b, 0xf8, 0x7f, 0xf5, 0x60, 0xd2,
-	0xae, 0x23, 0x9d, 0xae, 0x05, 0xcf, 0x32, 0x7e, 0x8e, 0xe2, 0x44, 0x9f, 0x63, 0xf6, 0x9f, 0x86,
-	0x5d, 0x90, 0x1c, 0xc2, 0xae, 0x05, 0x58, 0x91, 0x5a, 0xda, 0xa6, 0xa1, 0x5d, 0x40, 0xc9, 0x0c,
-	0x26, 0xae, 0xc3, 0x5b, 0x56, 0xdf, 0xb0, 0x3a, 0x98, 0x4e, 0x9d, 0x6e, 0xe4, 0x35, 0x65, 0xcb,
-	0x50, 0xda, 0x90, 0x29, 0x6a, 0x2e, 0x95, 0xf5, 0x6f, 0x1b, 0xff, 0x1a, 0xd0, 0x11, 0xd3, 0x33,
-	0x14, 0x34, 0xc5, 0xd0, 0x68, 0x4c, 0xfb, 0xda, 0x0c, 0xbb, 0xa0, 0xff, 0x5b, 0x0f, 0xc6, 0xad,
-	0x67, 0x66, 0xd2, 0xc7, 0x8a, 0x55, 0x93, 0x3e, 0x56, 0xac, 0x74, 0x8a, 0x64, 0x4e, 0xb3, 0xe6,
-	0x6a, 0x8d, 0xa1, 0x3b, 0x5c, 0x8e, 0x09, 0xab, 0xf2, 0xba, 0xd7, 0x5a, 0x4b, 0xb3, 0x33, 0x2a,
-	0x52, 0x74, 0x25, 0x67, 0x0d, 0x7d, 0x05, 0x5c, 0xb0, 0x94, 0x15, 0x34, 0x73, 0x95, 0xd6, 0xd8,
-	0xda, 0xa7, 0x13, 0x6d, 0xae, 0xc7, 0xf6, 0xd8, 0xc6, 0xf6, 0xff, 0xec, 0xc3, 0xb4, 0xd3, 0xf1,
-	0x74, 0x5e, 0x92, 0x56, 0x53, 0xb6, 0x81, 0xb6, 0x21, 0x12, 0x00, 0x51, 0x28, 0x72, 0xf9, 0xa4,
-	0x48, 0x4e, 0x78, 0x91, 0x30, 0x0d, 0x4a, 0x17, 0xfc, 0x15, 0x1e, 0x9d, 0xc7, 0x8c, 0x16, 0x69,
-	0x45, 0x53, 0x94, 0x5e, 0xdf, 0xbc, 0xf8, 0x35, 0xa0, 0x77, 0xa3, 0x71, 0x8c, 0xa5, 0xc2, 0xe4,
-	0xa4, 0x12, 0x02, 0x8b, 0x98, 0xa1, 0x34, 0xc5, 0x3a, 0x0a, 0xaf, 0xf0, 0x90, 0xaf, 0xa0, 0xbf,
-	0x40, 0x34, 0x1f, 0x39, 0x3e, 0x3e, 0xbc, 0xb6, 0x73, 0xaf, 0xad, 0x39, 0x62, 0xa8, 0x25, 0xfe,
-	0xbf, 0x3d, 0x98, 0xb4, 0x51, 0xf2, 0x85, 0x4e, 0xcc, 0x3b, 0x4c, 0xe6, 0x58, 0xcf, 0x14, 0x9d,
-	0xa6, 0xec, 0x0e, 0x5d, 0xfd, 0x44, 0xb3, 0x0a, 0xc3, 0x86, 0x4a, 0x3e, 0x02, 0x28, 0x51, 0xc4,
-	0x58, 0x28, 0x9a, 0xda, 0x82, 0xdf, 0x0c, 0x5b, 0x08, 0x79, 0x0e, 0x83, 0x05, 0xe2, 0x1b, 0xfd,
-	0x4a, 0xf4, 0xd5, 0xed, 0x1e, 0x07, 0xff, 0x2f, 0xca, 0x60, 0x6e, 0x55, 0x61, 0x2d, 0x9f, 0xcd,
-	0x61, 0xe0, 0x30, 0x32, 0x81, 0xe1, 0xdc, 0x05, 0xb0, 0xb7, 0x41, 0xf6, 0x61, 0xfa, 0xba, 0x39,
-	0x50, 0x43, 0x3d, 0xe2, 0xc3, 0x5d, 0x43, 0x78, 0x9d, 0x55, 0xb2, 0xeb, 0xdb, 0xf4, 0x9f, 0xc2,
-	0xb0, 0xfe, 0x18, 0x5d, 0x81, 0x31, 0x4f, 0x9a, 0x07, 0xac, 0xd7, 0xfa, 0xbd, 0x24, 0xec, 0x8c,
-	0x49, 0x16, 0xb1, 0x8c, 0xa9, 0x95, 0x7b, 0x55, 0x1d, 0xcc, 0xff, 0x19, 0xa6, 0x9d, 0x84, 0x90,
-	0x87, 0x30, 0x8c, 0x1d, 0xe0, 0xb2, 0x77, 0xe7, 0xaa, 0xec, 0x85, 0x0d, 0x4b, 0x97, 0x34, 0xcd,
-	0x9b, 0x67, 0x3b, 0x0a, 0x9d, 0x75, 0xfc, 0x77, 0x0f, 0x46, 0x3c, 0x72, 0x33, 0x24, 0x79, 0x04,
-	0xa3, 0x66, 0x12, 0x24, 0x66, 0xcb, 0x8b, 0x83, 0xa1, 0x3f, 0xd1, 0x68, 0x3d, 0x19, 0xce, 0x36,
-	0x1e, 0xf6, 0xc8, 0x63, 0x18, 0x35, 0xc3, 0x95, 0x15, 0x5d, 0x9c, 0xc9, 0xfc, 0xf7, 0x2e, 0xa0,
-	0x6e, 0x16, 0xda, 0x20, 0x9f, 0xc3, 0xc0, 0x4d, 0x57, 0x84, 0x68, 0x4e, 0x77, 0x20, 0xf3, 0x6f,
-	0x77, 0xb0, 0x46, 0xf5, 0x18, 0x46, 0xcd, 0x60, 0x65, 0x4f, 0xbc, 0x38, 0x8f, 0xd9, 0x13, 0x2f,
-	0x4f, 0x5f, 0x1b, 0xd1, 0x8e, 0xf9, 0xeb, 0x7a, 0xf4, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x90,
-	0x7c, 0xe3, 0xfe, 0x38, 0x0b, 0x00, 0x00,
+	// 2648 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcd, 0x6f, 0x1c, 0x49,
+	0x15, 0x4f, 0xcf, 0xd8, 0xf3, 0xf1, 0xec, 0x71, 0xc6, 0x15, 0xc7, 0xe9, 0x6d, 0x85, 0x25, 0x1a,
+	0xb2, 0xc1, 0xda, 0xc3, 0x6c, 0xd6, 0xfb, 0x41, 0x96, 0xc0, 0xae, 0x9c, 0xb1, 0x9d, 0x35, 0x24,
+	0xc1, 0x5b, 0xe3, 0xdd, 0x15, 0x27, 0x54, 0xd3, 0x5d, 0x9e, 0x29, 0xd4, 0xd3, 0xdd, 0xa9, 0xae,
+	0xf1, 0xc7, 0x9e, 0x39, 0x21, 0x4e, 0x1c, 0xb8, 0x00, 0x42, 0x42, 0x5c, 0x90, 0xb8, 0xf3, 0x3f,
+	0xf0, 0x3f, 0x70, 0x42, 0xe2, 0xc6, 0x11, 0xed, 0x11, 0xf4, 0xaa, 0xaa, 0xbf, 0x66, 0xc6, 0xf6,
+	0x8a, 0x13, 0x97, 0x64, 0xde, 0xef, 0xfd, 0x5e, 0x75, 0xd5, 0xab, 0x57, 0x55, 0xef, 0x3d, 0x43,
+	0xc7, 0x97, 0xec, 0x3c, 0xe4, 0xb2, 0x9f, 0xc8, 0x58, 0xc5, 0xa4, 0x96, 0x8c, 0xbc, 0x6f, 0x8f,
+	0xe3, 0x78, 0x1c, 0xf2, 0x77, 0x34, 0x32, 0x9a, 0x9d, 0xbe, 0xa3, 0xc4, 0x94, 0xa7, 0x8a, 0x4d,
+	0x13, 0x43, 0xf2, 0x3a, 0xa1, 0x48, 0x95, 0x88, 0xc6, 0x46, 0xec, 0x3d, 0x86, 0xee, 0x70, 0x36,
+	0x4a, 0x7d, 0x29, 0x46, 0x9c, 0xf2, 0xd7, 0x33, 0x9e, 0x2a, 0x72, 0x1f, 0xda, 0xd3, 0x38, 0xe0,
+	0x92, 0xa9, 0x58, 0xba, 0xce, 0x03, 0x67, 0xa7, 0x45, 0x0b, 0xa0, 0xf7, 0x9b, 0x3a, 0xb4, 0x3e,
+	0x4f, 0xb9, 0xdc, 0x67, 0x8a, 0x91, 0xef, 0x42, 0x33, 0x91, 0xf1, 0xa9, 0x08, 0xb9, 0x26, 0xae,
+	0xed, 0xae, 0xf5, 0x93, 0x51, 0xff, 0xd8, 0x40, 0x9f, 0xde, 0xa2, 0x99, 0x96, 0xbc, 0x0d, 0x4d,
+	0xfb, 0x61, 0xb7, 0xa6, 0x89, 0x1b, 0xfd, 0xa1, 0x18, 0x47, 0x3c, 0x78, 0x61, 0x50, 0xe4, 0x5a,
+	0x02, 0xf9, 0x3e, 0x00, 0xbf, 0x48, 0x84, 0x64, 0x4a, 0xc4, 0x91, 0x5b, 0xd7, 0x74, 0xaf, 0x6f,
+	0x16, 0xd6, 0xcf, 0x16, 0xd6, 0x3f, 0xc9, 0x16, 0x46, 0x4b, 0x6c, 0xd2, 0x07, 0x90, 0x3c, 0x99,
+	0x29, 0x63, 0xbb, 0x62, 0x3f, 0x95, 0x8c, 0xfa, 0x34, 0x47, 0x69, 0x89, 0x41, 0xbe, 0x07, 0x9d,
+	0x33, 0x2e, 0xc5, 0xa9, 0xe0, 0xc1, 0x50, 0x31, 0x95, 0xba, 0xab, 0xda, 0x64, 0x13, 0x4d, 0xbe,
+	0x28, 0x2b, 0x68, 0x95, 0x47, 0xde, 0x07, 0x08, 0x66, 0x49, 0x28, 0x7c, 0xa6, 0x78, 0xea, 0x36,
+	0xb4, 0xd5, 0x16, 0x5a, 0xed, 0x67, 0xe8, 0x20, 0x9c, 0xa5, 0x8a, 0x4b, 0x5a, 0xe2, 0x11, 0x17,
+	0x9a, 0x7e, 0x9c, 0x5c, 0xfa, 0x4c, 0xb9, 0x4d, 0xed, 0xd8, 0x4c, 0x24, 0xdb, 0xd0, 0x08, 0xd9,
+	0x88, 0x87, 0xa9, 0xdb, 0x7a, 0x50, 0xdf, 0x69, 0x53, 0x2b, 0x91, 0x07, 0xb0, 0xf6, 0x7a, 0xc6,
+	0x24, 0x8b, 0x94, 0x88, 0x78, 0xe0, 0xb6, 0xb5, 0x55, 0x19, 0x7a, 0xd6, 0x80, 0x95, 0x80, 0x29,
+	0xd6, 0x7b, 0x04, 0xdd, 0x01, 0xc6, 0xc3, 0xab, 0x38, 0xc8, 0xb7, 0x92, 0xc0, 0x4a, 0xc2, 0xb9,
+	0xd9, 0xc5, 0x36, 0xd5, 0xbf, 0x7b, 0x77, 0x60, 0xb3, 0xc4, 0x4b, 0x93, 0x38, 0x4a, 0x79, 0xef,
+	0x21, 0x6c, 0x3c, 0x63, 0xd1, 0x4d, 0xa6, 0x9b, 0x70, 0x3b, 0x67, 0x59, 0xc3, 0x47, 0xd0, 0xfd,
+	0x3c, 0x1a, 0xdd, 0x6c, 0x7a, 0x07, 0x36, 0x4b, 0x3c, 0x6b, 0x3c, 0x80, 0xbb, 0x9f, 0xe5, 0x2b,
+	0xb9, 0x61, 0x04, 0xf4, 0x90, 0xe4, 0x2c, 0x8d, 0x23, 0x1d, 0x41, 0x6d, 0x6a, 0xa5, 0x9e, 0x0b,
+	0xdb, 0xf3, 0x83, 0xd8, 0xe1, 0xdf, 0x82, 0xdb, 0xcf, 0xb9, 0xfa, 0x6c, 0x16, 0x2b, 0x76, 0xdd,
+	0xd4, 0x02, 0xe8, 0x16, 0x34, 0x63, 0x8a, 0x67, 0x60, 0x2c, 0x59, 0x32, 0x19, 0x8a, 0xaf, 0x4c,
+	0x68, 0xaf, 0xd0, 0x02, 0x20, 0x5b, 0xb0, 0xfa, 0x1a, 0xe9, 0x7a, 0x26, 0x2b, 0xd4, 0x08, 0x68,
+	0x13, 0x9f, 0x71, 0xa9, 0x07, 0xd2, 0x61, 0xdb, 0xa2, 0x05, 0xd0, 0xfb, 0xe5, 0x0a, 0x74, 0x86,
+	0x9c, 0x49, 0x7f, 0x92, 0xcd, 0x45, 0x8f, 0xc2, 0xe5, 0xa5, 0x9d, 0x8c, 0x11, 0xc8, 0x63, 0x58,
+	0x51, 0x97, 0x09, 0xd7, 0x43, 0x6f, 0xec, 0xde, 0xc7, 0x90, 0xaa, 0x98, 0x59, 0xe9, 0xe4, 0x32,
+	0xe1, 0x54, 0x33, 0xc9, 0x9b, 0x00, 0x18, 0x5d, 0xe3, 0x58, 0x0a, 0x9e, 0xba, 0x75, 0x1d, 0x3e,
+	0x25, 0x44, 0xeb, 0x67, 0x52, 0xf2, 0xc8, 0x47, 0xfd, 0x8a, 0xd5, 0xe7, 0x88, 0xd6, 0xc7, 0x51,
+	0x20, 0xf0, 0x40, 0xe0, 0x01, 0x30, 0xfa, 0x1c, 0x21, 0x0f, 0xa1, 0xe3, 0xc7, 0x91, 0x92, 0xcc,
+	0x57, 0xf8, 0x55, 0x8c, 0x76, 0xa4, 0x54, 0x41, 0x0c, 0xed, 0x74, 0x22, 0x92, 0xf4, 0x24, 0x76,
+	0x9b, 0x5a, 0x9f, 0x89, 0xe4, 0x31, 0x34, 0xd2, 0x58, 0xaa, 0x67, 0x97, 0x6e, 0x4b, 0xaf, 0xc9,
+	0x5d, 0xb2, 0x26, 0xad, 0xa7, 0x96, 0xa7, 0x77, 0x89, 0x8d, 0xb9, 0x8e, 0xf6, 0x0e, 0xd5, 0xbf,
+	0x89, 0x07, 0x2d, 0xfc, 0x5f, 0x6f, 0x08, 0x68, 0x3c, 0x97, 0x51, 0x37, 0x15, 0xd1, 0xb1, 0x14,
+	0x3e, 0x77, 0xd7, 0x1e, 0x38, 0x3b, 0x0e, 0xcd, 0x65, 0xad, 0x63, 0x17, 0x46, 0xb7, 0x6e, 0x75,
+	0x56, 0xee, 0xed, 0x00, 0x14, 0xde, 0x24, 0xeb, 0xd0, 0x7a, 0x71, 0x34, 0x3c, 0x39, 0x7a, 0xf5,
+	0x7c, 0xd8, 0xbd, 0x85, 0xd2, 0x31, 0xfd, 0xc9, 0xe1, 0xd1, 0x8b, 0x83, 0x61, 0xd7, 0xe9, 0xbd,
+	0x84, 0x86, 0x99, 0x23, 0xe9, 0x40, 0x9b, 0x1e, 0xbc, 0x38, 0xf8, 0x62, 0xef, 0xd5, 0xe0, 0xa0,
+	0x7b, 0x8b, 0x00, 0x34, 0x5e, 0x1d, 0x7c, 0x79, 0x30, 0x3c, 0xe9, 0x3a, 0xa4, 0x0d, 0xab, 0x27,
+	0x47, 0x27, 0x2f, 0x0e, 0xba, 0x35, 0x64, 0x1d, 0xd3, 0xa3, 0xc1, 0xc1, 0xcf, 0xf6, 0x86, 0x83,
+	0x6e, 0x9d, 0x6c, 0x00, 0x18, 0x71, 0xff, 0x60, 0x38, 0xe8, 0xae, 0xf4, 0xbe, 0xae, 0xc3, 0x46,
+	0xe6, 0x01, 0x1b, 0x71, 0x5b, 0xb0, 0xaa, 0x62, 0xc5, 0x42, 0x1d, 0x0d, 0x1d, 0x6a, 0x04, 0xf2,
+	0x1e, 0x34, 0x25, 0x4f, 0x67, 0xa1, 0x4a, 0xdd, 0xda, 0x83, 0xfa, 0xce, 0xda, 0xee, 0x1b, 0x65,
+	0xe7, 0x19, 0xd3, 0x3e, 0xd5, 0x0c, 0x9a, 0x31, 0xd1, 0xe1, 0xa7, 0xcc, 0xe7, 0xca, 0x04, 0xc3,
+	0xda, 0xae, 0xbb, 0xc4, 0xe6, 0x10, 0x09, 0xd4, 0xf2, 0x70, 0x8b, 0x13, 0xf4, 0xc8, 0xc0, 0x44,
+	0xc5, 0xa5, 0xbe, 0x39, 0xdb, 0xb4, 0x0a, 0x7a, 0x09, 0x34, 0xcc, 0xa7, 0xc8, 0x03, 0x73, 0xe7,
+	0xd8, 0x4b, 0x7f, 0x1d, 0xc7, 0xcf, 0xde, 0x04, 0xaa, 0x35, 0xb8, 0x9c, 0xd4, 0x8f, 0xa5, 0x89,
+	0xe3, 0x0e, 0x35, 0x02, 0xe9, 0xc3, 0xaa, 0x1e, 0xd2, 0xde, 0xea, 0xcb, 0x26, 0xa6, 0x77, 0x86,
+	0x1a, 0x9a, 0xf7, 0x14, 0x56, 0xcd, 0x2e, 0x6e, 0x43, 0x83, 0x4d, 0xe3, 0x59, 0xa4, 0xf4, 0x27,
+	0x1d, 0x6a, 0x25, 0xdc, 0x5d, 0x3f, 0x9b, 0xb3, 0xb9, 0x16, 0x72, 0xd9, 0xfb, 0xb5, 0x03, 0xab,
+	0x7a, 0x99, 0x18, 0x4f, 0x11, 0x9b, 0xf2, 0xec, 0xd4, 0xe3, 0x6f, 0xf2, 0x14, 0x1a, 0x67, 0x2c,
+	0x9c, 0xf1, 0xcc, 0xb1, 0xdf, 0xb9, 0xca, 0x49, 0xe6, 0xdf, 0x2f, 0x90, 0x4b, 0xad, 0x89, 0xf7,
+	0x04, 0xa0, 0x40, 0x71, 0xad, 0x1a, 0xcf, 0x0e, 0xf2, 0x59, 0x86, 0xfa, 0x7a, 0xc6, 0xd6, 0x03,
+	0x5a, 0xe8, 0xfd, 0xd5, 0x81, 0xbb, 0xf8, 0xe6, 0xbd, 0xcc, 0x1e, 0xd4, 0x34, 0xbb, 0x0e, 0xca,
+	0x4b, 0x71, 0xaa, 0x4b, 0x41, 0x5d, 0xc8, 0xa2, 0xf1, 0x0c, 0x0f, 0x85, 0x5d, 0x66, 0x26, 0xe3,
+	0xde, 0x61, 0x40, 0x73, 0xe9, 0xf3, 0x48, 0xb1, 0xb1, 0xf1, 0xad, 0x43, 0xab, 0x20, 0xbe, 0x23,
+	0x53, 0x76, 0x71, 0x28, 0x2e, 0x78, 0x70, 0xc8, 0xb9, 0xde, 0x5f, 0x87, 0x96, 0x21, 0xbc, 0x06,
+	0x52, 0xce, 0xa3, 0x2f, 0x85, 0x9a, 0x88, 0x48, 0xbf, 0x83, 0x1d, 0x5a, 0x42, 0x7a, 0x7f, 0xac,
+	0xc1, 0xf6, 0xfc, 0xcc, 0x6d, 0xec, 0xee, 0x03, 0xe4, 0x09, 0x42, 0xea, 0x3a, 0xda, 0x9f, 0x0f,
+	0xd1, 0x9f, 0xcb, 0xf9, 0xfd, 0x1c, 0xa2, 0x25, 0xbb, 0xc5, 0x20, 0xac, 0x2d, 0x0b, 0xc2, 0xdf,
+	0x3a, 0xd0, 0xce, 0xed, 0xbf, 0x41, 0x20, 0x7e, 0x88, 0xae, 0x4b, 0xd5, 0x90, 0xf3, 0xc8, 0xad,
+	0xdd, 0x98, 0x4b, 0xe4, 0x5c, 0xf2, 0x3e, 0xb4, 0x4e, 0x33, 0x6f, 0xdd, 0x14, 0xad, 0x39, 0xb3,
+	0xf7, 0x36, 0x6c, 0x3d, 0xe7, 0xaa, 0x94, 0x6c, 0x5c, 0xf3, 0xee, 0xfc, 0xa9, 0x06, 0x50, 0x30,
+	0x71, 0x87, 0x30, 0x89, 0x89, 0xc6, 0x83, 0x3c, 0xce, 0x3b, 0xb4, 0x0c, 0xe1, 0x15, 0x8b, 0xef,
+	0x09, 0x0b, 0x43, 0xbd, 0x12, 0x87, 0x66, 0x22, 0x6a, 0x5e, 0xcf, 0x58, 0x28, 0xd4, 0xa5, 0xdd,
+	0xfd, 0x4c, 0xc4, 0x51, 0x03, 0x8e, 0xf9, 0x5d, 0x92, 0x67, 0x44, 0x0e, 0x2d, 0x43, 0xe8, 0xf6,
+	0x80, 0x87, 0xe2, 0x8c, 0xcb, 0xcb, 0x61, 0xc2, 0x79, 0xa0, 0xb7, 0xde, 0xa1, 0x55, 0x90, 0xec,
+	0xc0, 0x6d, 0x7f, 0x96, 0xaa, 0x78, 0xca, 0xe5, 0x90, 0xcb, 0x33, 0x3c, 0xc3, 0x0d, 0xcd, 0x9b,
+	0x87, 0x8b, 0x93, 0xdf, 0xd4, 0x7a, 0x23, 0x90, 0x27, 0xd0, 0x46, 0xd7, 0x52, 0xa6, 0x78, 0xe0,
+	0xb6, 0x6e, 0xdc, 0x87, 0x82, 0xdc, 0xfb, 0x97, 0x03, 0x9d, 0x4a, 0x2a, 0x86, 0x33, 0x3e, 0x8d,
+	0xc3, 0x30, 0x3e, 0xe7, 0xb2, 0xec, 0xab, 0x2a, 0x48, 0x1e, 0xc1, 0x86, 0x01, 0x72, 0x97, 0x9a,
+	0x83, 0x38, 0x87, 0x92, 0x1e, 0xac, 0xdb, 0xcc, 0xd3, 0xb0, 0xea, 0x9a, 0x55, 0xc1, 0xe6, 0xf7,
+	0x66, 0x65, 0x71, 0x6f, 0x1e, 0x42, 0x87, 0xe1, 0x66, 0x8c, 0x39, 0xd5, 0xa8, 0xf6, 0x62, 0x8d,
+	0x56, 0x41, 0xfc, 0x96, 0x88, 0xfc, 0x38, 0x4a, 0x45, 0xaa, 0x78, 0xa4, 0xec, 0x4b, 0x5a, 0xc1,
+	0x7a, 0x9f, 0xc0, 0xdd, 0x43, 0x11, 0x05, 0x79, 0x1e, 0x99, 0x5e, 0x97, 0x14, 0x11, 0x58, 0x49,
+	0xc3, 0xd9, 0xd8, 0x1e, 0x15, 0xfd, 0xbb, 0xf7, 0x23, 0xd8, 0x9e, 0x1f, 0xc0, 0x9e, 0xd3, 0xc7,
+	0xd0, 0xf2, 0x4d, 0x56, 0x9a, 0x9d, 0xd2, 0xe5, 0x29, 0x6b, 0xce, 0xea, 0xfd, 0xa5, 0x06, 0xdd,
+	0x79, 0x35, 0x79, 0x02, 0x2d, 0xeb, 0x9d, 0x6c, 0x98, 0xfb, 0xcb, 0x86, 0xe9, 0xdb, 0xdc, 0x9e,
+	0xe6, 0x6c, 0xef, 0xef, 0x0e, 0x34, 0x2d, 0xfa, 0x4d, 0x97, 0x43, 0xba, 0x50, 0xf7, 0x45, 0xa0,
+	0xb7, 0xa5, 0x4d, 0xf1, 0xa7, 0x7e, 0x2a, 0x85, 0x0a, 0xb9, 0x7d, 0xa5, 0x8c, 0x40, 0x3e, 0x86,
+	0xf5, 0x53, 0x21, 0x53, 0xa5, 0x93, 0x5b, 0x1b, 0xc6, 0xd7, 0x07, 0x59, 0x85, 0xaf, 0xef, 0x3f,
+	0x31, 0x15, 0x21, 0x93, 0x78, 0x8c, 0x4c, 0x70, 0x97, 0x10, 0xdc, 0xbb, 0x74, 0xc2, 0x24, 0x0f,
+	0x8e, 0xa6, 0x6c, 0xcc, 0x53, 0x1d, 0xde, 0x1d, 0x5a, 0xc1, 0x7a, 0x5f, 0x81, 0x8b, 0xae, 0x1f,
+	0x1a, 0x2b, 0x03, 0x66, 0xdb, 0x67, 0xd7, 0xe1, 0x54, 0xd6, 0x11, 0x7c, 0xca, 0xd2, 0x49, 0x96,
+	0x46, 0x6a, 0x01, 0xd1, 0x44, 0xa3, 0x75, 0x83, 0x6a, 0xc1, 0xde, 0xdf, 0xfb, 0x22, 0x55, 0x2c,
+	0xf2, 0x79, 0x16, 0x81, 0x25, 0xa8, 0xf7, 0x8b, 0x1a, 0xbc, 0xb1, 0xe4, 0xe3, 0x76, 0xeb, 0x9f,
+	0x42, 0x43, 0x98, 0x79, 0x3b, 0xc5, 0x73, 0x77, 0x25, 0xbd, 0xaf, 0x45, 0x6a, 0x4d, 0xbc, 0x3f,
+	0x3b, 0xb0, 0xaa, 0x91, 0xe5, 0x8b, 0x38, 0x17, 0x81, 0x9a, 0x64, 0xcf, 0x9c, 0x16, 0xf0, 0xbd,
+	0x9e, 0x70, 0x31, 0x9e, 0x64, 0xc7, 0xc9, 0x4a, 0xfa, 0xb2, 0xc1, 0xf5, 0x54, 0x16, 0xb2, 0x4a,
+	0xab, 0x20, 0xb2, 0x92, 0x0a, 0x6b, 0xd5, 0xb0, 0x2a, 0xa0, 0x76, 0x14, 0xc7, 0x50, 0x36, 0xa7,
+	0xc8, 0x08, 0xbd, 0x1d, 0x20, 0x18, 0x61, 0x94, 0x9f, 0x09, 0x7e, 0x7e, 0xdd, 0xd9, 0xe9, 0xfd,
+	0xae, 0x06, 0x77, 0x2a, 0x54, 0xeb, 0xaa, 0x27, 0x98, 0x73, 0x69, 0xc8, 0xfa, 0xea, 0xcd, 0xec,
+	0x29, 0x9b, 0x63, 0xf6, 0x8d, 0x4c, 0x33, 0xba, 0xf7, 0x37, 0x07, 0x33, 0x24, 0xfc, 0xbd, 0x34,
+	0xba, 0xad, 0xf3, 0x6a, 0x85, 0xf3, 0xb2, 0x78, 0xaf, 0x97, 0xe2, 0xbd, 0xa8, 0x04, 0x57, 0x2a,
+	0x95, 0x60, 0x51, 0xff, 0xac, 0x96, 0xeb, 0x1f, 0xf2, 0x3e, 0x34, 0x7d, 0xc9, 0xf5, 0xbd, 0xda,
+	0xb8, 0x31, 0xe4, 0x33, 0x6a, 0xfe, 0x70, 0x36, 0xaf, 0x7a, 0x38, 0x7b, 0x3f, 0x80, 0xad, 0xbd,
+	0x24, 0x91, 0xf1, 0x19, 0xb7, 0xcb, 0xbc, 0xe6, 0x1a, 0x5a, 0x58, 0x59, 0xef, 0x1e, 0xdc, 0x9d,
+	0xb3, 0xb6, 0x45, 0xd9, 0x53, 0xb8, 0x43, 0xf9, 0xcf, 0xb9, 0xaf, 0xfe, 0x97, 0x51, 0xb7, 0x61,
+	0xab, 0x6a, 0x6c, 0x07, 0xfd, 0xd5, 0x26, 0x34, 0x6d, 0xd7, 0x01, 0xfd, 0x84, 0xd6, 0x47, 0xfb,
+	0x76, 0x2c, 0x2b, 0xe5, 0x49, 0x60, 0xad, 0x94, 0x04, 0x62, 0x98, 0xb2, 0x28, 0x08, 0xb9, 0xdd,
+	0x01, 0x2b, 0xe9, 0x7c, 0x2b, 0xf6, 0x8b, 0x26, 0x42, 0x9b, 0xe6, 0x32, 0x86, 0x1d, 0x1b, 0xc5,
+	0x33, 0x65, 0xb7, 0xc1, 0x08, 0xe4, 0x6d, 0xe8, 0xa6, 0x93, 0x58, 0xaa, 0xfd, 0xd2, 0x63, 0xdb,
+	0xd0, 0x84, 0x05, 0x5c, 0xcf, 0x24, 0x3d, 0x3d, 0xb7, 0x2d, 0x00, 0xfd, 0x1b, 0x67, 0x72, 0xc6,
+	0xa3, 0x20, 0x96, 0xfa, 0x71, 0x6c, 0x51, 0x2b, 0x55, 0x9b, 0x31, 0xed, 0xb9, 0x66, 0x0c, 0xf9,
+	0x04, 0x3a, 0xb9, 0x70, 0x14, 0x9d, 0xc6, 0xba, 0x32, 0xb2, 0x45, 0x82, 0xf5, 0x47, 0xff, 0x65,
+	0x99, 0x40, 0xab, 0x7c, 0xf2, 0x11, 0xac, 0x61, 0x19, 0xc7, 0x7c, 0xa5, 0xcd, 0xd7, 0xb4, 0xf9,
+	0xbd, 0xb2, 0xf9, 0xa0, 0x50, 0xd3, 0x32, 0x97, 0xbc, 0x0b, 0x0d, 0x3f, 0x0e, 0x31, 0xe1, 0x5b,
+	0x5f, 0xfc, 0xa8, 0xfd, 0x7f, 0xa0, 0x09, 0xd4, 0x12, 0xc9, 0x53, 0x58, 0x67, 0x67, 0x4c, 0x31,
+	0x89, 0xe7, 0x98, 0xa7, 0x6e, 0x67, 0xf1, 0x73, 0xfa, 0x9a, 0x31, 0x6a, 0x5a, 0x21, 0xa3, 0xf1,
+	0x84, 0xb3, 0x80, 0x67, 0xc6, 0x1b, 0x37, 0x18, 0x97, 0xc9, 0x58, 0x78, 0xa4, 0xba, 0xbf, 0x73,
+	0xbb, 0x48, 0xe5, 0xe6, 0xe6, 0x6a, 0xda, 0x3c, 0x86, 0x86, 0x6e, 0x4f, 0x66, 0xa3, 0x50, 0xf8,
+	0x3f, 0xe6, 0x97, 0x6e, 0x57, 0xef, 0x63, 0x01, 0x90, 0x0f, 0x61, 0x3b, 0x55, 0xb1, 0xe4, 0x7b,
+	0x51, 0x70, 0x18, 0xcb, 0x73, 0x26, 0x03, 0x4c, 0x7e, 0xf0, 0x2a, 0xda, 0xd4, 0x47, 0xf6, 0x0a,
+	0x2d, 0x3e, 0x51, 0x98, 0xd7, 0xbc, 0x8c, 0x03, 0x9d, 0xcd, 0xb8, 0xe4, 0xe6, 0x27, 0xaa, 0xcc,
+	0xf7, 0xfe, 0xe0, 0x40, 0xa7, 0xe2, 0x59, 0x4c, 0xfc, 0x12, 0x29, 0xa6, 0x2c, 0xef, 0x22, 0x64,
+	0x22, 0xae, 0x20, 0xe5, 0x58, 0xc5, 0xa3, 0xce, 0xc4, 0x7c, 0x01, 0x60, 0x08, 0x2a, 0x7e, 0xa1,
+	0xb2, 0x8b, 0x07, 0x7f, 0xa3, 0xc5, 0x44, 0x8c, 0x27, 0xa1, 0xbe, 0xb6, 0x4d, 0xd4, 0x17, 0x00,
+	0xde, 0xc9, 0xb9, 0x70, 0xc2, 0x2f, 0xb2, 0xf0, 0xaf, 0x82, 0xde, 0xbf, 0x1d, 0x58, 0x2b, 0x45,
+	0x0c, 0xce, 0xef, 0x9c, 0x8f, 0x52, 0xa1, 0xb2, 0xe2, 0x28, 0x13, 0xf1, 0x18, 0xf1, 0x29, 0x13,
+	0xa1, 0x9d, 0x9b, 0x11, 0xf0, 0x99, 0x4b, 0x26, 0x71, 0xc4, 0x5f, 0xcd, 0xa6, 0x23, 0x2e, 0xed,
+	0xf4, 0xca, 0x10, 0xf9, 0x21, 0x76, 0x13, 0x7c, 0xc1, 0x42, 0x7d, 0x3d, 0xae, 0xed, 0xbe, 0x75,
+	0x45, 0xb0, 0xf6, 0x87, 0x9a, 0xb5, 0xe7, 0xeb, 0xba, 0x8b, 0x5a, 0x23, 0xef, 0x73, 0xe8, 0x54,
+	0x14, 0x84, 0xd8, 0x7e, 0x8b, 0xbd, 0x78, 0xf0, 0x37, 0x1e, 0xff, 0x59, 0xca, 0x65, 0xe9, 0xba,
+	0xc8, 0x65, 0xfd, 0xea, 0xc8, 0x38, 0x3e, 0xb5, 0x73, 0x33, 0x82, 0xf7, 0x4f, 0x07, 0xd6, 0xcb,
+	0x71, 0xf4, 0x7f, 0x99, 0xa3, 0x62, 0x50, 0xc7, 0xa9, 0x32, 0x7a, 0x53, 0xe0, 0x15, 0xc0, 0x62,
+	0x06, 0xdb, 0x58, 0x92, 0xc1, 0x7a, 0xbf, 0x77, 0x60, 0xad, 0x74, 0xcc, 0xb4, 0xfb, 0x44, 0x74,
+	0x99, 0xbb, 0x4f, 0x44, 0x97, 0xba, 0x02, 0x98, 0x66, 0x55, 0x4a, 0x9b, 0x1a, 0x01, 0x6f, 0xb8,
+	0x29, 0x0f, 0xc4, 0x6c, 0x9a, 0xdd, 0xb5, 0x46, 0x42, 0x76, 0xc8, 0xe4, 0x38, 0xcf, 0xe6, 0xb4,
+	0x80, 0x5b, 0x10, 0x4b, 0x31, 0x16, 0x11, 0x0b, 0x6d, 0xa4, 0xe5, 0x32, 0xea, 0xd0, 0xd1, 0x7a,
+	0x7b, 0xcc, 0x1d, 0x9b, 0xcb, 0xde, 0x3f, 0xea, 0xd0, 0xa9, 0xdc, 0x78, 0xf3, 0x15, 0x90, 0x99,
+	0x68, 0x19, 0x22, 0x7d, 0x20, 0x8a, 0xcb, 0x69, 0xba, 0x17, 0x05, 0x83, 0xa2, 0x11, 0x66, 0x26,
+	0xbf, 0x44, 0x83, 0x7e, 0xcc, 0xaa, 0xef, 0xac, 0xdf, 0x56, 0x00, 0x38, 0x1a, 0xf3, 0x7d, 0x9e,
+	0x28, 0x1e, 0x0c, 0xe6, 0xdb, 0x6e, 0x4b, 0x34, 0xe4, 0x09, 0xd4, 0x4f, 0x39, 0xb7, 0xe9, 0xea,
+	0xa3, 0x2b, 0x6f, 0xee, 0x42, 0x3a, 0xe4, 0x9c, 0xa2, 0x89, 0xf7, 0xb5, 0x03, 0xeb, 0x65, 0x94,
+	0x7c, 0x50, 0xaa, 0x59, 0x9d, 0xc5, 0x4b, 0x39, 0xab, 0xa1, 0x4d, 0x2f, 0x23, 0xa7, 0x62, 0xe6,
+	0x9b, 0x14, 0xed, 0x83, 0x9a, 0xde, 0xf6, 0x12, 0x42, 0x3e, 0x85, 0xe6, 0x29, 0xe7, 0xd8, 0x23,
+	0xd3, 0x5b, 0xb7, 0xb1, 0xdb, 0xff, 0x66, 0xb3, 0xec, 0x1f, 0x1a, 0x2b, 0x9a, 0x99, 0xf7, 0x0e,
+	0xa1, 0x69, 0x31, 0xec, 0xaf, 0x65, 0xad, 0x87, 0xee, 0x2d, 0xb2, 0x09, 0x9d, 0xa2, 0x59, 0x81,
+	0x90, 0x43, 0x3c, 0x2c, 0x63, 0x2e, 0x78, 0x70, 0x1c, 0xce, 0xd2, 0xaa, 0xae, 0xe6, 0x3d, 0x83,
+	0x56, 0xb6, 0x18, 0x8c, 0x40, 0x3f, 0x0e, 0xf2, 0x03, 0x8c, 0xbf, 0xf1, 0xbc, 0x04, 0xe2, 0x4c,
+	0xa4, 0x62, 0x24, 0x74, 0x51, 0x6c, 0x4e, 0x55, 0x05, 0xf3, 0x7e, 0x0a, 0x9d, 0x8a, 0x43, 0x74,
+	0x75, 0x54, 0x6e, 0xc0, 0xd8, 0xea, 0x68, 0xde, 0x7b, 0xa5, 0xb6, 0x4c, 0xd1, 0x95, 0xb2, 0x2d,
+	0x69, 0x23, 0xed, 0xfe, 0xa7, 0x01, 0xed, 0x78, 0x64, 0xff, 0x3a, 0x43, 0xde, 0x85, 0x76, 0xfe,
+	0x37, 0x16, 0xa2, 0x87, 0x9c, 0xff, 0x93, 0x8b, 0x57, 0xc9, 0xbf, 0x1e, 0x3b, 0x58, 0x2d, 0xe7,
+	0x3d, 0x7a, 0x63, 0x32, 0xdf, 0xda, 0xf7, 0xee, 0xce, 0xa1, 0x36, 0x79, 0xdd, 0x85, 0xa6, 0x6d,
+	0xd1, 0x13, 0x82, 0x8c, 0x6a, 0x57, 0xdf, 0xbb, 0x53, 0xc1, 0xf2, 0x84, 0xb7, 0x9d, 0xf7, 0xe6,
+	0xcd, 0xd7, 0xe6, 0x5b, 0xfa, 0xde, 0xdd, 0x39, 0xd4, 0x5a, 0x3e, 0x87, 0x8d, 0x6a, 0xef, 0x9d,
+	0xe8, 0x80, 0x5b, 0xda, 0xd4, 0xf7, 0xbc, 0x65, 0x2a, 0x3b, 0xd0, 0x07, 0xd0, 0xca, 0x7a, 0xf0,
+	0x44, 0xcf, 0x71, 0xae, 0x71, 0xef, 0x6d, 0x55, 0x41, 0x6b, 0xf6, 0x0e, 0x34, 0x4c, 0x43, 0x86,
+	0x6c, 0x2e, 0x34, 0x95, 0x3d, 0xb2, 0xd8, 0xaf, 0xc1, 0x09, 0x57, 0x7b, 0x52, 0x66, 0xc2, 0x4b,
+	0x3b, 0x72, 0x9e, 0xb7, 0x4c, 0x65, 0x07, 0xfa, 0x08, 0x3a, 0x95, 0x46, 0x0f, 0x71, 0xed, 0x04,
+	0x17, 0x7a, 0x3f, 0xde, 0xdc, 0xdf, 0x9f, 0x70, 0x0e, 0xd5, 0xfa, 0xdc, 0xcc, 0x61, 0x69, 0xd1,
+	0xef, 0x79, 0xcb, 0x54, 0x76, 0x0e, 0xc7, 0xb0, 0xb9, 0x50, 0xc1, 0x91, 0xfb, 0x57, 0x14, 0x76,
+	0x66, 0xb8, 0x6f, 0x5d, 0x5b, 0xf6, 0x91, 0x8f, 0x61, 0xad, 0x54, 0xe7, 0x90, 0xed, 0x85, 0xc2,
+	0xc7, 0x8c, 0x72, 0xef, 0x8a, 0x82, 0x88, 0xec, 0x43, 0xa7, 0x92, 0xf5, 0x1b, 0xaf, 0x2c, 0x2b,
+	0x23, 0xbc, 0x37, 0x96, 0x68, 0xec, 0x28, 0x7b, 0xb0, 0x5e, 0xce, 0xf2, 0xc9, 0x3d, 0xe3, 0xc0,
+	0x85, 0xa2, 0xc1, 0x73, 0x17, 0x15, 0x66, 0x88, 0x51, 0x43, 0xe7, 0x52, 0xef, 0xfd, 0x77, 0x00,
+	0x20, 0xc3, 0xa4, 0xe4, 0x23, 0x1d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1251,6 +3152,10 @@ type ObcrawlerClient interface {
 	//
 	// Also, search engines MUST respect the expiration and not return any
 	// data which has expired.
+	//
+	// Content blocked by the crawler's classifiers is never streamed.
+	// Quarantined content is only streamed to moderator subscriptions,
+	// which require the moderator authentication token.
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Obcrawler_SubscribeClient, error)
 	// CrawlNode queues up a crawl of the given node.
 	CrawlNode(ctx context.Context, in *CrawlNodeRequest, opts ...grpc.CallOption) (*CrawlNodeResponse, error)
@@ -1260,6 +3165,53 @@ type ObcrawlerClient interface {
 	// UnbanNode will un-ban the provided node. It will not immediately
 	// crawl the node again. If you want that call CrawlNode.
 	UnbanNode(ctx context.Context, in *UnbanNodeRequest, opts ...grpc.CallOption) (*UnbanNodeResponse, error)
+	// QuarantineNode holds the node's data for review. The node is still
+	// crawled but its data is not indexed, pinned or streamed to public
+	// subscribers until a moderator approves it. Rejecting it bans the
+	// node.
+	QuarantineNode(ctx context.Context, in *QuarantineNodeRequest, opts ...grpc.CallOption) (*QuarantineNodeResponse, error)
+	// GetQuota returns the storage quota status of the given node. Nodes
+	// whose data exceeds the quota only have their profile and listings
+	// cached and pinned.
+	GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error)
+	// Search runs a full-text search over the crawled listings or
+	// profiles. Listings may be filtered on their facets and price. The
+	// number of results with each facet value is returned with each page.
+	// Prices are normalized to the crawler's price currency. Expired
+	// data is never returned.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// ListModerators returns the crawled moderators, most recently seen
+	// first. Moderators may be filtered on the currencies and languages
+	// they accept and on their fees. Fixed fees are normalized to the
+	// crawler's price currency. Expired profiles are never returned.
+	ListModerators(ctx context.Context, in *ListModeratorsRequest, opts ...grpc.CallOption) (*ListModeratorsResponse, error)
+	// GetReputation returns the aggregate of the vendor's ratings. Only
+	// ratings with valid vendor and buyer signatures are counted, once
+	// per order, so it does not rely on the stats the vendor reports in
+	// its profile.
+	GetReputation(ctx context.Context, in *GetReputationRequest, opts ...grpc.CallOption) (*Reputation, error)
+	// FindDuplicates returns the clusters of listings copied across
+	// stores. Listings are copies if their text is nearly the same or
+	// they share an image. If a listing is given only the cluster of its
+	// copies is returned.
+	FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error)
+	// FindSimilarImages returns the crawled images whose perceptual hashes
+	// are near those of the given image, along with the peers using them.
+	// Either the CID of a crawled image or the dHash and/or pHash of an
+	// image may be given. Only images cached by the crawler are hashed.
+	FindSimilarImages(ctx context.Context, in *FindSimilarImagesRequest, opts ...grpc.CallOption) (*FindSimilarImagesResponse, error)
+	// ListReviews returns the review queue, oldest first. It holds the
+	// quarantined nodes and the profiles and listings quarantined by the
+	// crawler's classifiers.
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+	// ApproveReview approves a quarantined profile or listing, or the
+	// node itself if no CID is given. The node is crawled again so the
+	// approved data is indexed, pinned and streamed to all subscribers.
+	ApproveReview(ctx context.Context, in *ApproveReviewRequest, opts ...grpc.CallOption) (*ApproveReviewResponse, error)
+	// RejectReview rejects a quarantined profile or listing, which is
+	// then treated as if it were blocked, or bans the node itself if no
+	// CID is given.
+	RejectReview(ctx context.Context, in *RejectReviewRequest, opts ...grpc.CallOption) (*RejectReviewResponse, error)
 }
 
 type obcrawlerClient struct {
@@ -1329,6 +3281,96 @@ func (c *obcrawlerClient) UnbanNode(ctx context.Context, in *UnbanNodeRequest, o
 	return out, nil
 }
 
+func (c *obcrawlerClient) QuarantineNode(ctx context.Context, in *QuarantineNodeRequest, opts ...grpc.CallOption) (*QuarantineNodeResponse, error) {
+	out := new(QuarantineNodeResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/QuarantineNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error) {
+	out := new(GetQuotaResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/GetQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) ListModerators(ctx context.Context, in *ListModeratorsRequest, opts ...grpc.CallOption) (*ListModeratorsResponse, error) {
+	out := new(ListModeratorsResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/ListModerators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) GetReputation(ctx context.Context, in *GetReputationRequest, opts ...grpc.CallOption) (*Reputation, error) {
+	out := new(Reputation)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/GetReputation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error) {
+	out := new(FindDuplicatesResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/FindDuplicates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) FindSimilarImages(ctx context.Context, in *FindSimilarImagesRequest, opts ...grpc.CallOption) (*FindSimilarImagesResponse, error) {
+	out := new(FindSimilarImagesResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/FindSimilarImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	out := new(ListReviewsResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/ListReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) ApproveReview(ctx context.Context, in *ApproveReviewRequest, opts ...grpc.CallOption) (*ApproveReviewResponse, error) {
+	out := new(ApproveReviewResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/ApproveReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *obcrawlerClient) RejectReview(ctx context.Context, in *RejectReviewRequest, opts ...grpc.CallOption) (*RejectReviewResponse, error) {
+	out := new(RejectReviewResponse)
+	err := c.cc.Invoke(ctx, "/pb.obcrawler/RejectReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ObcrawlerServer is the server API for Obcrawler service.
 type ObcrawlerServer interface {
 	// Subscribe is an RPC which streams new profiles and listings as they
@@ -1338,6 +3380,10 @@ type ObcrawlerServer interface {
 	//
 	// Also, search engines MUST respect the expiration and not return any
 	// data which has expired.
+	//
+	// Content blocked by the crawler's classifiers is never streamed.
+	// Quarantined content is only streamed to moderator subscriptions,
+	// which require the moderator authentication token.
 	Subscribe(*SubscribeRequest, Obcrawler_SubscribeServer) error
 	// CrawlNode queues up a crawl of the given node.
 	CrawlNode(context.Context, *CrawlNodeRequest) (*CrawlNodeResponse, error)
@@ -1347,6 +3393,53 @@ type ObcrawlerServer interface {
 	// UnbanNode will un-ban the provided node. It will not immediately
 	// crawl the node again. If you want that call CrawlNode.
 	UnbanNode(context.Context, *UnbanNodeRequest) (*UnbanNodeResponse, error)
+	// QuarantineNode holds the node's data for review. The node is still
+	// crawled but its data is not indexed, pinned or streamed to public
+	// subscribers until a moderator approves it. Rejecting it bans the
+	// node.
+	QuarantineNode(context.Context, *QuarantineNodeRequest) (*QuarantineNodeResponse, error)
+	// GetQuota returns the storage quota status of the given node. Nodes
+	// whose data exceeds the quota only have their profile and listings
+	// cached and pinned.
+	GetQuota(context.Context, *GetQuotaRequest) (*GetQuotaResponse, error)
+	// Search runs a full-text search over the crawled listings or
+	// profiles. Listings may be filtered on their facets and price. The
+	// number of results with each facet value is returned with each page.
+	// Prices are normalized to the crawler's price currency. Expired
+	// data is never returned.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// ListModerators returns the crawled moderators, most recently seen
+	// first. Moderators may be filtered on the currencies and languages
+	// they accept and on their fees. Fixed fees are normalized to the
+	// crawler's price currency. Expired profiles are never returned.
+	ListModerators(context.Context, *ListModeratorsRequest) (*ListModeratorsResponse, error)
+	// GetReputation returns the aggregate of the vendor's ratings. Only
+	// ratings with valid vendor and buyer signatures are counted, once
+	// per order, so it does not rely on the stats the vendor reports in
+	// its profile.
+	GetReputation(context.Context, *GetReputationRequest) (*Reputation, error)
+	// FindDuplicates returns the clusters of listings copied across
+	// stores. Listings are copies if their text is nearly the same or
+	// they share an image. If a listing is given only the cluster of its
+	// copies is returned.
+	FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error)
+	// FindSimilarImages returns the crawled images whose perceptual hashes
+	// are near those of the given image, along with the peers using them.
+	// Either the CID of a crawled image or the dHash and/or pHash of an
+	// image may be given. Only images cached by the crawler are hashed.
+	FindSimilarImages(context.Context, *FindSimilarImagesRequest) (*FindSimilarImagesResponse, error)
+	// ListReviews returns the review queue, oldest first. It holds the
+	// quarantined nodes and the profiles and listings quarantined by the
+	// crawler's classifiers.
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	// ApproveReview approves a quarantined profile or listing, or the
+	// node itself if no CID is given. The node is crawled again so the
+	// approved data is indexed, pinned and streamed to all subscribers.
+	ApproveReview(context.Context, *ApproveReviewRequest) (*ApproveReviewResponse, error)
+	// RejectReview rejects a quarantined profile or listing, which is
+	// then treated as if it were blocked, or bans the node itself if no
+	// CID is given.
+	RejectReview(context.Context, *RejectReviewRequest) (*RejectReviewResponse, error)
 }
 
 // UnimplementedObcrawlerServer can be embedded to have forward compatible implementations.
@@ -1365,6 +3458,36 @@ func (*UnimplementedObcrawlerServer) BanNode(ctx context.Context, req *BanNodeRe
 func (*UnimplementedObcrawlerServer) UnbanNode(ctx context.Context, req *UnbanNodeRequest) (*UnbanNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnbanNode not implemented")
 }
+func (*UnimplementedObcrawlerServer) QuarantineNode(ctx context.Context, req *QuarantineNodeRequest) (*QuarantineNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuarantineNode not implemented")
+}
+func (*UnimplementedObcrawlerServer) GetQuota(ctx context.Context, req *GetQuotaRequest) (*GetQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuota not implemented")
+}
+func (*UnimplementedObcrawlerServer) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedObcrawlerServer) ListModerators(ctx context.Context, req *ListModeratorsRequest) (*ListModeratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModerators not implemented")
+}
+func (*UnimplementedObcrawlerServer) GetReputation(ctx context.Context, req *GetReputationRequest) (*Reputation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReputation not implemented")
+}
+func (*UnimplementedObcrawlerServer) FindDuplicates(ctx context.Context, req *FindDuplicatesRequest) (*FindDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicates not implemented")
+}
+func (*UnimplementedObcrawlerServer) FindSimilarImages(ctx context.Context, req *FindSimilarImagesRequest) (*FindSimilarImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSimilarImages not implemented")
+}
+func (*UnimplementedObcrawlerServer) ListReviews(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (*UnimplementedObcrawlerServer) ApproveReview(ctx context.Context, req *ApproveReviewRequest) (*ApproveReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReview not implemented")
+}
+func (*UnimplementedObcrawlerServer) RejectReview(ctx context.Context, req *RejectReviewRequest) (*RejectReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectReview not implemented")
+}
 
 func RegisterObcrawlerServer(s *grpc.Server, srv ObcrawlerServer) {
 	s.RegisterService(&_Obcrawler_serviceDesc, srv)
@@ -1445,6 +3568,186 @@ func _Obcrawler_UnbanNode_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Obcrawler_QuarantineNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuarantineNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).QuarantineNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/QuarantineNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).QuarantineNode(ctx, req.(*QuarantineNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_GetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).GetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/GetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).GetQuota(ctx, req.(*GetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_ListModerators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModeratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).ListModerators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/ListModerators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).ListModerators(ctx, req.(*ListModeratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_GetReputation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReputationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).GetReputation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/GetReputation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).GetReputation(ctx, req.(*GetReputationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_FindDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).FindDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/FindDuplicates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).FindDuplicates(ctx, req.(*FindDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_FindSimilarImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).FindSimilarImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/FindSimilarImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).FindSimilarImages(ctx, req.(*FindSimilarImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/ListReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_ApproveReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).ApproveReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/ApproveReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).ApproveReview(ctx, req.(*ApproveReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Obcrawler_RejectReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObcrawlerServer).RejectReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.obcrawler/RejectReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObcrawlerServer).RejectReview(ctx, req.(*RejectReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Obcrawler_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.obcrawler",
 	HandlerType: (*ObcrawlerServer)(nil),
@@ -1461,6 +3764,46 @@ var _Obcrawler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UnbanNode",
 			Handler:    _Obcrawler_UnbanNode_Handler,
 		},
+		{
+			MethodName: "QuarantineNode",
+			Handler:    _Obcrawler_QuarantineNode_Handler,
+		},
+		{
+			MethodName: "GetQuota",
+			Handler:    _Obcrawler_GetQuota_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Obcrawler_Search_Handler,
+		},
+		{
+			MethodName: "ListModerators",
+			Handler:    _Obcrawler_ListModerators_Handler,
+		},
+		{
+			MethodName: "GetReputation",
+			Handler:    _Obcrawler_GetReputation_Handler,
+		},
+		{
+			MethodName: "FindDuplicates",
+			Handler:    _Obcrawler_FindDuplicates_Handler,
+		},
+		{
+			MethodName: "FindSimilarImages",
+			Handler:    _Obcrawler_FindSimilarImages_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _Obcrawler_ListReviews_Handler,
+		},
+		{
+			MethodName: "ApproveReview",
+			Handler:    _Obcrawler_ApproveReview_Handler,
+		},
+		{
+			MethodName: "RejectReview",
+			Handler:    _Obcrawler_RejectReview_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
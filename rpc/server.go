@@ -8,6 +8,8 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/op/go-logging"
+	"sort"
+	"time"
 )
 
 var log = logging.MustGetLogger("RPC")
@@ -31,8 +33,12 @@ func NewGrpcServer(crawler Crawler) *GrpcServer {
 //
 // Also, search engines MUST respect the expiration and not return any
 // data which has expired.
+//
+// Content blocked by the crawler's classifiers is never streamed.
+// Quarantined content is only streamed to moderator subscriptions,
+// which require the moderator authentication token.
 func (s *GrpcServer) Subscribe(req *pb.SubscribeRequest, stream pb.Obcrawler_SubscribeServer) error {
-	sub, err := s.crawler.Subscribe()
+	sub, err := s.crawler.Subscribe(req.Moderator)
 	if err != nil {
 		return err
 	}
@@ -41,138 +47,173 @@ func (s *GrpcServer) Subscribe(req *pb.SubscribeRequest, stream pb.Obcrawler_Sub
 	for {
 		select {
 		case obj := <-sub.Out:
-			ts, err := ptypes.TimestampProto(obj.ExpirationDate)
+			ud, err := newUserData(obj.Data, obj.ExpirationDate)
 			if err != nil {
-				log.Errorf("Error creating expiration timestamp: %s", err)
+				log.Errorf("Error converting crawled object: %s", err)
+				continue
+			}
+			if ud == nil {
 				continue
 			}
-			switch o := obj.Data.(type) {
-			case *models.Profile:
-				lastModified, err := ptypes.TimestampProto(o.LastModified)
+			if obj.Reputation != nil {
+				ud.Reputation, err = newReputation(obj.Reputation)
 				if err != nil {
-					log.Errorf("Error creating last modified timestamp: %s", err)
-					continue
+					log.Errorf("Error converting reputation: %s", err)
 				}
-				pro := &pb.UserData_Profile{
-					Profile: &pb.Profile{
-						PeerID:           o.PeerID,
-						Name:             o.Name,
-						Handle:           o.Handle,
-						Location:         o.Location,
-						About:            o.About,
-						ShortDescription: o.ShortDescription,
-						Nsfw:             o.Nsfw,
-						Vendor:           o.Vendor,
-						Moderator:        o.Moderator,
-						Colors: &pb.Profile_ProfileColors{
-							Primary:       o.Colors.Primary,
-							Secondary:     o.Colors.Secondary,
-							Text:          o.Colors.Text,
-							Highlight:     o.Colors.Highlight,
-							HighlightText: o.Colors.HighlightText,
-						},
-						AvatarHashes: &pb.Profile_ImageHashes{
-							Tiny:     o.AvatarHashes.Tiny,
-							Small:    o.AvatarHashes.Small,
-							Medium:   o.AvatarHashes.Medium,
-							Large:    o.AvatarHashes.Large,
-							Original: o.AvatarHashes.Original,
-							Filename: o.AvatarHashes.Filename,
-						},
-						HeaderHashes: &pb.Profile_ImageHashes{
-							Tiny:     o.HeaderHashes.Tiny,
-							Small:    o.HeaderHashes.Small,
-							Medium:   o.HeaderHashes.Medium,
-							Large:    o.HeaderHashes.Large,
-							Original: o.HeaderHashes.Original,
-							Filename: o.HeaderHashes.Filename,
-						},
-						PublicKey:              o.EscrowPublicKey,
-						StoreAndForwardServers: o.StoreAndForwardServers,
-						LastModified:           lastModified,
-					},
+			}
+			if obj.Duplicates != nil {
+				ud.Duplicates, err = newDuplicateCluster(obj.Duplicates)
+				if err != nil {
+					log.Errorf("Error converting duplicate cluster: %s", err)
 				}
-
-				if o.ModeratorInfo != nil {
-					pro.Profile.ModeratorInfo = &pb.Profile_ModeratorInfo{
-						Fee: &pb.Profile_ModeratorInfo_ModeratorFee{
-							Percentage: float32(o.ModeratorInfo.Fee.Percentage),
-						},
-						Description:        o.ModeratorInfo.Description,
-						AcceptedCurrencies: o.ModeratorInfo.AcceptedCurrencies,
-						Languages:          o.ModeratorInfo.Languages,
-						TermsAndConditions: o.ModeratorInfo.TermsAndConditions,
-					}
-					switch o.ModeratorInfo.Fee.FeeType {
-					case models.FixedFee:
-						pro.Profile.ModeratorInfo.Fee.FeeType = pb.Profile_ModeratorInfo_ModeratorFee_FixedFee
-					case models.PercentageFee:
-						pro.Profile.ModeratorInfo.Fee.FeeType = pb.Profile_ModeratorInfo_ModeratorFee_PercentageFee
-					case models.FixedPlusPercentageFee:
-						pro.Profile.ModeratorInfo.Fee.FeeType = pb.Profile_ModeratorInfo_ModeratorFee_FixedPlusPercentageFee
-					}
-					if o.ModeratorInfo.Fee.FixedFee != nil {
-						pro.Profile.ModeratorInfo.Fee.FixedFee = &pb.Profile_CurrencyValue{
-							Amount: o.ModeratorInfo.Fee.FixedFee.Amount.String(),
-						}
-						if o.ModeratorInfo.Fee.FixedFee.Currency != nil {
-							pro.Profile.ModeratorInfo.Fee.FixedFee.Currency = &pb.Profile_Currency{
-								Code:         o.ModeratorInfo.Fee.FixedFee.Currency.Code.String(),
-								Divisibility: uint32(o.ModeratorInfo.Fee.FixedFee.Currency.Divisibility),
-							}
-						}
-					}
+				if sl, ok := obj.Data.(*obpb.SignedListing); ok && len(obj.Duplicates.Listings) > 0 {
+					ud.Copycat = obj.Duplicates.Listings[0].PeerID != sl.GetListing().GetVendorID().GetPeerID()
 				}
-
-				if o.ContactInfo != nil {
-					pro.Profile.ContactInfo = &pb.Profile_ContactInfo{
-						Email:       o.ContactInfo.Email,
-						PhoneNumber: o.ContactInfo.PhoneNumber,
-						Website:     o.ContactInfo.Website,
-					}
-					for _, s := range o.ContactInfo.Social {
-						pro.Profile.ContactInfo.Social = append(pro.Profile.ContactInfo.Social, &pb.Profile_ContactInfo_SocialAccount{
-							Type:     s.Type,
-							Username: s.Username,
-							Proof:    s.Proof,
-						})
-					}
+			}
+			if obj.Stats != nil {
+				ud.VerifiedStats = &pb.VerifiedStats{
+					FollowerCount:  obj.Stats.FollowerCount,
+					FollowingCount: obj.Stats.FollowingCount,
+					ListingCount:   obj.Stats.ListingCount,
+					RatingCount:    obj.Stats.RatingCount,
+					AverageRating:  obj.Stats.AverageRating,
+					Inconsistent:   obj.Stats.Inconsistent,
 				}
+			}
+			ud.Labels = obj.Labels
+			ud.Quarantined = obj.Quarantined
+			if err := stream.Send(ud); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil // client disconnected
+		}
+	}
+}
 
-				if o.Stats != nil {
-					pro.Profile.Stats = &pb.Profile_ProfileStats{
-						FollowerCount:  o.Stats.FollowerCount,
-						FollowingCount: o.Stats.FollowingCount,
-						ListingCount:   o.Stats.ListingCount,
-						PostCount:      o.Stats.PostCount,
-						RatingCount:    o.Stats.RatingCount,
-						AverageRating:  o.Stats.AverageRating,
+// newUserData converts a crawled profile or listing into its protobuf
+// form. It returns nil if the data is neither.
+func newUserData(data interface{}, expiration time.Time) (*pb.UserData, error) {
+	ts, err := ptypes.TimestampProto(expiration)
+	if err != nil {
+		return nil, err
+	}
+	switch o := data.(type) {
+	case *models.Profile:
+		lastModified, err := ptypes.TimestampProto(o.LastModified)
+		if err != nil {
+			return nil, err
+		}
+		pro := &pb.UserData_Profile{
+			Profile: &pb.Profile{
+				PeerID:           o.PeerID,
+				Name:             o.Name,
+				Handle:           o.Handle,
+				Location:         o.Location,
+				About:            o.About,
+				ShortDescription: o.ShortDescription,
+				Nsfw:             o.Nsfw,
+				Vendor:           o.Vendor,
+				Moderator:        o.Moderator,
+				Colors: &pb.Profile_ProfileColors{
+					Primary:       o.Colors.Primary,
+					Secondary:     o.Colors.Secondary,
+					Text:          o.Colors.Text,
+					Highlight:     o.Colors.Highlight,
+					HighlightText: o.Colors.HighlightText,
+				},
+				AvatarHashes: &pb.Profile_ImageHashes{
+					Tiny:     o.AvatarHashes.Tiny,
+					Small:    o.AvatarHashes.Small,
+					Medium:   o.AvatarHashes.Medium,
+					Large:    o.AvatarHashes.Large,
+					Original: o.AvatarHashes.Original,
+					Filename: o.AvatarHashes.Filename,
+				},
+				HeaderHashes: &pb.Profile_ImageHashes{
+					Tiny:     o.HeaderHashes.Tiny,
+					Small:    o.HeaderHashes.Small,
+					Medium:   o.HeaderHashes.Medium,
+					Large:    o.HeaderHashes.Large,
+					Original: o.HeaderHashes.Original,
+					Filename: o.HeaderHashes.Filename,
+				},
+				PublicKey:              o.EscrowPublicKey,
+				StoreAndForwardServers: o.StoreAndForwardServers,
+				LastModified:           lastModified,
+			},
+		}
+
+		if o.ModeratorInfo != nil {
+			pro.Profile.ModeratorInfo = &pb.Profile_ModeratorInfo{
+				Fee: &pb.Profile_ModeratorInfo_ModeratorFee{
+					Percentage: float32(o.ModeratorInfo.Fee.Percentage),
+				},
+				Description:        o.ModeratorInfo.Description,
+				AcceptedCurrencies: o.ModeratorInfo.AcceptedCurrencies,
+				Languages:          o.ModeratorInfo.Languages,
+				TermsAndConditions: o.ModeratorInfo.TermsAndConditions,
+			}
+			switch o.ModeratorInfo.Fee.FeeType {
+			case models.FixedFee:
+				pro.Profile.ModeratorInfo.Fee.FeeType = pb.Profile_ModeratorInfo_ModeratorFee_FixedFee
+			case models.PercentageFee:
+				pro.Profile.ModeratorInfo.Fee.FeeType = pb.Profile_ModeratorInfo_ModeratorFee_PercentageFee
+			case models.FixedPlusPercentageFee:
+				pro.Profile.ModeratorInfo.Fee.FeeType = pb.Profile_ModeratorInfo_ModeratorFee_FixedPlusPercentageFee
+			}
+			if o.ModeratorInfo.Fee.FixedFee != nil {
+				pro.Profile.ModeratorInfo.Fee.FixedFee = &pb.Profile_CurrencyValue{
+					Amount: o.ModeratorInfo.Fee.FixedFee.Amount.String(),
+				}
+				if o.ModeratorInfo.Fee.FixedFee.Currency != nil {
+					pro.Profile.ModeratorInfo.Fee.FixedFee.Currency = &pb.Profile_Currency{
+						Code:         o.ModeratorInfo.Fee.FixedFee.Currency.Code.String(),
+						Divisibility: uint32(o.ModeratorInfo.Fee.FixedFee.Currency.Divisibility),
 					}
 				}
+			}
+		}
 
-				ud := &pb.UserData{
-					Expiration: ts,
-					Data:       pro,
-				}
+		if o.ContactInfo != nil {
+			pro.Profile.ContactInfo = &pb.Profile_ContactInfo{
+				Email:       o.ContactInfo.Email,
+				PhoneNumber: o.ContactInfo.PhoneNumber,
+				Website:     o.ContactInfo.Website,
+			}
+			for _, s := range o.ContactInfo.Social {
+				pro.Profile.ContactInfo.Social = append(pro.Profile.ContactInfo.Social, &pb.Profile_ContactInfo_SocialAccount{
+					Type:     s.Type,
+					Username: s.Username,
+					Proof:    s.Proof,
+				})
+			}
+		}
 
-				if err := stream.Send(ud); err != nil {
-					return err
-				}
-			case *obpb.SignedListing:
-				ud := &pb.UserData{
-					Expiration: ts,
-					Data: &pb.UserData_Listing{
-						Listing: o,
-					},
-				}
-				if err := stream.Send(ud); err != nil {
-					return err
-				}
+		if o.Stats != nil {
+			pro.Profile.Stats = &pb.Profile_ProfileStats{
+				FollowerCount:  o.Stats.FollowerCount,
+				FollowingCount: o.Stats.FollowingCount,
+				ListingCount:   o.Stats.ListingCount,
+				PostCount:      o.Stats.PostCount,
+				RatingCount:    o.Stats.RatingCount,
+				AverageRating:  o.Stats.AverageRating,
 			}
-		case <-stream.Context().Done():
-			return nil // client disconnected
 		}
+
+		return &pb.UserData{
+			Expiration: ts,
+			Data:       pro,
+		}, nil
+	case *obpb.SignedListing:
+		return &pb.UserData{
+			Expiration: ts,
+			Data: &pb.UserData_Listing{
+				Listing: o,
+			},
+		}, nil
 	}
+	return nil, nil
 }
 
 // CrawlNode queues up a crawl of the given node.
@@ -203,3 +244,342 @@ func (s *GrpcServer) UnbanNode(ctx context.Context, req *pb.UnbanNodeRequest) (*
 	}
 	return &pb.UnbanNodeResponse{}, s.crawler.UnbanNode(pid)
 }
+
+// QuarantineNode holds the node's data for review. The node is still
+// crawled but its data is not indexed, pinned or streamed to public
+// subscribers until a moderator approves it. Rejecting it bans the
+// node.
+func (s *GrpcServer) QuarantineNode(ctx context.Context, req *pb.QuarantineNodeRequest) (*pb.QuarantineNodeResponse, error) {
+	pid, err := peer.Decode(req.Peer)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.QuarantineNodeResponse{}, s.crawler.QuarantineNode(pid, req.Reason)
+}
+
+// GetQuota returns the storage quota status of the given node. Nodes
+// whose data exceeds the quota only have their profile and listings
+// cached and pinned.
+func (s *GrpcServer) GetQuota(ctx context.Context, req *pb.GetQuotaRequest) (*pb.GetQuotaResponse, error) {
+	pid, err := peer.Decode(req.Peer)
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.crawler.GetQuota(pid)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetQuotaResponse{
+		GraphSize: status.GraphSize,
+		Quota:     status.Quota,
+		OverQuota: status.OverQuota,
+	}, nil
+}
+
+// Search runs a full-text search over the crawled listings or
+// profiles. Listings may be filtered on their facets and price. The
+// number of results with each facet value is returned with each page.
+// Prices are normalized to the crawler's price currency. Expired
+// data is never returned.
+func (s *GrpcServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	query := &SearchQuery{
+		Query: req.Query,
+		Filters: map[string][]string{
+			FacetCategory:     req.Categories,
+			FacetCurrency:     req.Currencies,
+			FacetCondition:    req.Conditions,
+			FacetContractType: req.ContractTypes,
+			FacetShipsTo:      req.ShipsTo,
+		},
+		MinPrice: req.MinPrice,
+		MaxPrice: req.MaxPrice,
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+	}
+	switch req.Type {
+	case pb.SearchRequest_LISTINGS:
+		query.Type = SearchListings
+	case pb.SearchRequest_PROFILES:
+		query.Type = SearchProfiles
+	}
+	switch req.SortBy {
+	case pb.SearchRequest_RELEVANCE:
+		query.SortBy = SortByRelevance
+	case pb.SearchRequest_NEWEST:
+		query.SortBy = SortByNewest
+	case pb.SearchRequest_TITLE:
+		query.SortBy = SortByTitle
+	case pb.SearchRequest_PRICE_ASC:
+		query.SortBy = SortByPriceAscending
+	case pb.SearchRequest_PRICE_DESC:
+		query.SortBy = SortByPriceDescending
+	}
+
+	results, err := s.crawler.Search(query)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchResponse{
+		Total:         uint32(results.Total),
+		PriceCurrency: results.PriceCurrency,
+	}
+	for _, r := range results.Results {
+		ud, err := newUserData(r.Data, r.ExpirationDate)
+		if err != nil {
+			return nil, err
+		}
+		result := &pb.SearchResponse_Result{
+			Data:  ud,
+			Score: uint32(r.Score),
+		}
+		if r.Price != nil {
+			result.Price = &pb.SearchResponse_Price{
+				Amount:   *r.Price,
+				Currency: results.PriceCurrency,
+			}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	names := make([]string, 0, len(results.Facets))
+	for name := range results.Facets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		facet := &pb.SearchResponse_Facet{Name: name}
+		for value, count := range results.Facets[name] {
+			facet.Values = append(facet.Values, &pb.SearchResponse_Facet_FacetValue{
+				Value: value,
+				Count: uint32(count),
+			})
+		}
+		sort.Slice(facet.Values, func(i, j int) bool {
+			if facet.Values[i].Count != facet.Values[j].Count {
+				return facet.Values[i].Count > facet.Values[j].Count
+			}
+			return facet.Values[i].Value < facet.Values[j].Value
+		})
+		resp.Facets = append(resp.Facets, facet)
+	}
+	return resp, nil
+}
+
+// ListModerators returns the crawled moderators, most recently seen
+// first. Moderators may be filtered on the currencies and languages
+// they accept and on their fees. Fixed fees are normalized to the
+// crawler's price currency. Expired profiles are never returned.
+func (s *GrpcServer) ListModerators(ctx context.Context, req *pb.ListModeratorsRequest) (*pb.ListModeratorsResponse, error) {
+	results, err := s.crawler.ListModerators(&ModeratorQuery{
+		Currency:      req.Currency,
+		Language:      req.Language,
+		MaxPercentage: req.MaxPercentage,
+		MaxFixedFee:   req.MaxFixedFee,
+		SeenWithin:    time.Duration(req.SeenWithin) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListModeratorsResponse{
+		PriceCurrency: results.PriceCurrency,
+	}
+	for _, m := range results.Moderators {
+		ud, err := newUserData(m.Profile, m.ExpirationDate)
+		if err != nil {
+			return nil, err
+		}
+		lastSeen, err := ptypes.TimestampProto(m.LastSeen)
+		if err != nil {
+			return nil, err
+		}
+		mod := &pb.ListModeratorsResponse_Moderator{
+			Data:     ud,
+			LastSeen: lastSeen,
+		}
+		if m.NormalizedFixedFee != nil {
+			mod.FixedFee = &pb.SearchResponse_Price{
+				Amount:   *m.NormalizedFixedFee,
+				Currency: results.PriceCurrency,
+			}
+		}
+		resp.Moderators = append(resp.Moderators, mod)
+	}
+	return resp, nil
+}
+
+// GetReputation returns the aggregate of the vendor's ratings. Only
+// ratings with valid vendor and buyer signatures are counted, once
+// per order, so it does not rely on the stats the vendor reports in
+// its profile.
+func (s *GrpcServer) GetReputation(ctx context.Context, req *pb.GetReputationRequest) (*pb.Reputation, error) {
+	pid, err := peer.Decode(req.Peer)
+	if err != nil {
+		return nil, err
+	}
+	rep, err := s.crawler.GetReputation(pid)
+	if err != nil {
+		return nil, err
+	}
+	return newReputation(rep)
+}
+
+// newReputation converts the reputation to its protobuf message.
+func newReputation(rep *Reputation) (*pb.Reputation, error) {
+	ret := &pb.Reputation{
+		RatingCount:     uint32(rep.RatingCount),
+		Overall:         rep.Overall,
+		Quality:         rep.Quality,
+		Description:     rep.Description,
+		DeliverySpeed:   rep.DeliverySpeed,
+		CustomerService: rep.CustomerService,
+		Score:           rep.Score,
+	}
+	if !rep.LastRated.IsZero() {
+		lastRated, err := ptypes.TimestampProto(rep.LastRated)
+		if err != nil {
+			return nil, err
+		}
+		ret.LastRated = lastRated
+	}
+	return ret, nil
+}
+
+// FindDuplicates returns the clusters of listings copied across
+// stores. Listings are copies if their text is nearly the same or
+// they share an image. If a listing is given only the cluster of its
+// copies is returned.
+func (s *GrpcServer) FindDuplicates(ctx context.Context, req *pb.FindDuplicatesRequest) (*pb.FindDuplicatesResponse, error) {
+	clusters, err := s.crawler.FindDuplicates(&DuplicateQuery{
+		PeerID: req.Peer,
+		Slug:   req.Slug,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := new(pb.FindDuplicatesResponse)
+	for _, cluster := range clusters {
+		c, err := newDuplicateCluster(cluster)
+		if err != nil {
+			return nil, err
+		}
+		resp.Clusters = append(resp.Clusters, c)
+	}
+	return resp, nil
+}
+
+// newDuplicateCluster converts the cluster to its protobuf message.
+func newDuplicateCluster(cluster *DuplicateCluster) (*pb.DuplicateCluster, error) {
+	ret := new(pb.DuplicateCluster)
+	for _, l := range cluster.Listings {
+		firstCrawled, err := ptypes.TimestampProto(l.FirstCrawled)
+		if err != nil {
+			return nil, err
+		}
+		ret.Listings = append(ret.Listings, &pb.DuplicateCluster_Listing{
+			Peer:         l.PeerID,
+			Slug:         l.Slug,
+			Cid:          l.CID,
+			Title:        l.Title,
+			FirstCrawled: firstCrawled,
+			Similarity:   l.Similarity,
+			SharedImages: uint32(l.SharedImages),
+		})
+	}
+	return ret, nil
+}
+
+// FindSimilarImages returns the crawled images whose perceptual hashes
+// are near those of the given image, along with the peers using them.
+// Either the CID of a crawled image or the dHash and/or pHash of an
+// image may be given. Only images cached by the crawler are hashed.
+func (s *GrpcServer) FindSimilarImages(ctx context.Context, req *pb.FindSimilarImagesRequest) (*pb.FindSimilarImagesResponse, error) {
+	query := &ImageQuery{
+		CID:         req.Cid,
+		MaxDistance: int(req.MaxDistance),
+	}
+	if req.DHash != 0 {
+		query.DHash = &req.DHash
+	}
+	if req.PHash != 0 {
+		query.PHash = &req.PHash
+	}
+	images, err := s.crawler.FindSimilarImages(query)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(pb.FindSimilarImagesResponse)
+	for _, img := range images {
+		resp.Images = append(resp.Images, &pb.FindSimilarImagesResponse_Image{
+			Cid:           img.CID,
+			Width:         uint32(img.Width),
+			Height:        uint32(img.Height),
+			DHashDistance: int32(img.DHashDistance),
+			PHashDistance: int32(img.PHashDistance),
+			Peers:         img.PeerIDs,
+		})
+	}
+	return resp, nil
+}
+
+// ListReviews returns the review queue, oldest first. It holds the
+// quarantined nodes and the profiles and listings quarantined by the
+// crawler's classifiers.
+func (s *GrpcServer) ListReviews(ctx context.Context, req *pb.ListReviewsRequest) (*pb.ListReviewsResponse, error) {
+	reviews, err := s.crawler.ListReviews(&ReviewQuery{PeerID: req.Peer})
+	if err != nil {
+		return nil, err
+	}
+	resp := new(pb.ListReviewsResponse)
+	for _, r := range reviews {
+		created, err := ptypes.TimestampProto(r.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		review := &pb.ListReviewsResponse_Review{
+			Peer:    r.PeerID,
+			Cid:     r.CID,
+			Slug:    r.Slug,
+			Labels:  r.Labels,
+			Reason:  r.Reason,
+			Created: created,
+		}
+		if r.Data != nil {
+			review.Data, err = newUserData(r.Data, r.CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+			// Quarantined data isn't tied to an IPNS record.
+			if review.Data != nil {
+				review.Data.Expiration = nil
+				review.Data.Labels = r.Labels
+				review.Data.Quarantined = true
+			}
+		}
+		resp.Reviews = append(resp.Reviews, review)
+	}
+	return resp, nil
+}
+
+// ApproveReview approves a quarantined profile or listing, or the
+// node itself if no CID is given. The node is crawled again so the
+// approved data is indexed, pinned and streamed to all subscribers.
+func (s *GrpcServer) ApproveReview(ctx context.Context, req *pb.ApproveReviewRequest) (*pb.ApproveReviewResponse, error) {
+	pid, err := peer.Decode(req.Peer)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ApproveReviewResponse{}, s.crawler.ApproveReview(pid, req.Cid)
+}
+
+// RejectReview rejects a quarantined profile or listing, which is
+// then treated as if it were blocked, or bans the node itself if no
+// CID is given.
+func (s *GrpcServer) RejectReview(ctx context.Context, req *pb.RejectReviewRequest) (*pb.RejectReviewResponse, error) {
+	pid, err := peer.Decode(req.Peer)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RejectReviewResponse{}, s.crawler.RejectReview(pid, req.Cid)
+}
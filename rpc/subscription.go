@@ -3,14 +3,30 @@ package rpc
 import "time"
 
 // Subscription represents a subscription to the data
-// streamed by the crawler.
+// streamed by the crawler. Only moderator subscriptions
+// receive quarantined objects.
 type Subscription struct {
-	Close func() error
-	Out   chan *Object
+	Close     func() error
+	Out       chan *Object
+	Moderator bool
 }
 
 // Object is streamed to the subscription's out chan.
 type Object struct {
 	Data           interface{}
 	ExpirationDate time.Time
+
+	// Reputation is set for the profiles of vendors.
+	Reputation *Reputation
+
+	// Stats is set for profiles whose stats could be verified.
+	Stats *VerifiedStats
+
+	// Duplicates is set for listings copied across stores.
+	Duplicates *DuplicateCluster
+
+	// Labels are the labels the classifiers gave the content. Quarantined
+	// is set if the content is pending review.
+	Labels      []string
+	Quarantined bool
 }